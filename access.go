@@ -0,0 +1,202 @@
+package go_interpreter
+
+import (
+	"fmt"
+	"github.com/kovalenkong/go-interpreter/functions"
+	"math"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+)
+
+// fieldCache maps struct types to indices of their fields by name.
+var fieldCache sync.Map // reflect.Type -> map[string][]int
+
+// structFields returns fields of struct type t by name. The name is taken
+// from the `formula` tag, then from the `json` tag, then from the field
+// itself; fields tagged "-" are skipped. Fields of embedded structs are
+// promoted unless a shallower field has the same name.
+func structFields(t reflect.Type) map[string][]int {
+	if fields, ok := fieldCache.Load(t); ok {
+		return fields.(map[string][]int)
+	}
+	fields := make(map[string][]int)
+	for _, f := range reflect.VisibleFields(t) {
+		if f.Anonymous && f.Tag.Get("formula") == "" && f.Tag.Get("json") == "" || !exported(t, f.Index) {
+			continue
+		}
+		name := fieldName(f)
+		if name == "-" {
+			continue
+		}
+		if index, ok := fields[name]; ok && len(index) <= len(f.Index) {
+			continue
+		}
+		fields[name] = f.Index
+	}
+	cached, _ := fieldCache.LoadOrStore(t, fields)
+	return cached.(map[string][]int)
+}
+
+// exported reports whether all fields on the path to the field are exported:
+// values promoted through unexported embedded fields can't be read.
+func exported(t reflect.Type, index []int) bool {
+	for i := range index {
+		if !t.FieldByIndex(index[:i+1]).IsExported() {
+			return false
+		}
+	}
+	return true
+}
+
+// fieldName returns name of struct field in formulas.
+func fieldName(f reflect.StructField) string {
+	tag := f.Tag.Get("formula")
+	if tag == "" {
+		tag = f.Tag.Get("json")
+	}
+	if name, _, _ := strings.Cut(tag, ","); name != "" {
+		return name
+	}
+	return f.Name
+}
+
+// member returns field of a struct or value of a map by the name.
+func (e *Interpreter) member(object any, name string) (any, error) {
+	v := indirect(reflect.ValueOf(object))
+	switch v.Kind() {
+	case reflect.Struct:
+		index, ok, err := resolveName(e.folding, structFields(v.Type()), name)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			return nil, fmt.Errorf("%w field '%s' not found in %s", functions.ErrRef, name, v.Type())
+		}
+		field, err := v.FieldByIndexErr(index)
+		if err != nil {
+			// field of a nil embedded struct
+			return functions.Blank, nil
+		}
+		return plainValue(field), nil
+	case reflect.Map:
+		if v.Type().Key().Kind() != reflect.String {
+			return nil, fmt.Errorf("%w keys of %s are not strings", functions.ErrValue, v.Type())
+		}
+		value := v.MapIndex(reflect.ValueOf(name).Convert(v.Type().Key()))
+		if !value.IsValid() && e.folding != FoldExact {
+			keys := make(map[string]reflect.Value, v.Len())
+			for iter := v.MapRange(); iter.Next(); {
+				keys[iter.Key().String()] = iter.Value()
+			}
+			var err error
+			if value, _, err = resolveName(e.folding, keys, name); err != nil {
+				return nil, err
+			}
+		}
+		if !value.IsValid() {
+			return nil, fmt.Errorf("%w key '%s' not found", functions.ErrRef, name)
+		}
+		return plainValue(value), nil
+	}
+	return nil, fmt.Errorf("%w can't access field '%s' of %s", functions.ErrValue, name, typeName(object))
+}
+
+// index returns element of an array by zero-based position or member by name.
+func (e *Interpreter) index(object, key any) (any, error) {
+	if name, ok := key.(string); ok {
+		return e.member(object, name)
+	}
+	i, ok := position(key)
+	if !ok {
+		return nil, fmt.Errorf("%w index should be an integer or a string, got %v", functions.ErrValue, key)
+	}
+	v := indirect(reflect.ValueOf(object))
+	switch v.Kind() {
+	case reflect.Slice, reflect.Array:
+		if i < 0 || i >= int64(v.Len()) {
+			return nil, fmt.Errorf("%w index %d out of range [0; %d)", functions.ErrRef, i, v.Len())
+		}
+		return plainValue(v.Index(int(i))), nil
+	}
+	return nil, fmt.Errorf("%w can't index %s", functions.ErrValue, typeName(object))
+}
+
+// indirect dereferences pointers and interfaces up to a nil or a concrete value.
+func indirect(v reflect.Value) reflect.Value {
+	for (v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface) && !v.IsNil() {
+		v = v.Elem()
+	}
+	return v
+}
+
+// position returns integer value of index.
+func position(key any) (int64, bool) {
+	switch val := key.(type) {
+	case float64:
+		if val != math.Trunc(val) || math.Abs(val) > math.MaxInt32 {
+			return 0, false
+		}
+		return int64(val), true
+	case functions.Decimal:
+		if !val.IsInteger() {
+			return 0, false
+		}
+		return val.Int64()
+	}
+	return 0, false
+}
+
+// plainValue converts Go value to a value of formulas: numbers become
+// float64, slices become arrays and nil values become Blank. Structs
+// and maps are returned as is to access their members.
+func plainValue(v reflect.Value) any {
+	v = indirect(v)
+	if !v.IsValid() || (v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface) && v.IsNil() {
+		return functions.Blank
+	}
+	switch val := v.Interface().(type) {
+	case time.Time, functions.Decimal, functions.Money, functions.Quantity, functions.Table, []float64:
+		return val
+	}
+	switch v.Kind() {
+	case reflect.Bool:
+		return v.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(v.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return float64(v.Uint())
+	case reflect.Float32, reflect.Float64:
+		return v.Float()
+	case reflect.Complex64, reflect.Complex128:
+		return v.Complex()
+	case reflect.String:
+		return v.String()
+	case reflect.Slice, reflect.Array:
+		if numericKind(v.Type().Elem().Kind()) {
+			numbers := make([]float64, v.Len())
+			for i := range numbers {
+				numbers[i] = plainValue(v.Index(i)).(float64)
+			}
+			return numbers
+		}
+		values := make([]any, v.Len())
+		for i := range values {
+			values[i] = plainValue(v.Index(i))
+		}
+		return values
+	}
+	return v.Interface()
+}
+
+// numericKind reports whether values of kind are converted to float64.
+func numericKind(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
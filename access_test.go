@@ -0,0 +1,140 @@
+package go_interpreter
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/kovalenkong/go-interpreter/functions"
+	"reflect"
+	"testing"
+)
+
+type customer struct {
+	Name string `json:"name"`
+	Tier int    `formula:"tier" json:"level"`
+	Note string `json:"-"`
+}
+
+type audit struct {
+	Created string
+}
+
+type line struct {
+	Price float64 `json:"price"`
+	Qty   uint    `json:"qty"`
+}
+
+type order struct {
+	*audit
+	Customer customer          `json:"customer"`
+	Items    []line            `json:"items"`
+	Tags     []string          `json:"tags"`
+	Extra    map[string]any    `json:"extra"`
+	Scores   [3]int32          `json:"scores"`
+	Ref      *customer         `json:"ref"`
+	Sizes    map[string]string `json:"sizes"`
+}
+
+func TestInterpreter_Access(t *testing.T) {
+	var row map[string]any
+	if err := json.Unmarshal([]byte(`{"Unit Price": 2.5, "dims": {"w": [1, 2, 3]}, "none": null}`), &row); err != nil {
+		t.Fatal(err)
+	}
+	interpreter := NewDefaultInterpreter()
+	interpreter.SetVar("order", &order{
+		Customer: customer{Name: "ACME", Tier: 2},
+		Items:    []line{{Price: 10, Qty: 2}, {Price: 5.5, Qty: 4}},
+		Tags:     []string{"new", "vip"},
+		Extra:    map[string]any{"discount": 0.1},
+		Scores:   [3]int32{7, 8, 9},
+		Sizes:    map[string]string{"M": "medium"},
+	})
+	interpreter.SetVar("row", row)
+	cases := map[string]any{
+		`order.customer.tier`:                       2.,
+		`order.customer.name`:                       "ACME",
+		`order.items[0].price`:                      10.,
+		`order.items[1].price * order.items[1].qty`: 22.,
+		`order.items[2 - 1]["qty"]`:                 4.,
+		`order["customer"]["tier"] + 1`:             3.,
+		`order.tags[1]`:                             "vip",
+		`SUM(order.scores)`:                         24.,
+		`order.extra.discount`:                      0.1,
+		`row["Unit Price"] * 4`:                     10.,
+		`SUM(row.dims.w)`:                           6.,
+		`ISBLANK(row.none)`:                         true,
+		`ISBLANK(order.ref)`:                        true,
+		`order.sizes.M`:                             "medium",
+		`CEILING.MATH(order.items[1].price)`:        6.,
+	}
+	for formula, expected := range cases {
+		res, err := interpreter.Execute(formula)
+		if err != nil {
+			t.Fatalf("formula '%s': expected nil error, got %s", formula, err)
+		}
+		if res != expected {
+			t.Errorf("formula '%s': expected %v, got %v", formula, expected, res)
+		}
+		program, err := interpreter.Compile(formula)
+		if err != nil {
+			t.Fatalf("formula '%s': expected nil error, got %s", formula, err)
+		}
+		if res, err := program.Run(); err != nil || res != expected {
+			t.Errorf("compiled formula '%s': expected %v, got %v (%v)", formula, expected, res, err)
+		}
+	}
+	program, err := interpreter.Compile(`order.items[Row].qty * 2`)
+	if err != nil {
+		t.Fatalf("expected nil error, got %s", err)
+	}
+	results, rowErrs := EvalBatch(program, map[string][]float64{"Row": {1, 0, 2}}, 3)
+	if results[0] != 8. || results[1] != 4. || !errors.Is(rowErrs[2], functions.ErrRef) {
+		t.Errorf("batch: expected [8 4 #REF!], got %v %v", results, rowErrs)
+	}
+	errs := map[string]error{
+		`order.customer.level`:  functions.ErrRef,
+		`order.customer.Note`:   functions.ErrRef,
+		`order.items[2]`:        functions.ErrRef,
+		`order.items[-1]`:       functions.ErrRef,
+		`order.items[0,5]`:      functions.ErrValue,
+		`row.missing`:           functions.ErrRef,
+		`order.customer.tier.x`: functions.ErrValue,
+		`order.Created`:         functions.ErrRef,
+		`order.tier[0]`:         functions.ErrRef,
+	}
+	for formula, expected := range errs {
+		if _, err := interpreter.Execute(formula); !errors.Is(err, expected) {
+			t.Errorf("formula '%s': expected %s, got %v", formula, expected, err)
+		}
+	}
+}
+
+func TestInterpreter_AccessFolding(t *testing.T) {
+	interpreter := NewDefaultInterpreter(WithNameFolding(FoldASCII))
+	interpreter.SetVar("Order", order{Customer: customer{Tier: 3}, Sizes: map[string]string{"M": "medium", "m": "mini"}})
+	if res, err := interpreter.Execute(`order.CUSTOMER.Tier`); err != nil || res != 3. {
+		t.Errorf("expected 3, got %v (%v)", res, err)
+	}
+	if res, err := interpreter.Execute(`order.sizes.m`); err != nil || res != "mini" {
+		t.Errorf("expected exact match, got %v (%v)", res, err)
+	}
+	interpreter.SetVar("Order", order{Sizes: map[string]string{"M": "medium", "m": "mini"}})
+	if _, err := interpreter.Execute(`order.sizes.Sizes`); err == nil {
+		t.Errorf("expected error for missing key")
+	}
+}
+
+func TestStructFields(t *testing.T) {
+	fields := structFields(reflect.TypeOf(order{}))
+	if index := fields["customer"]; !reflect.DeepEqual(index, []int{1}) {
+		t.Errorf("customer: expected [1], got %v", index)
+	}
+	if _, ok := fields["Created"]; ok {
+		t.Errorf("field promoted through unexported embedded struct should be skipped")
+	}
+	if fields["tags"] == nil || fields["Customer"] != nil {
+		t.Errorf("fields should be named by tags, got %v", fields)
+	}
+	if again := structFields(reflect.TypeOf(order{})); reflect.ValueOf(again).Pointer() != reflect.ValueOf(fields).Pointer() {
+		t.Errorf("fields of the type should be cached")
+	}
+}
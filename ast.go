@@ -48,6 +48,20 @@ type Comparison struct {
 	Op    TokenType
 }
 
+// A Member node represents a field or key access: Object.Name.
+type Member struct {
+	pos    uint
+	Object Node
+	Name   string
+}
+
+// An Index node represents an index expression: Object[Index].
+type Index struct {
+	pos    uint
+	Object Node
+	Index  Node
+}
+
 func (l *Literal) Pos() uint {
 	return l.pos
 }
@@ -70,3 +84,11 @@ func (u *UnaryExpr) Pos() uint {
 func (f *Function) Pos() uint {
 	return f.pos
 }
+
+func (m *Member) Pos() uint {
+	return m.pos
+}
+
+func (i *Index) Pos() uint {
+	return i.pos
+}
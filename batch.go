@@ -0,0 +1,379 @@
+package go_interpreter
+
+import (
+	"fmt"
+	"math"
+)
+
+// batchColumn is an intermediate result of batch evaluation: one value per row.
+// Only the slice matching kind is set.
+type batchColumn struct {
+	kind    valueKind
+	numbers []float64
+	bools   []bool
+	values  []any
+}
+
+func (c *batchColumn) value(row int) any {
+	switch c.kind {
+	case kindNumber:
+		return c.numbers[row]
+	case kindBool:
+		return c.bools[row]
+	default:
+		return c.values[row]
+	}
+}
+
+type batch struct {
+	interpreter *Interpreter
+	columns     map[string][]float64
+	n           int
+	errs        []error
+}
+
+// EvalBatch evaluates program for n rows at once. Variables found in columns
+// take their value from the row, other variables and functions are taken
+// from the program interpreter.
+//
+// Arithmetic and comparisons over numbers are evaluated column-at-a-time,
+// functions, non-numeric operands and numbers in decimal mode are evaluated
+// row by row. An error in a row doesn't stop evaluation of other rows: the
+// row result is nil and its error is reported at the same index of the
+// returned errors. Negative n is reported as the only error without results.
+func EvalBatch(program *Program, columns map[string][]float64, n int) ([]any, []error) {
+	if n < 0 {
+		return nil, []error{fmt.Errorf("negative number of rows: %d", n)}
+	}
+	b := &batch{
+		interpreter: program.interpreter,
+		columns:     columns,
+		n:           n,
+		errs:        make([]error, n),
+	}
+	col, err := b.eval(program.node)
+	results := make([]any, n)
+	for i := range results {
+		if err != nil {
+			b.errs[i] = err
+		}
+		if b.errs[i] != nil {
+			continue
+		}
+		results[i] = col.value(i)
+	}
+	return results, b.errs
+}
+
+func (b *batch) eval(node Node) (batchColumn, error) {
+	switch n := node.(type) {
+	case *BinaryExpr:
+		return b.evalBinaryExpr(n)
+	case *Literal:
+		val, err := b.interpreter.evalLiteral(n)
+		if err != nil {
+			return batchColumn{}, err
+		}
+		return b.broadcast(val), nil
+	case *Ident:
+		return b.evalIdent(n), nil
+	case *Function:
+		return b.evalFunction(n)
+	case *UnaryExpr:
+		return b.evalUnary(n)
+	case *Comparison:
+		return b.evalComparison(n)
+	case *Member:
+		return b.evalMember(n)
+	case *Index:
+		return b.evalIndex(n)
+	default:
+		return batchColumn{}, fmt.Errorf("unknown node type: %T", node)
+	}
+}
+
+// broadcast repeats scalar value for every row.
+func (b *batch) broadcast(value any) batchColumn {
+	switch val := value.(type) {
+	case float64:
+		numbers := make([]float64, b.n)
+		for i := range numbers {
+			numbers[i] = val
+		}
+		return batchColumn{kind: kindNumber, numbers: numbers}
+	case bool:
+		bools := make([]bool, b.n)
+		for i := range bools {
+			bools[i] = val
+		}
+		return batchColumn{kind: kindBool, bools: bools}
+	default:
+		values := make([]any, b.n)
+		for i := range values {
+			values[i] = val
+		}
+		return batchColumn{values: values}
+	}
+}
+
+func (b *batch) evalIdent(node *Ident) batchColumn {
+	data, ok, err := resolveName(b.interpreter.folding, b.columns, node.Name)
+	if err != nil {
+		return b.failAll(err)
+	}
+	if ok {
+		if len(data) >= b.n {
+			return batchColumn{kind: kindNumber, numbers: data[:b.n]}
+		}
+		numbers := make([]float64, b.n)
+		copy(numbers, data)
+		for i := len(data); i < b.n; i++ {
+			b.fail(i, fmt.Errorf("column '%s' has no row %d", node.Name, i))
+		}
+		return batchColumn{kind: kindNumber, numbers: numbers}
+	}
+	val, err := b.interpreter.lookupVar(node.Name)
+	if err != nil {
+		return b.failAll(err)
+	}
+	return b.broadcast(val)
+}
+
+// fail records the first error of the row.
+func (b *batch) fail(row int, err error) {
+	if b.errs[row] == nil {
+		b.errs[row] = err
+	}
+}
+
+// failAll records err for every row and returns an empty column.
+func (b *batch) failAll(err error) batchColumn {
+	for i := 0; i < b.n; i++ {
+		b.fail(i, err)
+	}
+	return batchColumn{values: make([]any, b.n)}
+}
+
+// perRow evaluates fn for every row that has no error yet.
+func (b *batch) perRow(fn func(row int) (any, error)) batchColumn {
+	values := make([]any, b.n)
+	for i := range values {
+		if b.errs[i] != nil {
+			continue
+		}
+		val, err := fn(i)
+		if err != nil {
+			b.fail(i, err)
+			continue
+		}
+		values[i] = val
+	}
+	return batchColumn{values: values}
+}
+
+// numeric reports whether columns can be processed as float64 vectors.
+// In decimal mode numbers are processed row by row as decimals.
+func (b *batch) numeric(columns ...batchColumn) bool {
+	if b.interpreter.decimal != nil {
+		return false
+	}
+	for _, column := range columns {
+		if column.kind != kindNumber {
+			return false
+		}
+	}
+	return true
+}
+
+func (b *batch) evalBinaryExpr(node *BinaryExpr) (batchColumn, error) {
+	left, err := b.eval(node.Left)
+	if err != nil {
+		return batchColumn{}, err
+	}
+	right, err := b.eval(node.Right)
+	if err != nil {
+		return batchColumn{}, err
+	}
+	if !b.numeric(left, right) {
+		return b.perRow(func(row int) (any, error) {
+			return b.interpreter.binaryOp(node.Op, left.value(row), right.value(row))
+		}), nil
+	}
+
+	l, r := left.numbers, right.numbers
+	res := make([]float64, b.n)
+	switch node.Op {
+	case ADD:
+		for i := range res {
+			res[i] = l[i] + r[i]
+		}
+	case SUB:
+		for i := range res {
+			res[i] = l[i] - r[i]
+		}
+	case MUL:
+		for i := range res {
+			res[i] = l[i] * r[i]
+		}
+	case DIV:
+		for i := range res {
+			if r[i] == 0 {
+				b.fail(i, fmt.Errorf("zero division error"))
+				continue
+			}
+			res[i] = l[i] / r[i]
+		}
+	case EXP:
+		for i := range res {
+			res[i] = math.Pow(l[i], r[i])
+		}
+	default:
+		return batchColumn{}, fmt.Errorf("unknown binary operation: %d", node.Op)
+	}
+	return batchColumn{kind: kindNumber, numbers: res}, nil
+}
+
+func (b *batch) evalUnary(node *UnaryExpr) (batchColumn, error) {
+	operand, err := b.eval(node.Left)
+	if err != nil {
+		return batchColumn{}, err
+	}
+	if !b.numeric(operand) {
+		return b.perRow(func(row int) (any, error) {
+			return b.interpreter.unaryOp(node.Op, operand.value(row))
+		}), nil
+	}
+	switch node.Op {
+	case ADD:
+		return operand, nil
+	case SUB:
+		res := make([]float64, b.n)
+		for i, val := range operand.numbers {
+			res[i] = -val
+		}
+		return batchColumn{kind: kindNumber, numbers: res}, nil
+	default:
+		return batchColumn{}, fmt.Errorf("unknown unary operator: %d", node.Op)
+	}
+}
+
+func (b *batch) evalComparison(node *Comparison) (batchColumn, error) {
+	left, err := b.eval(node.Left)
+	if err != nil {
+		return batchColumn{}, err
+	}
+	right, err := b.eval(node.Right)
+	if err != nil {
+		return batchColumn{}, err
+	}
+	if !b.numeric(left, right) {
+		return b.perRow(func(row int) (any, error) {
+			return b.interpreter.compareValues(node.Op, left.value(row), right.value(row))
+		}), nil
+	}
+
+	l, r := left.numbers, right.numbers
+	res := make([]bool, b.n)
+	switch node.Op {
+	case EQ:
+		for i := range res {
+			res[i] = l[i] == r[i]
+		}
+	case LT:
+		for i := range res {
+			res[i] = l[i] < r[i]
+		}
+	case GT:
+		for i := range res {
+			res[i] = l[i] > r[i]
+		}
+	case LTE:
+		for i := range res {
+			res[i] = l[i] <= r[i]
+		}
+	case GTE:
+		for i := range res {
+			res[i] = l[i] >= r[i]
+		}
+	default:
+		return batchColumn{}, fmt.Errorf("unexpected comparison token: %d", node.Op)
+	}
+	return batchColumn{kind: kindBool, bools: res}, nil
+}
+
+func (b *batch) evalMember(node *Member) (batchColumn, error) {
+	object, err := b.eval(node.Object)
+	if err != nil {
+		return batchColumn{}, err
+	}
+	return b.perRow(func(row int) (any, error) {
+		return b.interpreter.member(object.value(row), node.Name)
+	}), nil
+}
+
+func (b *batch) evalIndex(node *Index) (batchColumn, error) {
+	object, err := b.eval(node.Object)
+	if err != nil {
+		return batchColumn{}, err
+	}
+	index, err := b.eval(node.Index)
+	if err != nil {
+		return batchColumn{}, err
+	}
+	return b.perRow(func(row int) (any, error) {
+		return b.interpreter.index(object.value(row), index.value(row))
+	}), nil
+}
+
+func (b *batch) evalFunction(node *Function) (batchColumn, error) {
+	function, def, err := b.interpreter.resolveFunction(node.Name)
+	if err != nil {
+		return batchColumn{}, err
+	}
+	if catchesErrors(def) {
+		return b.evalCatching(node, function), nil
+	}
+	args := make([]batchColumn, len(node.Args))
+	for i, arg := range node.Args {
+		col, err := b.eval(arg)
+		if err != nil {
+			return batchColumn{}, err
+		}
+		args[i] = col
+	}
+	return b.perRow(func(row int) (any, error) {
+		values := make([]any, len(args))
+		for i := range args {
+			values[i] = args[i].value(row)
+		}
+		return function(values...)
+	}), nil
+}
+
+// evalCatching evaluates function which catches errors of arguments:
+// errors of an argument in a row are passed to the function as values.
+func (b *batch) evalCatching(node *Function, function Func) batchColumn {
+	errs := b.errs
+	args := make([]batchColumn, len(node.Args))
+	argErrs := make([][]error, len(node.Args))
+	for i, arg := range node.Args {
+		b.errs = make([]error, b.n)
+		col, err := b.eval(arg)
+		if err != nil {
+			col = b.failAll(err)
+		}
+		args[i], argErrs[i] = col, b.errs
+	}
+	b.errs = errs
+	return b.perRow(func(row int) (any, error) {
+		values := make([]any, len(args))
+		for i := range args {
+			if err := argErrs[i][row]; err != nil {
+				values[i] = err
+				continue
+			}
+			values[i] = args[i].value(row)
+		}
+		return function(values...)
+	})
+}
@@ -0,0 +1,102 @@
+package go_interpreter
+
+import (
+	"github.com/kovalenkong/go-interpreter/functions"
+	"testing"
+)
+
+func TestEvalBatch(t *testing.T) {
+	interpreter := NewInterpreter(
+		map[string]any{"K": 2.0},
+		map[string]Func{"Sum": functions.Sum},
+	)
+	columns := map[string][]float64{
+		"X": {1, 2, 3, 4},
+		"Y": {1, 0, 2, 4},
+	}
+	cases := map[string][]any{
+		`X * K + 1`:      {3., 5., 7., 9.},
+		`X / Y`:          {1., nil, 1.5, 1.},
+		`-X`:             {-1., -2., -3., -4.},
+		`X >= Y`:         {true, true, true, true},
+		`X = Y`:          {true, false, false, true},
+		`Sum(X;Y;K) * 2`: {8., 8., 14., 20.},
+		`Sum(X / Y)`:     {1., nil, 1.5, 1.},
+	}
+	for formula, expected := range cases {
+		program, err := interpreter.Compile(formula)
+		if err != nil {
+			t.Fatalf("formula '%s': expected nil error, got %s", formula, err)
+		}
+		results, errs := EvalBatch(program, columns, len(expected))
+		for i, res := range results {
+			if expected[i] == nil {
+				if errs[i] == nil {
+					t.Errorf("formula '%s' row %d: expected error, got nil", formula, i)
+				}
+				continue
+			}
+			if errs[i] != nil {
+				t.Errorf("formula '%s' row %d: expected nil error, got %s", formula, i, errs[i])
+				continue
+			}
+			if res != expected[i] {
+				t.Errorf("formula '%s' row %d: expected '%v', got '%v'", formula, i, expected[i], res)
+			}
+		}
+	}
+}
+
+func TestEvalBatch_Errors(t *testing.T) {
+	interpreter := NewInterpreter(map[string]any{}, nil)
+	program, err := interpreter.Compile(`X + Missing`)
+	if err != nil {
+		t.Fatalf("expected nil error, got %s", err)
+	}
+	_, errs := EvalBatch(program, map[string][]float64{"X": {1, 2}}, 2)
+	for i, err := range errs {
+		if err == nil {
+			t.Errorf("row %d: expected error for missing variable", i)
+		}
+	}
+
+	program, err = interpreter.Compile(`X * 2`)
+	if err != nil {
+		t.Fatalf("expected nil error, got %s", err)
+	}
+	results, errs := EvalBatch(program, map[string][]float64{"X": {1}}, 2)
+	if errs[0] != nil || results[0] != 2. {
+		t.Errorf("row 0: expected 2, got %v (%v)", results[0], errs[0])
+	}
+	if errs[1] == nil {
+		t.Errorf("row 1: expected error for short column")
+	}
+
+	results, errs = EvalBatch(program, map[string][]float64{"X": {1}}, -1)
+	if results != nil || len(errs) != 1 || errs[0] == nil {
+		t.Errorf("expected error for negative number of rows, got %v (%v)", results, errs)
+	}
+}
+
+func TestEvalBatch_CatchErrors(t *testing.T) {
+	interpreter := NewDefaultInterpreter()
+	program, err := interpreter.Compile(`IFERROR(X / Y; -1) + X`)
+	if err != nil {
+		t.Fatalf("expected nil error, got %s", err)
+	}
+	columns := map[string][]float64{
+		"X": {1, 2, 3},
+		"Y": {1, 0, 2},
+	}
+	results, errs := EvalBatch(program, columns, 3)
+	expected := []any{2., 1., 4.5}
+	for i, res := range results {
+		if errs[i] != nil {
+			t.Errorf("row %d: expected nil error, got %s", i, errs[i])
+			continue
+		}
+		if res != expected[i] {
+			t.Errorf("row %d: expected '%v', got '%v'", i, expected[i], res)
+		}
+	}
+}
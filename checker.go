@@ -0,0 +1,338 @@
+package go_interpreter
+
+import (
+	"fmt"
+	"github.com/kovalenkong/go-interpreter/functions"
+	"strings"
+)
+
+// Schema declares types of variables and signatures of functions
+// available to a formula.
+type Schema struct {
+	Variables map[string]functions.Type
+	Functions map[string]functions.Signature
+	Folding   NameFolding
+}
+
+// A TypeError describes a type error found by Check.
+type TypeError struct {
+	Pos uint
+	Msg string
+}
+
+func (e *TypeError) Error() string {
+	return fmt.Sprintf("position %d: %s", e.Pos, e.Msg)
+}
+
+// TypeErrors is a list of all type errors found in a formula.
+type TypeErrors []*TypeError
+
+func (e TypeErrors) Error() string {
+	messages := make([]string, len(e))
+	for i, err := range e {
+		messages[i] = err.Error()
+	}
+	return strings.Join(messages, "; ")
+}
+
+// Check statically checks node against schema and returns every type error found.
+// Operands of unknown type (functions.TypeAny) are accepted everywhere.
+func Check(node Node, schema *Schema) TypeErrors {
+	c := &checker{schema: schema}
+	c.check(node)
+	return c.errs
+}
+
+// SetSignature declares signature of the function, it's used by Schema.
+func (e *Interpreter) SetSignature(name string, signature functions.Signature) {
+	if e.signatures == nil {
+		e.signatures = map[string]functions.Signature{}
+	}
+	e.signatures[name] = signature
+}
+
+// Schema returns schema of the interpreter. Types of variables are taken from
+// their current values, signatures of registry functions are taken from their
+// definitions, other functions without declared signature accept any arguments.
+func (e *Interpreter) Schema() *Schema {
+	schema := &Schema{
+		Variables: make(map[string]functions.Type, len(e.variables)),
+		Functions: make(map[string]functions.Signature, len(e.functions)),
+		Folding:   e.folding,
+	}
+	if e.env != nil {
+		for name := range e.env.meta.fields {
+			value, _ := e.env.Var(name)
+			schema.Variables[name] = functions.TypeOf(value)
+		}
+	}
+	for name, value := range e.variables {
+		schema.Variables[name] = functions.TypeOf(value)
+	}
+	if e.registry != nil {
+		for name, def := range e.registry.All() {
+			schema.Functions[name] = def.Signature
+		}
+	}
+	if e.env != nil {
+		for name := range e.env.meta.methods {
+			schema.Functions[name] = functions.Signature{
+				Variadic: []functions.Type{functions.TypeAny},
+				Return:   functions.TypeAny,
+			}
+		}
+	}
+	for name := range e.functions {
+		signature, ok := e.signatures[name]
+		if !ok {
+			signature = functions.Signature{
+				Variadic: []functions.Type{functions.TypeAny},
+				Return:   functions.TypeAny,
+			}
+		}
+		schema.Functions[name] = signature
+	}
+	return schema
+}
+
+// Validate parses formula and checks it against the interpreter schema without executing it.
+func (e *Interpreter) Validate(formula string) error {
+	node, err := e.parse(formula)
+	if err != nil {
+		return err
+	}
+	if errs := Check(node, e.Schema()); len(errs) != 0 {
+		return errs
+	}
+	return nil
+}
+
+type checker struct {
+	schema *Schema
+	errs   TypeErrors
+}
+
+func (c *checker) errorf(pos uint, format string, args ...any) {
+	c.errs = append(c.errs, &TypeError{
+		Pos: pos,
+		Msg: fmt.Sprintf(format, args...),
+	})
+}
+
+func (c *checker) check(node Node) functions.Type {
+	switch n := node.(type) {
+	case *BinaryExpr:
+		return c.checkBinaryExpr(n)
+	case *Literal:
+		switch n.Kind {
+		case NUMBER:
+			return functions.TypeNumber
+		case STRING:
+			return functions.TypeString
+		}
+		c.errorf(n.Pos(), "unknown literal type: %d", n.Kind)
+		return functions.TypeAny
+	case *Ident:
+		t, ok, err := resolveName(c.schema.Folding, c.schema.Variables, n.Name)
+		if err != nil {
+			c.errorf(n.Pos(), "%s", err)
+			return functions.TypeAny
+		}
+		if !ok {
+			c.errorf(n.Pos(), "variable '%s' not found", n.Name)
+			return functions.TypeAny
+		}
+		return t
+	case *Function:
+		return c.checkFunction(n)
+	case *UnaryExpr:
+		numeric := functions.TypeNumber | functions.TypeMoney | functions.TypeQuantity | functions.TypeComplex
+		t := c.check(n.Left)
+		if !numeric.Accepts(t) {
+			c.errorf(n.Left.Pos(), "operand of unary %s: expected %s, got %s", opName(n.Op), numeric, t)
+			return functions.TypeNumber
+		}
+		return t & numeric
+	case *Comparison:
+		c.checkComparison(n)
+		return functions.TypeBool
+	case *Member:
+		// types of fields are known only at run time
+		c.check(n.Object)
+		return functions.TypeAny
+	case *Index:
+		c.check(n.Object)
+		c.expect(n.Index, functions.TypeNumber|functions.TypeString, "index")
+		return functions.TypeAny
+	default:
+		c.errorf(node.Pos(), "unknown node type: %T", node)
+		return functions.TypeAny
+	}
+}
+
+// expect checks node and reports an error if its type is not accepted by expected.
+func (c *checker) expect(node Node, expected functions.Type, format string, args ...any) {
+	t := c.check(node)
+	if !expected.Accepts(t) {
+		c.errorf(node.Pos(), "%s: expected %s, got %s", fmt.Sprintf(format, args...), expected, t)
+	}
+}
+
+func (c *checker) checkBinaryExpr(node *BinaryExpr) functions.Type {
+	allowed := functions.TypeNumber | functions.TypeQuantity | functions.TypeComplex
+	switch node.Op {
+	case ADD, SUB:
+		allowed |= functions.TypeDate | functions.TypeMoney
+	case MUL, DIV:
+		allowed |= functions.TypeMoney
+	}
+	left := c.check(node.Left)
+	if !allowed.Accepts(left) {
+		c.errorf(node.Left.Pos(), "operand of %s: expected %s, got %s", opName(node.Op), allowed, left)
+	}
+	right := c.check(node.Right)
+	if !allowed.Accepts(right) {
+		c.errorf(node.Right.Pos(), "operand of %s: expected %s, got %s", opName(node.Op), allowed, right)
+	}
+
+	number := func(t functions.Type) bool { return functions.TypeNumber.Accepts(t) }
+	date := func(t functions.Type) bool { return functions.TypeDate.Accepts(t) }
+	money := func(t functions.Type) bool { return functions.TypeMoney.Accepts(t) }
+	quantity := func(t functions.Type) bool { return functions.TypeQuantity.Accepts(t) }
+	var result functions.Type
+	if functions.TypeComplex.Accepts(left) || functions.TypeComplex.Accepts(right) {
+		// numbers are promoted to complex
+		result |= functions.TypeComplex
+	}
+	switch node.Op {
+	case ADD:
+		if number(left) && number(right) {
+			result |= functions.TypeNumber
+		}
+		if date(left) && number(right) || number(left) && date(right) {
+			result |= functions.TypeDate
+		}
+		if money(left) && money(right) {
+			result |= functions.TypeMoney
+		}
+		if quantity(left) && quantity(right) {
+			result |= functions.TypeQuantity
+		}
+	case SUB:
+		if number(left) && number(right) || date(left) && date(right) {
+			result |= functions.TypeNumber
+		}
+		if date(left) && number(right) {
+			result |= functions.TypeDate
+		}
+		if money(left) && money(right) {
+			result |= functions.TypeMoney
+		}
+		if quantity(left) && quantity(right) {
+			result |= functions.TypeQuantity
+		}
+	case MUL:
+		if number(left) && number(right) {
+			result |= functions.TypeNumber
+		}
+		if money(left) && number(right) || number(left) && money(right) {
+			result |= functions.TypeMoney
+		}
+		if quantity(left) || quantity(right) {
+			// units of quantities may cancel out leaving a plain number
+			result |= functions.TypeQuantity | functions.TypeNumber
+		}
+	case DIV:
+		if number(left) && number(right) || money(left) && money(right) {
+			result |= functions.TypeNumber
+		}
+		if money(left) && number(right) {
+			result |= functions.TypeMoney
+		}
+		if quantity(left) || quantity(right) {
+			result |= functions.TypeQuantity | functions.TypeNumber
+		}
+	case EXP:
+		if quantity(left) {
+			result |= functions.TypeQuantity | functions.TypeNumber
+		}
+	}
+	if result == 0 {
+		return functions.TypeNumber
+	}
+	return result
+}
+
+func (c *checker) checkComparison(node *Comparison) {
+	left := c.check(node.Left)
+	right := c.check(node.Right)
+	if node.Op == EQ {
+		return
+	}
+	ordered := functions.TypeNumber | functions.TypeString | functions.TypeDate | functions.TypeMoney | functions.TypeQuantity
+	if !ordered.Accepts(left) {
+		c.errorf(node.Left.Pos(), "operand of %s: expected %s, got %s", opName(node.Op), ordered, left)
+		return
+	}
+	if !ordered.Accepts(right) {
+		c.errorf(node.Right.Pos(), "operand of %s: expected %s, got %s", opName(node.Op), ordered, right)
+		return
+	}
+	if !left.Accepts(right) {
+		c.errorf(node.Pos(), "can't compare %s and %s", left, right)
+	}
+}
+
+func (c *checker) checkFunction(node *Function) functions.Type {
+	signature, ok, err := resolveName(c.schema.Folding, c.schema.Functions, node.Name)
+	if err == nil && !ok {
+		err = fmt.Errorf("function '%s' not found", node.Name)
+	}
+	if err != nil {
+		c.errorf(node.Pos(), "%s", err)
+		for _, arg := range node.Args {
+			c.check(arg)
+		}
+		return functions.TypeAny
+	}
+	n := len(node.Args)
+	if !signature.ValidArity(n) {
+		c.errorf(node.Pos(), "function '%s' expects %s args, got %d", node.Name, signature.Arity(), n)
+		for _, arg := range node.Args {
+			c.check(arg)
+		}
+		return signature.Return
+	}
+	for i, arg := range node.Args {
+		expected, _ := signature.ParamType(i, n)
+		c.expect(arg, expected, "argument %d of '%s'", i+1, node.Name)
+	}
+	return signature.Return
+}
+
+func opName(op TokenType) string {
+	switch op {
+	case ADD:
+		return "+"
+	case SUB:
+		return "-"
+	case MUL:
+		return "*"
+	case DIV:
+		return "/"
+	case EXP:
+		return "^"
+	case EQ:
+		return "="
+	case LT:
+		return "<"
+	case GT:
+		return ">"
+	case LTE:
+		return "<="
+	case GTE:
+		return ">="
+	default:
+		return fmt.Sprintf("operator %d", op)
+	}
+}
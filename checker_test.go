@@ -0,0 +1,122 @@
+package go_interpreter
+
+import (
+	"github.com/kovalenkong/go-interpreter/functions"
+	"testing"
+)
+
+func prepareSchema() *Schema {
+	return &Schema{
+		Variables: map[string]functions.Type{
+			"X": functions.TypeNumber,
+			"S": functions.TypeString,
+			"A": functions.TypeArray,
+			"U": functions.TypeAny,
+			"M": functions.TypeMoney,
+			"Q": functions.TypeQuantity,
+			"C": functions.TypeComplex,
+		},
+		Functions: map[string]functions.Signature{
+			"Sum":   functions.Signatures["Sum"],
+			"If":    functions.Signatures["If"],
+			"Round": functions.Signatures["Round"],
+			"Ifs":   functions.Signatures["Ifs"],
+		},
+	}
+}
+
+func TestCheck(t *testing.T) {
+	schema := prepareSchema()
+	cases := map[string]int{
+		`1 + X`:                      0,
+		`Sum(1;X;A) * 2`:             0,
+		`If(X > 1; "a"; 2)`:          0,
+		`Round(X)`:                   0,
+		`Round(X; 2)`:                0,
+		`Ifs(X > 1; 1; X < 0; 2)`:    0,
+		`U + 1`:                      0,
+		`S < "b"`:                    0,
+		`S = 1`:                      0,
+		`-M * 2 + Sum(M; M) / X`:     0,
+		`Round(M; 2) > M`:            0,
+		`M ^ 2`:                      1,
+		`(Q + Q) / Q * X > Q ^ 2`:    0,
+		`-Q`:                         0,
+		`(C + 1) ^ 0,5 * -C = C`:     0,
+		`Round(U + 1)`:               0,
+		`U.items[X].price * 2`:       0,
+		`U[S] + Y.a`:                 1,
+		`U[X > 1]`:                   1,
+		`Round(C + 1)`:               1,
+		`C < 1`:                      1,
+		`"a" + 1`:                    1,
+		`If(1;2;3)`:                  1,
+		`Round(1;2;3)`:               1,
+		`Ifs(X > 1; 1; X < 0)`:       1,
+		`S < 1`:                      1,
+		`-S`:                         1,
+		`Unknown(1)`:                 1,
+		`Y + 1`:                      1,
+		`"a" * S + Sum("b"; X > 1)`:  4,
+		`If("a"; Round("b"); 1 + S)`: 3,
+	}
+	for formula, count := range cases {
+		node, err := prepareExecutor(nil).parse(formula)
+		if err != nil {
+			t.Fatalf("formula '%s': expected nil error, got %s", formula, err)
+		}
+		errs := Check(node, schema)
+		if len(errs) != count {
+			t.Errorf("formula '%s': expected %d errors, got %d (%v)", formula, count, len(errs), errs)
+		}
+	}
+}
+
+func TestCheck_Position(t *testing.T) {
+	node, err := prepareExecutor(nil).parse(`1 + Round(X; "a")`)
+	if err != nil {
+		t.Fatalf("expected nil error, got %s", err)
+	}
+	errs := Check(node, prepareSchema())
+	if len(errs) != 1 {
+		t.Fatalf("expected 1 error, got %d", len(errs))
+	}
+	if errs[0].Pos != 14 {
+		t.Errorf("expected position 14, got %d", errs[0].Pos)
+	}
+}
+
+func TestInterpreter_Validate(t *testing.T) {
+	interpreter := NewInterpreter(
+		map[string]any{"X": 1.0},
+		map[string]Func{"If": functions.If, "Custom": functions.Len},
+	)
+	interpreter.SetSignature("If", functions.Signatures["If"])
+	if err := interpreter.Validate(`If(X > 0; Custom(1; "a"); 0)`); err != nil {
+		t.Errorf("expected nil error, got %s", err)
+	}
+	if err := interpreter.Validate(`If(X; 1; 0)`); err == nil {
+		t.Errorf("expected type error")
+	}
+}
+
+func TestInterpreter_ValidateRegistry(t *testing.T) {
+	registry := functions.NewRegistry()
+	registry.MustRegister(functions.Definition{
+		Name:      "Round",
+		Func:      functions.Round,
+		Signature: functions.Signatures["Round"],
+	})
+	interpreter := NewInterpreter(map[string]any{}, map[string]Func{})
+	interpreter.SetRegistry(registry)
+	if err := interpreter.Validate(`Round(1;2;3)`); err == nil {
+		t.Errorf("expected arity error")
+	}
+	res, err := interpreter.Execute(`Round(1,25;1)`)
+	if err != nil {
+		t.Fatalf("expected nil error, got %s", err)
+	}
+	if res != 1.3 {
+		t.Errorf("expected 1.3, got %v", res)
+	}
+}
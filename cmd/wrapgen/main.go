@@ -0,0 +1,302 @@
+// Command wrapgen generates adapters of Go functions to go_interpreter.Func
+// which convert arguments without reflection. It's a code-generated
+// alternative to go_interpreter.WrapFunc with the same conversions and
+// error messages:
+//
+//	//go:generate go run github.com/kovalenkong/go-interpreter/cmd/wrapgen -output funcs_gen.go discount total
+//
+// For every listed top-level function f of the package in the directory
+// it generates fFunc(args ...any) (any, error). Parameters may be bool,
+// string, numbers, time.Time, any and decimals, money and quantities of
+// the functions package, slices of them and a variadic parameter. The
+// function should return a value of such type and optionally an error.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	interpreterPath = "github.com/kovalenkong/go-interpreter"
+	functionsPath   = interpreterPath + "/functions"
+)
+
+func main() {
+	dir := flag.String("dir", ".", "directory of the package")
+	output := flag.String("output", "wrappers_gen.go", "name of the generated file in the directory")
+	tests := flag.Bool("tests", false, "look for functions in _test.go files too")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "usage: wrapgen [flags] function...\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if flag.NArg() == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+	src, err := generate(*dir, *output, *tests, flag.Args())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "wrapgen: %s\n", err)
+		os.Exit(1)
+	}
+	if err := os.WriteFile(filepath.Join(*dir, *output), src, 0o644); err != nil {
+		fmt.Fprintf(os.Stderr, "wrapgen: %s\n", err)
+		os.Exit(1)
+	}
+}
+
+// wrapped is a function to generate adapter of.
+type wrapped struct {
+	name     string
+	params   []param
+	variadic bool
+	hasError bool
+}
+
+// param is a parameter of a wrapped function.
+type param struct {
+	typ   string // element type of slices and variadic parameters
+	slice bool
+}
+
+// generate returns formatted source of adapters of functions names
+// declared in the package in dir. The output file is not parsed.
+func generate(dir, output string, tests bool, names []string) ([]byte, error) {
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, dir, func(info os.FileInfo) bool {
+		return info.Name() != filepath.Base(output) && (tests || !strings.HasSuffix(info.Name(), "_test.go"))
+	}, 0)
+	if err != nil {
+		return nil, err
+	}
+	var pkgName string
+	self := false
+	decls := map[string]*ast.FuncDecl{}
+	imports := map[*ast.FuncDecl]map[string]string{}
+	for _, pkg := range pkgs {
+		for _, file := range pkg.Files {
+			fileImports := importNames(file)
+			for _, decl := range file.Decls {
+				fn, ok := decl.(*ast.FuncDecl)
+				if !ok || fn.Recv != nil {
+					continue
+				}
+				if fn.Name.Name == "CheckArity" && !strings.HasSuffix(pkg.Name, "_test") {
+					self = true
+				}
+				for _, name := range names {
+					if fn.Name.Name != name {
+						continue
+					}
+					if pkgName != "" && pkgName != pkg.Name {
+						return nil, fmt.Errorf("functions are declared in packages %s and %s", pkgName, pkg.Name)
+					}
+					pkgName = pkg.Name
+					decls[name] = fn
+					imports[fn] = fileImports
+				}
+			}
+		}
+	}
+	functions := make([]wrapped, 0, len(names))
+	used := map[string]string{}
+	for _, name := range names {
+		decl, ok := decls[name]
+		if !ok {
+			return nil, fmt.Errorf("function %s not found in %s", name, dir)
+		}
+		fn, err := signature(decl, imports[decl], used)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %s", fset.Position(decl.Pos()), err)
+		}
+		functions = append(functions, fn)
+	}
+	return render(pkgName, self, used, functions)
+}
+
+// importNames maps names of packages imported by file to their paths.
+func importNames(file *ast.File) map[string]string {
+	names := map[string]string{}
+	for _, spec := range file.Imports {
+		path, _ := strconv.Unquote(spec.Path.Value)
+		name := path[strings.LastIndex(path, "/")+1:]
+		if spec.Name != nil {
+			name = spec.Name.Name
+		}
+		names[name] = path
+	}
+	return names
+}
+
+// signature describes function decl, imports of packages used by its
+// types are added to used.
+func signature(decl *ast.FuncDecl, imports, used map[string]string) (wrapped, error) {
+	fn := wrapped{name: decl.Name.Name}
+	if decl.Type.TypeParams != nil {
+		return fn, fmt.Errorf("generic functions are not supported")
+	}
+	for _, field := range decl.Type.Params.List {
+		expr := field.Type
+		p := param{}
+		if ellipsis, ok := expr.(*ast.Ellipsis); ok {
+			fn.variadic = true
+			expr = ellipsis.Elt
+		} else if array, ok := expr.(*ast.ArrayType); ok && array.Len == nil {
+			p.slice = true
+			expr = array.Elt
+		}
+		typ, err := scalarType(expr, imports, used)
+		if err != nil {
+			return fn, fmt.Errorf("parameter %d: %s", len(fn.params)+1, err)
+		}
+		p.typ = typ
+		// fields like (a, b float64) declare several parameters
+		for n := max(len(field.Names), 1); n > 0; n-- {
+			fn.params = append(fn.params, p)
+		}
+	}
+	var results []ast.Expr
+	if decl.Type.Results != nil {
+		for _, field := range decl.Type.Results.List {
+			for n := max(len(field.Names), 1); n > 0; n-- {
+				results = append(results, field.Type)
+			}
+		}
+	}
+	switch {
+	case len(results) == 2 && isError(results[1]):
+		fn.hasError = true
+	case len(results) != 1 || isError(results[0]):
+		return fn, fmt.Errorf("function should return a value and optionally an error")
+	}
+	expr := results[0]
+	if array, ok := expr.(*ast.ArrayType); ok && array.Len == nil {
+		expr = array.Elt
+	}
+	if _, err := scalarType(expr, imports, used); err != nil {
+		return fn, fmt.Errorf("result: %s", err)
+	}
+	return fn, nil
+}
+
+// scalarTypes are predeclared types of parameters and results.
+var scalarTypes = map[string]bool{
+	"bool": true, "string": true, "any": true,
+	"int": true, "int8": true, "int16": true, "int32": true, "int64": true,
+	"uint": true, "uint8": true, "uint16": true, "uint32": true, "uint64": true,
+	"float32": true, "float64": true, "complex64": true, "complex128": true,
+}
+
+// scalarType returns source of type expr if values of formulas can be converted to it.
+func scalarType(expr ast.Expr, imports, used map[string]string) (string, error) {
+	switch t := expr.(type) {
+	case *ast.Ident:
+		if scalarTypes[t.Name] {
+			return t.Name, nil
+		}
+	case *ast.InterfaceType:
+		if len(t.Methods.List) == 0 {
+			return "any", nil
+		}
+	case *ast.SelectorExpr:
+		if pkg, ok := t.X.(*ast.Ident); ok {
+			path := imports[pkg.Name]
+			if path == "time" && t.Sel.Name == "Time" ||
+				path == functionsPath && (t.Sel.Name == "Decimal" || t.Sel.Name == "Money" || t.Sel.Name == "Quantity") {
+				used[pkg.Name] = path
+				return pkg.Name + "." + t.Sel.Name, nil
+			}
+		}
+	}
+	var b bytes.Buffer
+	format.Node(&b, token.NewFileSet(), expr)
+	return "", fmt.Errorf("unsupported type %s", b.String())
+}
+
+func isError(expr ast.Expr) bool {
+	ident, ok := expr.(*ast.Ident)
+	return ok && ident.Name == "error"
+}
+
+func max(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+// render returns formatted source of the generated file.
+func render(pkgName string, self bool, used map[string]string, functions []wrapped) ([]byte, error) {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "// Code generated by wrapgen; DO NOT EDIT.\n\npackage %s\n\n", pkgName)
+	helpers := ""
+	if !self {
+		used["interpreter"] = interpreterPath
+		helpers = "interpreter."
+	}
+	aliases := make([]string, 0, len(used))
+	for alias := range used {
+		aliases = append(aliases, alias)
+	}
+	sort.Slice(aliases, func(i, j int) bool { return used[aliases[i]] < used[aliases[j]] })
+	if len(aliases) != 0 {
+		b.WriteString("import (\n")
+	}
+	for _, alias := range aliases {
+		path := used[alias]
+		if path[strings.LastIndex(path, "/")+1:] == alias {
+			fmt.Fprintf(&b, "\t%q\n", path)
+		} else {
+			fmt.Fprintf(&b, "\t%s %q\n", alias, path)
+		}
+	}
+	if len(aliases) != 0 {
+		b.WriteString(")\n")
+	}
+	for _, fn := range functions {
+		renderFunc(&b, helpers, fn)
+	}
+	src, err := format.Source(b.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("generated invalid code: %s", err)
+	}
+	return src, nil
+}
+
+// renderFunc writes adapter of fn calling helpers with the qualifier.
+func renderFunc(b *bytes.Buffer, helpers string, fn wrapped) {
+	fmt.Fprintf(b, "\n// %sFunc calls %s with arguments of a formula.\n", fn.name, fn.name)
+	fmt.Fprintf(b, "func %sFunc(args ...any) (any, error) {\n", fn.name)
+	fmt.Fprintf(b, "if err := %sCheckArity(%q, args, %d, %v); err != nil {\nreturn nil, err\n}\n", helpers, fn.name, len(fn.params), fn.variadic)
+	args := make([]string, len(fn.params))
+	for i, p := range fn.params {
+		args[i] = fmt.Sprintf("p%d", i)
+		switch {
+		case fn.variadic && i == len(fn.params)-1:
+			fmt.Fprintf(b, "p%d, err := %sVariadicArgs[%s](%q, args, %d)\n", i, helpers, p.typ, fn.name, i)
+			args[i] += "..."
+		case p.slice:
+			fmt.Fprintf(b, "p%d, err := %sSliceArg[%s](%q, args, %d)\n", i, helpers, p.typ, fn.name, i)
+		default:
+			fmt.Fprintf(b, "p%d, err := %sArg[%s](%q, args, %d)\n", i, helpers, p.typ, fn.name, i)
+		}
+		b.WriteString("if err != nil {\nreturn nil, err\n}\n")
+	}
+	call := fmt.Sprintf("%s(%s)", fn.name, strings.Join(args, ", "))
+	if !fn.hasError {
+		fmt.Fprintf(b, "return %sResult(%s), nil\n}\n", helpers, call)
+		return
+	}
+	fmt.Fprintf(b, "res, err := %s\nif err != nil {\nreturn nil, err\n}\nreturn %sResult(res), nil\n}\n", call, helpers)
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const source = `package prices
+
+import (
+	fn "github.com/kovalenkong/go-interpreter/functions"
+	"time"
+)
+
+func Net(price fn.Decimal, rates ...float64) (fn.Decimal, error) { return price, nil }
+
+func Days(from, to time.Time) int { return int(to.Sub(from).Hours() / 24) }
+
+func Tags(xs []string) []string { return xs }
+
+func Apply(f func() int) int { return f() }
+
+func Check() error { return nil }
+
+func Pair() (int, int) { return 0, 0 }
+
+func Lookup(m map[string]int) int { return 0 }
+`
+
+func TestGenerate(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "prices.go"), []byte(source), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	src, err := generate(dir, "prices_gen.go", false, []string{"Net", "Days", "Tags"})
+	if err != nil {
+		t.Fatalf("expected nil error, got %s", err)
+	}
+	for _, expected := range []string{
+		"// Code generated by wrapgen; DO NOT EDIT.",
+		"package prices",
+		`interpreter "github.com/kovalenkong/go-interpreter"`,
+		`fn "github.com/kovalenkong/go-interpreter/functions"`,
+		`"time"`,
+		"func NetFunc(args ...any) (any, error) {",
+		`interpreter.CheckArity("Net", args, 2, true)`,
+		`p0, err := interpreter.Arg[fn.Decimal]("Net", args, 0)`,
+		`p1, err := interpreter.VariadicArgs[float64]("Net", args, 1)`,
+		"res, err := Net(p0, p1...)",
+		`p1, err := interpreter.Arg[time.Time]("Days", args, 1)`,
+		"return interpreter.Result(Days(p0, p1)), nil",
+		`p0, err := interpreter.SliceArg[string]("Tags", args, 0)`,
+	} {
+		if !strings.Contains(string(src), expected) {
+			t.Errorf("expected generated code to contain %q, got\n%s", expected, src)
+		}
+	}
+
+	for _, name := range []string{"Apply", "Check", "Pair", "Lookup", "Missing"} {
+		if _, err := generate(dir, "prices_gen.go", false, []string{name}); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
+
+func TestGenerate_UpToDate(t *testing.T) {
+	// wrappers of the interpreter tests are generated with go:generate in wrap_test.go
+	names := []string{"discount", "join", "total", "half", "weekday", "first"}
+	src, err := generate("../..", "wrap_gen_test.go", true, names)
+	if err != nil {
+		t.Fatalf("expected nil error, got %s", err)
+	}
+	current, err := os.ReadFile("../../wrap_gen_test.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(src) != string(current) {
+		t.Errorf("wrap_gen_test.go is out of date, run go generate")
+	}
+}
@@ -0,0 +1,472 @@
+package go_interpreter
+
+import (
+	"fmt"
+	"github.com/kovalenkong/go-interpreter/functions"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// valueKind is a type of expression known before evaluation.
+type valueKind uint8
+
+const (
+	kindUnknown valueKind = iota
+	kindNumber
+	kindBool
+)
+
+type (
+	numberFn func(vars map[string]any) (float64, error)
+	boolFn   func(vars map[string]any) (bool, error)
+	valueFn  func(vars map[string]any) (any, error)
+)
+
+// compiled is a node turned into closures. value is always set,
+// number and boolean are set only when the kind is known statically.
+type compiled struct {
+	kind    valueKind
+	number  numberFn
+	boolean boolFn
+	value   valueFn
+}
+
+// A Program is a formula compiled into nested Go closures.
+//
+// Subexpressions whose types are known at compile time (number literals,
+// float64 variables and arithmetic or comparisons over them) are evaluated
+// without boxing values into any. Everything else falls back to the same
+// generic evaluation Execute uses, so does the whole program in decimal mode.
+type Program struct {
+	interpreter *Interpreter
+	node        Node
+	code        compiled
+}
+
+// Compile parses formula and compiles it into a Program bound to the interpreter.
+// Types of variables are taken from their current values.
+func (e *Interpreter) Compile(formula string) (*Program, error) {
+	node, err := e.parse(formula)
+	if err != nil {
+		return nil, err
+	}
+	return e.CompileNode(node)
+}
+
+// CompileNode compiles already parsed node into a Program bound to the interpreter.
+func (e *Interpreter) CompileNode(node Node) (*Program, error) {
+	code, err := e.compile(node)
+	if err != nil {
+		return nil, err
+	}
+	return &Program{
+		interpreter: e,
+		node:        node,
+		code:        code,
+	}, nil
+}
+
+// Node returns root node of the compiled formula.
+func (p *Program) Node() Node {
+	return p.node
+}
+
+// Numeric reports whether the whole program is specialized to float64.
+func (p *Program) Numeric() bool {
+	return p.code.kind == kindNumber
+}
+
+// Run evaluates the program against current interpreter variables.
+func (p *Program) Run() (any, error) {
+	return p.code.value(p.interpreter.variables)
+}
+
+// RunFloat evaluates the program and returns float64 result.
+// Numeric programs don't allocate. Decimal results are converted to the nearest float64.
+func (p *Program) RunFloat() (float64, error) {
+	if p.code.number != nil {
+		return p.code.number(p.interpreter.variables)
+	}
+	res, err := p.code.value(p.interpreter.variables)
+	if err != nil {
+		return 0, err
+	}
+	switch val := res.(type) {
+	case float64:
+		return val, nil
+	case functions.Decimal:
+		return val.Float64(), nil
+	default:
+		return 0, fmt.Errorf("expected float64, got %T", res)
+	}
+}
+
+// RunBool evaluates the program and returns bool result.
+func (p *Program) RunBool() (bool, error) {
+	if p.code.boolean != nil {
+		return p.code.boolean(p.interpreter.variables)
+	}
+	res, err := p.code.value(p.interpreter.variables)
+	if err != nil {
+		return false, err
+	}
+	val, ok := res.(bool)
+	if !ok {
+		return false, fmt.Errorf("expected bool, got %T", res)
+	}
+	return val, nil
+}
+
+func (e *Interpreter) compile(node Node) (compiled, error) {
+	switch n := node.(type) {
+	case *BinaryExpr:
+		return e.compileBinaryExpr(n)
+	case *Literal:
+		return e.compileLiteral(n)
+	case *Ident:
+		return e.compileIdent(n), nil
+	case *Function:
+		return e.compileFunction(n)
+	case *UnaryExpr:
+		return e.compileUnary(n)
+	case *Comparison:
+		return e.compileComparison(n)
+	case *Member:
+		return e.compileMember(n)
+	case *Index:
+		return e.compileIndex(n)
+	default:
+		return compiled{}, fmt.Errorf("unknown node type: %T", node)
+	}
+}
+
+func numberCode(fn numberFn) compiled {
+	return compiled{
+		kind:   kindNumber,
+		number: fn,
+		value: func(vars map[string]any) (any, error) {
+			val, err := fn(vars)
+			if err != nil {
+				return nil, err
+			}
+			return val, nil
+		},
+	}
+}
+
+func boolCode(fn boolFn) compiled {
+	return compiled{
+		kind:    kindBool,
+		boolean: fn,
+		value: func(vars map[string]any) (any, error) {
+			val, err := fn(vars)
+			if err != nil {
+				return nil, err
+			}
+			return val, nil
+		},
+	}
+}
+
+func (e *Interpreter) compileLiteral(node *Literal) (compiled, error) {
+	switch {
+	case node.Kind == NUMBER && e.decimal == nil:
+		val, err := strconv.ParseFloat(strings.Replace(node.Value, ",", ".", -1), 10)
+		if err != nil {
+			return compiled{}, err
+		}
+		return numberCode(func(map[string]any) (float64, error) {
+			return val, nil
+		}), nil
+	default:
+		val, err := e.evalLiteral(node)
+		if err != nil {
+			return compiled{}, err
+		}
+		return compiled{
+			value: func(map[string]any) (any, error) {
+				return val, nil
+			},
+		}, nil
+	}
+}
+
+func (e *Interpreter) compileIdent(node *Ident) compiled {
+	name := node.Name
+	// the name is resolved once, so runs look the variable up exactly
+	key, ok, _ := resolveKey(e.folding, e.variables, name)
+	if !ok {
+		key = name
+	}
+	current, _ := e.lookupVar(name)
+	if e.decimal != nil {
+		// numbers are left to generic operators converting them to decimals
+		current = nil
+	}
+	switch current.(type) {
+	case float64:
+		return numberCode(func(vars map[string]any) (float64, error) {
+			value, err := e.resolveVarKey(vars, key, name)
+			if err != nil {
+				return 0, err
+			}
+			val, ok := value.(float64)
+			if !ok {
+				return 0, fmt.Errorf("variable '%s': expected float64, got %T", name, value)
+			}
+			return val, nil
+		})
+	case bool:
+		return boolCode(func(vars map[string]any) (bool, error) {
+			value, err := e.resolveVarKey(vars, key, name)
+			if err != nil {
+				return false, err
+			}
+			val, ok := value.(bool)
+			if !ok {
+				return false, fmt.Errorf("variable '%s': expected bool, got %T", name, value)
+			}
+			return val, nil
+		})
+	default:
+		return compiled{
+			value: func(vars map[string]any) (any, error) {
+				return e.resolveVarKey(vars, key, name)
+			},
+		}
+	}
+}
+
+func (e *Interpreter) compileMember(node *Member) (compiled, error) {
+	object, err := e.compile(node.Object)
+	if err != nil {
+		return compiled{}, err
+	}
+	return compiled{
+		value: func(vars map[string]any) (any, error) {
+			val, err := object.value(vars)
+			if err != nil {
+				return nil, err
+			}
+			return e.member(val, node.Name)
+		},
+	}, nil
+}
+
+func (e *Interpreter) compileIndex(node *Index) (compiled, error) {
+	object, err := e.compile(node.Object)
+	if err != nil {
+		return compiled{}, err
+	}
+	index, err := e.compile(node.Index)
+	if err != nil {
+		return compiled{}, err
+	}
+	return compiled{
+		value: func(vars map[string]any) (any, error) {
+			val, err := object.value(vars)
+			if err != nil {
+				return nil, err
+			}
+			key, err := index.value(vars)
+			if err != nil {
+				return nil, err
+			}
+			return e.index(val, key)
+		},
+	}, nil
+}
+
+func (e *Interpreter) compileBinaryExpr(node *BinaryExpr) (compiled, error) {
+	left, err := e.compile(node.Left)
+	if err != nil {
+		return compiled{}, err
+	}
+	right, err := e.compile(node.Right)
+	if err != nil {
+		return compiled{}, err
+	}
+	if left.kind != kindNumber || right.kind != kindNumber {
+		op := node.Op
+		return compiled{
+			value: func(vars map[string]any) (any, error) {
+				l, err := left.value(vars)
+				if err != nil {
+					return nil, err
+				}
+				r, err := right.value(vars)
+				if err != nil {
+					return nil, err
+				}
+				return e.binaryOp(op, l, r)
+			},
+		}, nil
+	}
+
+	lfn, rfn := left.number, right.number
+	switch node.Op {
+	case ADD:
+		return numberCode(func(vars map[string]any) (float64, error) {
+			l, r, err := evalNumbers(vars, lfn, rfn)
+			return l + r, err
+		}), nil
+	case SUB:
+		return numberCode(func(vars map[string]any) (float64, error) {
+			l, r, err := evalNumbers(vars, lfn, rfn)
+			return l - r, err
+		}), nil
+	case MUL:
+		return numberCode(func(vars map[string]any) (float64, error) {
+			l, r, err := evalNumbers(vars, lfn, rfn)
+			return l * r, err
+		}), nil
+	case DIV:
+		return numberCode(func(vars map[string]any) (float64, error) {
+			l, r, err := evalNumbers(vars, lfn, rfn)
+			if err != nil {
+				return 0, err
+			}
+			if r == 0 {
+				return 0, fmt.Errorf("zero division error")
+			}
+			return l / r, nil
+		}), nil
+	case EXP:
+		return numberCode(func(vars map[string]any) (float64, error) {
+			l, r, err := evalNumbers(vars, lfn, rfn)
+			return math.Pow(l, r), err
+		}), nil
+	default:
+		return compiled{}, fmt.Errorf("unknown binary operation: %d", node.Op)
+	}
+}
+
+func evalNumbers(vars map[string]any, left, right numberFn) (float64, float64, error) {
+	l, err := left(vars)
+	if err != nil {
+		return 0, 0, err
+	}
+	r, err := right(vars)
+	if err != nil {
+		return 0, 0, err
+	}
+	return l, r, nil
+}
+
+func (e *Interpreter) compileUnary(node *UnaryExpr) (compiled, error) {
+	operand, err := e.compile(node.Left)
+	if err != nil {
+		return compiled{}, err
+	}
+	if operand.kind != kindNumber {
+		op := node.Op
+		return compiled{
+			value: func(vars map[string]any) (any, error) {
+				val, err := operand.value(vars)
+				if err != nil {
+					return nil, err
+				}
+				return e.unaryOp(op, val)
+			},
+		}, nil
+	}
+	fn := operand.number
+	switch node.Op {
+	case ADD:
+		return operand, nil
+	case SUB:
+		return numberCode(func(vars map[string]any) (float64, error) {
+			val, err := fn(vars)
+			return -val, err
+		}), nil
+	default:
+		return compiled{}, fmt.Errorf("unknown unary operator: %d", node.Op)
+	}
+}
+
+func (e *Interpreter) compileComparison(node *Comparison) (compiled, error) {
+	left, err := e.compile(node.Left)
+	if err != nil {
+		return compiled{}, err
+	}
+	right, err := e.compile(node.Right)
+	if err != nil {
+		return compiled{}, err
+	}
+	if left.kind != kindNumber || right.kind != kindNumber {
+		op := node.Op
+		return compiled{
+			value: func(vars map[string]any) (any, error) {
+				l, err := left.value(vars)
+				if err != nil {
+					return nil, err
+				}
+				r, err := right.value(vars)
+				if err != nil {
+					return nil, err
+				}
+				return e.compareValues(op, l, r)
+			},
+		}, nil
+	}
+
+	lfn, rfn := left.number, right.number
+	switch node.Op {
+	case EQ:
+		return boolCode(func(vars map[string]any) (bool, error) {
+			l, r, err := evalNumbers(vars, lfn, rfn)
+			return l == r, err
+		}), nil
+	case LT, GT, LTE, GTE:
+		op := node.Op
+		return boolCode(func(vars map[string]any) (bool, error) {
+			l, r, err := evalNumbers(vars, lfn, rfn)
+			if err != nil {
+				return false, err
+			}
+			return compare(l, r, op)
+		}), nil
+	default:
+		return compiled{}, fmt.Errorf("unexpected comparison token: %d", node.Op)
+	}
+}
+
+func (e *Interpreter) compileFunction(node *Function) (compiled, error) {
+	args := make([]valueFn, len(node.Args))
+	for i, arg := range node.Args {
+		code, err := e.compile(arg)
+		if err != nil {
+			return compiled{}, err
+		}
+		args[i] = code.value
+	}
+	name, key := node.Name, node.Name
+	if resolved, _, _, err := e.findFunction(name); err == nil {
+		// the name is resolved once, so runs look the function up exactly
+		key = resolved
+	}
+	return compiled{
+		value: func(vars map[string]any) (any, error) {
+			function, def, ok := e.exactFunction(key)
+			if !ok {
+				var err error
+				if function, def, err = e.resolveFunction(name); err != nil {
+					return nil, err
+				}
+			}
+			values := make([]any, len(args))
+			for i, arg := range args {
+				val, err := arg(vars)
+				if err != nil {
+					if !catchesErrors(def) {
+						return nil, err
+					}
+					val = err
+				}
+				values[i] = val
+			}
+			return function(values...)
+		},
+	}, nil
+}
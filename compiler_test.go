@@ -0,0 +1,139 @@
+package go_interpreter
+
+import (
+	"github.com/kovalenkong/go-interpreter/functions"
+	"testing"
+)
+
+func TestProgram_MatchesExecute(t *testing.T) {
+	interpreter := NewInterpreter(
+		map[string]any{
+			"X": 3.0,
+			"Y": 10.0,
+			"S": "text",
+			"B": true,
+		},
+		map[string]Func{
+			"Sum": functions.Sum,
+			"And": functions.And,
+		},
+	)
+	formulas := []string{
+		`1 + 1`,
+		`X * Y - 2 / 4`,
+		`-X ^ 2`,
+		`+X`,
+		`Sum(X;Y;1) * 2`,
+		`X < Y`,
+		`X = 3`,
+		`S = "text"`,
+		`And(B; X >= 3)`,
+		`"a" < "b"`,
+	}
+	for _, formula := range formulas {
+		expected, err := interpreter.Execute(formula)
+		if err != nil {
+			t.Fatalf("formula '%s': expected nil error, got %s", formula, err)
+		}
+		program, err := interpreter.Compile(formula)
+		if err != nil {
+			t.Fatalf("formula '%s': expected nil error, got %s", formula, err)
+		}
+		res, err := program.Run()
+		if err != nil {
+			t.Fatalf("formula '%s': expected nil error, got %s", formula, err)
+		}
+		if res != expected {
+			t.Fatalf("formula '%s': expected '%v', got '%v'", formula, expected, res)
+		}
+	}
+}
+
+func TestProgram_Numeric(t *testing.T) {
+	interpreter := NewInterpreter(map[string]any{"X": 1.0, "S": "s"}, map[string]Func{"Sum": functions.Sum})
+	cases := map[string]bool{
+		`1 + X * 2`:   true,
+		`-(X ^ 2)`:    true,
+		`Sum(1) + 1`:  false,
+		`S`:           false,
+		`X < 1`:       false,
+		`Unknown + 1`: false,
+	}
+	for formula, numeric := range cases {
+		program, err := interpreter.Compile(formula)
+		if err != nil {
+			t.Fatalf("formula '%s': expected nil error, got %s", formula, err)
+		}
+		if program.Numeric() != numeric {
+			t.Errorf("formula '%s': expected numeric %v, got %v", formula, numeric, program.Numeric())
+		}
+	}
+}
+
+func TestProgram_Errors(t *testing.T) {
+	interpreter := NewInterpreter(map[string]any{"X": 1.0}, nil)
+	program, err := interpreter.Compile(`1 / (X - 1)`)
+	if err != nil {
+		t.Fatalf("expected nil error, got %s", err)
+	}
+	if _, err := program.RunFloat(); err == nil {
+		t.Errorf("expected zero division error")
+	}
+	interpreter.SetVar("X", "changed")
+	if _, err := program.RunFloat(); err == nil {
+		t.Errorf("expected type error after variable changed type")
+	}
+}
+
+func TestProgram_ZeroAllocs(t *testing.T) {
+	interpreter := NewInterpreter(map[string]any{"X": 10.0, "Y": 20.0}, nil)
+	program, err := interpreter.Compile(`X + Y * 72 / 6^2 - -X`)
+	if err != nil {
+		t.Fatalf("expected nil error, got %s", err)
+	}
+	var res float64
+	allocs := testing.AllocsPerRun(100, func() {
+		res, err = program.RunFloat()
+	})
+	if err != nil {
+		t.Fatalf("expected nil error, got %s", err)
+	}
+	if res != 60 {
+		t.Fatalf("expected 60, got %f", res)
+	}
+	if allocs != 0 {
+		t.Errorf("expected 0 allocations, got %f", allocs)
+	}
+
+	comparison, err := interpreter.Compile(`X * 2 >= Y`)
+	if err != nil {
+		t.Fatalf("expected nil error, got %s", err)
+	}
+	allocs = testing.AllocsPerRun(100, func() {
+		_, err = comparison.RunBool()
+	})
+	if err != nil {
+		t.Fatalf("expected nil error, got %s", err)
+	}
+	if allocs != 0 {
+		t.Errorf("expected 0 allocations, got %f", allocs)
+	}
+}
+
+func BenchmarkProgram_RunFloat(b *testing.B) {
+	interpreter := NewInterpreter(map[string]any{"X": 10.0, "Y": 20.0}, nil)
+	program, err := interpreter.Compile(`X + Y * 72 / 6^2`)
+	if err != nil {
+		b.Fatalf("expected nil error, got %v", err)
+	}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		val, err := program.RunFloat()
+		if err != nil {
+			b.Fatalf("expected nil error, got %v", err)
+		}
+		if val != 50 {
+			b.Fatalf("expected 50, got %f", val)
+		}
+	}
+}
@@ -0,0 +1,54 @@
+package go_interpreter
+
+import (
+	"fmt"
+	"github.com/kovalenkong/go-interpreter/functions"
+	"math/cmplx"
+)
+
+// complexOperands promotes operands to complex128 if one of them is complex
+// and the other one is a number.
+func complexOperands(left, right any) (complex128, complex128, bool) {
+	_, lcomplex := left.(complex128)
+	_, rcomplex := right.(complex128)
+	if !lcomplex && !rcomplex {
+		return 0, 0, false
+	}
+	l, lok := toComplex(left)
+	r, rok := toComplex(right)
+	return l, r, lok && rok
+}
+
+func toComplex(value any) (complex128, bool) {
+	switch val := value.(type) {
+	case complex128:
+		return val, true
+	case float64:
+		return complex(val, 0), true
+	case functions.Decimal:
+		return complex(val.Float64(), 0), true
+	default:
+		return 0, false
+	}
+}
+
+// complexOp applies arithmetic operator to complex numbers.
+func complexOp(op TokenType, l, r complex128) (any, error) {
+	switch op {
+	case ADD:
+		return l + r, nil
+	case SUB:
+		return l - r, nil
+	case MUL:
+		return l * r, nil
+	case DIV:
+		if r == 0 {
+			return nil, fmt.Errorf("zero division error")
+		}
+		return l / r, nil
+	case EXP:
+		return cmplx.Pow(l, r), nil
+	default:
+		return nil, fmt.Errorf("unknown binary operation: %d", op)
+	}
+}
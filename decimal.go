@@ -0,0 +1,91 @@
+package go_interpreter
+
+import (
+	"fmt"
+	"github.com/kovalenkong/go-interpreter/functions"
+	"math"
+)
+
+// maxDecimalPower limits integer powers computed exactly,
+// larger powers are computed with float64.
+const maxDecimalPower = 1000
+
+// maxDecimalPrecision limits digits of exact powers, larger ones are #NUM!.
+const maxDecimalPrecision = 10000
+
+// defaultDecimalContext is used for decimal values outside of decimal mode.
+var defaultDecimalContext = functions.DecimalContext{Scale: 16, Rounding: functions.RoundHalfEven}
+
+// SetDecimalMode makes number literals exact decimals: numbers in arithmetic
+// and comparisons are converted to functions.Decimal and division is rounded
+// according to ctx. Nil ctx turns decimal mode off.
+func (e *Interpreter) SetDecimalMode(ctx *functions.DecimalContext) {
+	e.decimal = ctx
+}
+
+func (e *Interpreter) decimalContext() functions.DecimalContext {
+	if e.decimal == nil {
+		return defaultDecimalContext
+	}
+	return *e.decimal
+}
+
+// decimals returns both operands as decimals if they are numbers and
+// either decimal mode is on or one of them is already a decimal.
+func (e *Interpreter) decimals(left, right any) (functions.Decimal, functions.Decimal, bool, error) {
+	_, ldec := left.(functions.Decimal)
+	_, rdec := right.(functions.Decimal)
+	if e.decimal == nil && !ldec && !rdec {
+		return functions.Decimal{}, functions.Decimal{}, false, nil
+	}
+	l, ok, err := toDecimal(left)
+	if !ok || err != nil {
+		return functions.Decimal{}, functions.Decimal{}, false, err
+	}
+	r, ok, err := toDecimal(right)
+	if !ok || err != nil {
+		return functions.Decimal{}, functions.Decimal{}, false, err
+	}
+	return l, r, true, nil
+}
+
+// toDecimal converts number to decimal, ok is false for other types.
+func toDecimal(value any) (functions.Decimal, bool, error) {
+	switch val := value.(type) {
+	case functions.Decimal:
+		return val, true, nil
+	case float64:
+		d, err := functions.DecimalFromFloat(val)
+		return d, true, err
+	default:
+		return functions.Decimal{}, false, nil
+	}
+}
+
+// decimalOp applies arithmetic operator to decimals.
+func (e *Interpreter) decimalOp(op TokenType, l, r functions.Decimal) (any, error) {
+	switch op {
+	case ADD:
+		return l.Add(r), nil
+	case SUB:
+		return l.Sub(r), nil
+	case MUL:
+		return l.Mul(r), nil
+	case DIV:
+		return l.Quo(r, e.decimalContext())
+	case EXP:
+		if n, ok := r.Int64(); ok && r.IsInteger() && n >= -maxDecimalPower && n <= maxDecimalPower {
+			if int64(l.Precision())*n > maxDecimalPrecision || int64(l.Precision())*-n > maxDecimalPrecision {
+				return nil, fmt.Errorf("%w %v ^ %v has too many digits", functions.ErrNum, l, r)
+			}
+			return l.Pow(n, e.decimalContext())
+		}
+		res := math.Pow(l.Float64(), r.Float64())
+		if math.IsNaN(res) || math.IsInf(res, 0) {
+			return nil, fmt.Errorf("%v ^ %v is not a finite number", l, r)
+		}
+		return functions.DecimalFromFloat(res)
+	default:
+		return nil, fmt.Errorf("unknown binary operation: %d", op)
+	}
+}
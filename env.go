@@ -0,0 +1,115 @@
+package go_interpreter
+
+import (
+	"fmt"
+	"github.com/kovalenkong/go-interpreter/functions"
+	"reflect"
+	"sync"
+)
+
+// Env is a set of variables and functions bound to a Go value. Fields
+// and methods are resolved through metadata cached per type when formulas
+// access them, so values behind a pointer are read at evaluation time.
+type Env struct {
+	value  reflect.Value
+	object reflect.Value
+	meta   *envType
+}
+
+// envType is cached reflection metadata of a type bound as an environment.
+type envType struct {
+	fields  map[string][]int
+	methods map[string]envMethod
+}
+
+type envMethod struct {
+	index int
+	bind  func(method reflect.Value) Func
+}
+
+// envCache maps types to their environment metadata.
+var envCache sync.Map // reflect.Type -> *envType
+
+// NewEnvFromStruct returns environment of a struct or a pointer to struct.
+// Exported fields become variables named as in member access, see
+// structFields, and methods which can be called from formulas become
+// functions. Arguments of methods are converted as by WrapFunc.
+func NewEnvFromStruct(v any) (*Env, error) {
+	value := reflect.ValueOf(v)
+	if value.Kind() == reflect.Pointer && value.IsNil() {
+		return nil, fmt.Errorf("expected struct, got nil %T", v)
+	}
+	object := indirect(value)
+	if object.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("expected struct, got %T", v)
+	}
+	return &Env{value: value, object: object, meta: envTypeOf(value.Type())}, nil
+}
+
+// Var returns value of the variable with exactly the name.
+func (env *Env) Var(name string) (any, bool) {
+	value, ok, _ := env.lookupVar(FoldExact, name)
+	return value, ok
+}
+
+// Func returns function with exactly the name.
+func (env *Env) Func(name string) (Func, bool) {
+	function, ok, _ := env.lookupFunction(FoldExact, name)
+	return function, ok
+}
+
+// lookupVar returns value of the field matching name under folding.
+func (env *Env) lookupVar(folding NameFolding, name string) (any, bool, error) {
+	index, ok, err := resolveName(folding, env.meta.fields, name)
+	if !ok || err != nil {
+		return nil, false, err
+	}
+	field, err := env.object.FieldByIndexErr(index)
+	if err != nil {
+		// field of a nil embedded struct
+		return functions.Blank, true, nil
+	}
+	return plainValue(field), true, nil
+}
+
+// lookupFunction returns the method matching name under folding bound to the value.
+func (env *Env) lookupFunction(folding NameFolding, name string) (Func, bool, error) {
+	method, ok, err := resolveName(folding, env.meta.methods, name)
+	if !ok || err != nil {
+		return nil, false, err
+	}
+	return method.bind(env.value.Method(method.index)), true, nil
+}
+
+// SetEnv replaces variables of the interpreter with variables of env and
+// functions of the previous environment with functions of env. Variables
+// set later by SetVar take precedence over fields of env, functions set by
+// SetFunction take precedence over methods of env. Nil env removes the
+// environment.
+func (e *Interpreter) SetEnv(env *Env) {
+	e.env = env
+	e.variables = nil
+}
+
+// envTypeOf returns environment metadata of struct type or pointer to struct type t.
+func envTypeOf(t reflect.Type) *envType {
+	if meta, ok := envCache.Load(t); ok {
+		return meta.(*envType)
+	}
+	structType := t
+	for structType.Kind() == reflect.Pointer {
+		structType = structType.Elem()
+	}
+	meta := &envType{fields: structFields(structType), methods: map[string]envMethod{}}
+	for i := 0; i < t.NumMethod(); i++ {
+		method := t.Method(i)
+		bind, err := wrapper(method.Name, method.Type, 1)
+		if err != nil {
+			// methods which can't be called from formulas are skipped
+			continue
+		}
+		meta.methods[method.Name] = envMethod{index: i, bind: bind}
+	}
+	cached, _ := envCache.LoadOrStore(t, meta)
+	return cached.(*envType)
+}
@@ -0,0 +1,195 @@
+package go_interpreter
+
+import (
+	"errors"
+	"fmt"
+	"github.com/kovalenkong/go-interpreter/functions"
+	"testing"
+)
+
+type invoice struct {
+	Number   string  `formula:"number"`
+	Amount   float64 `json:"amount"`
+	Quantity int
+	Customer customer `json:"customer"`
+	Lines    []line   `json:"lines"`
+	internal float64
+}
+
+func (i invoice) Total() float64 {
+	total := 0.
+	for _, l := range i.Lines {
+		total += l.Price * float64(l.Qty)
+	}
+	return total
+}
+
+func (i *invoice) Discount(rate float64, code string) (float64, error) {
+	if code != "VIP" {
+		return 0, fmt.Errorf("%w unknown code %s", functions.ErrNA, code)
+	}
+	return i.Amount * rate, nil
+}
+
+func (i *invoice) Pick(args ...any) (any, error) {
+	return args[len(args)-1], nil
+}
+
+func (i *invoice) Lines2() ([]line, int) { return i.Lines, 0 }
+
+func (i *invoice) Apply(f func(float64) float64) float64 { return f(i.Amount) }
+
+func TestNewEnvFromStruct(t *testing.T) {
+	inv := &invoice{
+		Number:   "A-1",
+		Amount:   200,
+		Quantity: 3,
+		Customer: customer{Name: "ACME", Tier: 2},
+		Lines:    []line{{Price: 10, Qty: 2}, {Price: 5, Qty: 1}},
+	}
+	env, err := NewEnvFromStruct(inv)
+	if err != nil {
+		t.Fatalf("expected nil error, got %s", err)
+	}
+	for _, name := range []string{"internal", "Lines2", "Apply"} {
+		_, isVar := env.Var(name)
+		_, isFunc := env.Func(name)
+		if isVar || isFunc {
+			t.Errorf("%s should not be bound", name)
+		}
+	}
+	interpreter := NewDefaultInterpreter()
+	interpreter.SetEnv(env)
+	cases := map[string]any{
+		`number`:                       "A-1",
+		`amount / Quantity`:            200. / 3,
+		`customer.tier`:                2.,
+		`lines[1].price`:               5.,
+		`Total()`:                      25.,
+		`Discount(0,1; "VIP") + 1`:     21.,
+		`Pick(1; "b")`:                 "b",
+		`IFERROR(Discount(1; "x"); 0)`: 0.,
+		`ROUND(amount; 0)`:             200.,
+	}
+	for formula, expected := range cases {
+		res, err := interpreter.Execute(formula)
+		if err != nil {
+			t.Fatalf("formula '%s': expected nil error, got %s", formula, err)
+		}
+		if res != expected {
+			t.Errorf("formula '%s': expected %v, got %v", formula, expected, res)
+		}
+	}
+	errs := map[string]error{
+		`Discount(1; "x")`: functions.ErrNA,
+		`Discount(1)`:      functions.ErrValue,
+		`Discount("a"; 1)`: functions.ErrValue,
+	}
+	for formula, expected := range errs {
+		if _, err := interpreter.Execute(formula); !errors.Is(err, expected) {
+			t.Errorf("formula '%s': expected %s, got %v", formula, expected, err)
+		}
+	}
+
+	// value receivers expose only methods of the value
+	env, err = NewEnvFromStruct(*inv)
+	if err != nil {
+		t.Fatalf("expected nil error, got %s", err)
+	}
+	_, total := env.Func("Total")
+	_, discount := env.Func("Discount")
+	if !total || discount {
+		t.Errorf("expected only value methods, got Total %v and Discount %v", total, discount)
+	}
+
+	program, err := interpreter.Compile(`amount * 2`)
+	if err != nil {
+		t.Fatalf("expected nil error, got %s", err)
+	}
+	for _, amount := range []float64{1, 2} {
+		env, err := NewEnvFromStruct(&invoice{Amount: amount})
+		if err != nil {
+			t.Fatalf("expected nil error, got %s", err)
+		}
+		interpreter.SetEnv(env)
+		if res, err := program.Run(); err != nil || res != amount*2 {
+			t.Errorf("expected %v, got %v (%v)", amount*2, res, err)
+		}
+	}
+
+	var nilInvoice *invoice
+	for _, v := range []any{nil, 1., nilInvoice, []invoice{}} {
+		if _, err := NewEnvFromStruct(v); err == nil {
+			t.Errorf("%T: expected error", v)
+		}
+	}
+}
+
+func TestInterpreter_SetEnv(t *testing.T) {
+	inv := &invoice{Amount: 200, Lines: []line{{Price: 10, Qty: 2}}}
+	env, err := NewEnvFromStruct(inv)
+	if err != nil {
+		t.Fatalf("expected nil error, got %s", err)
+	}
+	interpreter := NewInterpreter(map[string]any{"x": 1.}, nil)
+	interpreter.SetEnv(env)
+	if res, err := interpreter.Execute(`Total() + amount`); err != nil || res != 220. {
+		t.Errorf("expected 220, got %v (%v)", res, err)
+	}
+	if _, err := interpreter.Execute(`x`); err == nil {
+		t.Errorf("expected variables to be replaced")
+	}
+
+	// fields are read on access
+	inv.Amount = 300
+	if res, err := interpreter.Execute(`amount`); err != nil || res != 300. {
+		t.Errorf("expected 300, got %v (%v)", res, err)
+	}
+
+	// variables set later take precedence and don't change env
+	interpreter.SetVar("amount", 1.)
+	if res, err := interpreter.Execute(`amount`); err != nil || res != 1. {
+		t.Errorf("expected 1, got %v (%v)", res, err)
+	}
+	if amount, _ := env.Var("amount"); amount != 300. {
+		t.Errorf("expected env amount 300, got %v", amount)
+	}
+
+	// functions of the previous environment are removed
+	other, err := NewEnvFromStruct(customer{Name: "ACME"})
+	if err != nil {
+		t.Fatalf("expected nil error, got %s", err)
+	}
+	interpreter.SetEnv(other)
+	if _, err := interpreter.Execute(`Total()`); err == nil {
+		t.Errorf("expected Total to be removed")
+	}
+	if res, err := interpreter.Execute(`name`); err != nil || res != "ACME" {
+		t.Errorf("expected ACME, got %v (%v)", res, err)
+	}
+	interpreter.SetEnv(nil)
+	if _, err := interpreter.Execute(`name`); err == nil {
+		t.Errorf("expected environment to be removed")
+	}
+}
+
+func BenchmarkNewEnvFromStruct(b *testing.B) {
+	inv := &invoice{Number: "A-1", Amount: 200, Quantity: 3}
+	interpreter := NewDefaultInterpreter()
+	program, err := interpreter.Compile(`amount * Quantity`)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		env, err := NewEnvFromStruct(inv)
+		if err != nil {
+			b.Fatal(err)
+		}
+		interpreter.SetEnv(env)
+		if _, err := program.Run(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
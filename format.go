@@ -0,0 +1,133 @@
+package go_interpreter
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Format returns text of formula of the node which parses back to the
+// same node. Names which are not plain identifiers are quoted, like
+// [Unit Price].
+func Format(node Node) string {
+	var b strings.Builder
+	format(&b, node)
+	return b.String()
+}
+
+// Priorities of operators as in the parser.
+const (
+	priorityComparison = iota + 1
+	priorityAddSub
+	priorityMul
+	priorityDiv
+	priorityExp
+	priorityUnary
+	priorityPrimary
+)
+
+func priority(node Node) int {
+	switch n := node.(type) {
+	case *Comparison:
+		return priorityComparison
+	case *BinaryExpr:
+		switch n.Op {
+		case ADD, SUB:
+			return priorityAddSub
+		case MUL:
+			return priorityMul
+		case DIV:
+			return priorityDiv
+		default:
+			return priorityExp
+		}
+	case *UnaryExpr:
+		return priorityUnary
+	}
+	return priorityPrimary
+}
+
+func format(b *strings.Builder, node Node) {
+	switch n := node.(type) {
+	case *Literal:
+		if n.Kind == STRING {
+			b.WriteString(`"` + n.Value + `"`)
+		} else {
+			b.WriteString(n.Value)
+		}
+	case *Ident:
+		b.WriteString(quoteName(n.Name))
+	case *Function:
+		parts := strings.Split(n.Name, ".")
+		for i, part := range parts {
+			parts[i] = quoteName(part)
+		}
+		b.WriteString(strings.Join(parts, "."))
+		b.WriteString("(")
+		for i, arg := range n.Args {
+			if i > 0 {
+				b.WriteString("; ")
+			}
+			format(b, arg)
+		}
+		b.WriteString(")")
+	case *Member:
+		formatObject(b, n.Object)
+		b.WriteString(".")
+		b.WriteString(quoteName(n.Name))
+	case *Index:
+		formatObject(b, n.Object)
+		b.WriteString("[")
+		format(b, n.Index)
+		b.WriteString("]")
+	case *UnaryExpr:
+		b.WriteString(opName(n.Op))
+		formatOperand(b, n.Left, priorityUnary)
+	case *BinaryExpr:
+		formatOperand(b, n.Left, priority(n))
+		b.WriteString(" " + opName(n.Op) + " ")
+		formatOperand(b, n.Right, priority(n)+1)
+	case *Comparison:
+		formatOperand(b, n.Left, priority(n))
+		b.WriteString(" " + opName(n.Op) + " ")
+		formatOperand(b, n.Right, priority(n)+1)
+	}
+}
+
+// formatOperand formats node in parentheses if its priority is lower than min.
+func formatOperand(b *strings.Builder, node Node, min int) {
+	if priority(node) >= min {
+		format(b, node)
+		return
+	}
+	b.WriteString("(")
+	format(b, node)
+	b.WriteString(")")
+}
+
+// formatObject formats object of member or index access: only names,
+// functions and other accesses are followed by access without parentheses.
+func formatObject(b *strings.Builder, node Node) {
+	switch node.(type) {
+	case *Ident, *Function, *Member, *Index:
+		format(b, node)
+	default:
+		b.WriteString("(")
+		format(b, node)
+		b.WriteString(")")
+	}
+}
+
+// quoteName returns name as is if it is a plain identifier, otherwise
+// quoted by brackets or by backticks if the name contains a bracket.
+func quoteName(name string) string {
+	for i, r := range name {
+		if unicode.IsLetter(r) || r == '_' || i > 0 && unicode.IsDigit(r) {
+			continue
+		}
+		if strings.Contains(name, "]") {
+			return "`" + strings.ReplaceAll(name, "`", "``") + "`"
+		}
+		return "[" + name + "]"
+	}
+	return name
+}
@@ -0,0 +1,118 @@
+package go_interpreter
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestFormat(t *testing.T) {
+	cases := map[string]string{
+		`1+2*3`:                     `1 + 2 * 3`,
+		`(1 + 2) * 3`:               `(1 + 2) * 3`,
+		`a / b * c`:                 `a / b * c`,
+		`a * (b / c)`:               `a * b / c`,
+		`(a * b) / c`:               `(a * b) / c`,
+		`a - (b - c)`:               `a - (b - c)`,
+		`2 ^ (3 ^ 2)`:               `2 ^ (3 ^ 2)`,
+		`-(a + 1)`:                  `-(a + 1)`,
+		`--1`:                       `--1`,
+		`(a > 1) = (b < 2)`:         `a > 1 = (b < 2)`,
+		`Sum(1;"x";2,5)`:            `Sum(1; "x"; 2,5)`,
+		`CEILING.MATH(x)`:           `CEILING.MATH(x)`,
+		`order.items[i + 1].price`:  `order.items[i + 1].price`,
+		`(a + b).c`:                 `(a + b).c`,
+		`[Unit Price] * qty`:        `[Unit Price] * qty`,
+		"`Unit Price` * unit_price": `[Unit Price] * unit_price`,
+		`row.[Unit Price]`:          `row.[Unit Price]`,
+		`row["Unit Price"]`:         `row["Unit Price"]`,
+		"`a]b` + [1st]":             "`a]b` + [1st]",
+		`[My Func](1)`:              `[My Func](1)`,
+		`_total + x[[Unit Price]]`:  `_total + x[[Unit Price]]`,
+		"[order.total] + `a``b`":    "[order.total] + [a`b]",
+		`Sum(x.y)[0]`:               `Sum(x.y)[0]`,
+	}
+	for formula, expected := range cases {
+		node := parse(t, formula)
+		res := Format(node)
+		if res != expected {
+			t.Errorf("formula '%s': expected %s, got %s", formula, expected, res)
+			continue
+		}
+		if again := Format(parse(t, res)); again != res {
+			t.Errorf("formula '%s': formatted text parses to %s", formula, again)
+		}
+	}
+}
+
+func TestLexer_Ident(t *testing.T) {
+	cases := map[string][]string{
+		`unit_price * _x1`: {"unit_price", "_x1"},
+		`[Unit Price] * 2`: {"Unit Price"},
+		"`Unit Price` * 2": {"Unit Price"},
+		`row["Unit"]`:      {"row"},
+		`F([a b]; x)[[c]]`: {"F", "a b", "x", "c"},
+		"`a``b` + [x[y]":   {"a`b", "x[y"},
+		`price$ + x`:       {"price$", "x"},
+		`total#1 + a$b$`:   {"total#1", "a$b$"},
+	}
+	for formula, expected := range cases {
+		lexer := NewLexer()
+		lexer.SetIdentChars("$#")
+		tokens, err := lexer.Lex(strings.NewReader(formula))
+		if err != nil {
+			t.Fatalf("formula '%s': expected nil error, got %s", formula, err)
+		}
+		var idents []string
+		for _, token := range tokens {
+			if token.Type == IDENT {
+				idents = append(idents, token.Value)
+			}
+		}
+		if strings.Join(idents, "|") != strings.Join(expected, "|") {
+			t.Errorf("formula '%s': expected identifiers %q, got %q", formula, expected, idents)
+		}
+	}
+	for _, formula := range []string{`[Unit Price`, "`a", `[] + 1`, `price$ + #count`} {
+		lexer := NewLexer()
+		lexer.SetIdentChars("$#")
+		if _, err := lexer.Lex(strings.NewReader(formula)); err == nil {
+			t.Errorf("formula '%s': expected error", formula)
+		}
+	}
+}
+
+func TestInterpreter_QuotedNames(t *testing.T) {
+	interpreter := NewDefaultInterpreter(WithIdentChars("$"))
+	interpreter.SetVar("Unit Price", 2.5)
+	interpreter.SetVar("unit_qty", 4.)
+	interpreter.SetVar("cost$", 1.)
+	interpreter.SetVar("row", map[string]any{"Unit Price": 3.})
+	cases := map[string]float64{
+		`[Unit Price] * unit_qty`:  10,
+		"`Unit Price` + cost$":     3.5,
+		`row.[Unit Price] * 2`:     6,
+		`row["Unit Price"] - 1`:    2,
+		`SUM([Unit Price]; cost$)`: 3.5,
+	}
+	for formula, expected := range cases {
+		res, err := interpreter.Execute(formula)
+		if err != nil {
+			t.Fatalf("formula '%s': expected nil error, got %s", formula, err)
+		}
+		if res != expected {
+			t.Errorf("formula '%s': expected %v, got %v", formula, expected, res)
+		}
+	}
+}
+
+func parse(t *testing.T, formula string) Node {
+	tokens, err := NewLexer().Lex(strings.NewReader(formula))
+	if err != nil {
+		t.Fatalf("formula '%s': expected nil error, got %s", formula, err)
+	}
+	node, err := NewParser().Parse(tokens)
+	if err != nil {
+		t.Fatalf("formula '%s': expected nil error, got %s", formula, err)
+	}
+	return node
+}
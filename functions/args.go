@@ -0,0 +1,137 @@
+package functions
+
+import "fmt"
+
+// checkArgs returns an error if number of args is out of [min, max] range.
+// Negative max means unlimited number of args.
+func checkArgs(args []any, min, max int) error {
+	n := len(args)
+	if n >= min && (max < 0 || n <= max) {
+		return nil
+	}
+	switch {
+	case max < 0:
+		return fmt.Errorf("expected %d or more args, got %d", min, n)
+	case min == max:
+		return fmt.Errorf("expected %d args, got %d", min, n)
+	case max == min+1:
+		return fmt.Errorf("expected %d or %d args, got %d", min, max, n)
+	default:
+		return fmt.Errorf("expected %d to %d args, got %d", min, max, n)
+	}
+}
+
+// number returns arg as float64, Blank is 0. Decimals are converted to the nearest float64.
+func number(arg any) (float64, error) {
+	switch val := arg.(type) {
+	case blank:
+		return 0, nil
+	case Decimal:
+		return val.Float64(), nil
+	}
+	val, ok := arg.(float64)
+	if !ok {
+		return 0, fmt.Errorf("expected float64, got %T", arg)
+	}
+	return val, nil
+}
+
+// boolean returns arg as bool, Blank is FALSE.
+func boolean(arg any) (bool, error) {
+	if arg == Blank {
+		return false, nil
+	}
+	val, ok := arg.(bool)
+	if !ok {
+		return false, fmt.Errorf("expected bool, got %T", arg)
+	}
+	return val, nil
+}
+
+// optionalNumber returns i-th arg as float64 or def if there is no such arg.
+func optionalNumber(args []any, i int, def float64) (float64, error) {
+	if i >= len(args) {
+		return def, nil
+	}
+	return number(args[i])
+}
+
+// flatten collects numbers from scalars, []float64 and []any arrays and tables.
+// Nested arrays are flattened recursively, Blank values are skipped.
+func flatten(args ...any) ([]float64, error) {
+	numbers := make([]float64, 0, len(args))
+	for _, arg := range args {
+		var err error
+		numbers, err = appendNumbers(numbers, arg)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return numbers, nil
+}
+
+func appendNumbers(numbers []float64, arg any) ([]float64, error) {
+	switch val := arg.(type) {
+	case float64:
+		return append(numbers, val), nil
+	case blank:
+		return numbers, nil
+	case Decimal:
+		return append(numbers, val.Float64()), nil
+	case []float64:
+		return append(numbers, val...), nil
+	case Table:
+		for _, row := range val {
+			var err error
+			numbers, err = appendNumbers(numbers, row)
+			if err != nil {
+				return nil, err
+			}
+		}
+		return numbers, nil
+	case []any:
+		for _, el := range val {
+			var err error
+			numbers, err = appendNumbers(numbers, el)
+			if err != nil {
+				return nil, err
+			}
+		}
+		return numbers, nil
+	default:
+		return nil, fmt.Errorf("expected float64, got %T", arg)
+	}
+}
+
+// vector returns one-dimensional array of values: arrays are returned as is,
+// tables with one row or one column are flattened and scalars are wrapped.
+func vector(arg any) ([]any, error) {
+	switch val := arg.(type) {
+	case []any:
+		return val, nil
+	case []float64:
+		values := make([]any, len(val))
+		for i, el := range val {
+			values[i] = el
+		}
+		return values, nil
+	case []string:
+		values := make([]any, len(val))
+		for i, el := range val {
+			values[i] = el
+		}
+		return values, nil
+	case Table, [][]any, [][]float64, [][]string:
+		t, _ := NewTable(val)
+		switch {
+		case t.Rows() == 1:
+			return t.Row(0), nil
+		case t.Cols() == 1:
+			return t.Col(0), nil
+		default:
+			return nil, errorf(ErrValue, "expected one row or column, got %dx%d table", t.Rows(), t.Cols())
+		}
+	default:
+		return []any{arg}, nil
+	}
+}
@@ -0,0 +1,232 @@
+package functions
+
+import (
+	"math/cmplx"
+	"strconv"
+	"strings"
+)
+
+// parseComplex parses complex number in Excel text form like "3+4i", "-2.5j" or "i".
+func parseComplex(s string) (complex128, error) {
+	t := s
+	if strings.HasSuffix(t, "j") {
+		t = t[:len(t)-1] + "i"
+	}
+	if strings.HasSuffix(t, "i") && (len(t) == 1 || t[len(t)-2] == '+' || t[len(t)-2] == '-') {
+		t = t[:len(t)-1] + "1i"
+	}
+	c, err := strconv.ParseComplex(t, 128)
+	if err != nil || strings.ContainsAny(t, "()") || cmplx.IsNaN(c) || cmplx.IsInf(c) {
+		return 0, errorf(ErrNum, "invalid complex number '%s'", s)
+	}
+	return c, nil
+}
+
+// complexNumber returns arg as complex128: numbers are real, text is parsed, Blank is 0.
+func complexNumber(arg any) (complex128, error) {
+	switch val := arg.(type) {
+	case complex128:
+		return val, nil
+	case string:
+		return parseComplex(val)
+	case blank:
+		return 0, nil
+	}
+	x, err := number(arg)
+	if err != nil {
+		return 0, errorf(ErrValue, "expected complex number, got %T", arg)
+	}
+	return complex(x, 0), nil
+}
+
+// appendComplex collects complex numbers from scalars, arrays and tables.
+// Blank values are skipped.
+func appendComplex(numbers []complex128, arg any) ([]complex128, error) {
+	switch val := arg.(type) {
+	case blank:
+		return numbers, nil
+	case []float64:
+		for _, x := range val {
+			numbers = append(numbers, complex(x, 0))
+		}
+		return numbers, nil
+	case []any:
+		for _, el := range val {
+			var err error
+			numbers, err = appendComplex(numbers, el)
+			if err != nil {
+				return nil, err
+			}
+		}
+		return numbers, nil
+	case Table:
+		for _, row := range val {
+			var err error
+			numbers, err = appendComplex(numbers, row)
+			if err != nil {
+				return nil, err
+			}
+		}
+		return numbers, nil
+	default:
+		c, err := complexNumber(arg)
+		if err != nil {
+			return nil, err
+		}
+		return append(numbers, c), nil
+	}
+}
+
+// complexArgs collects complex numbers of all args.
+func complexArgs(args []any) ([]complex128, error) {
+	if err := checkArgs(args, 1, -1); err != nil {
+		return nil, err
+	}
+	numbers := make([]complex128, 0, len(args))
+	for _, arg := range args {
+		var err error
+		numbers, err = appendComplex(numbers, arg)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return numbers, nil
+}
+
+// complexFunc returns function of one complex number.
+func complexFunc(fn func(complex128) any) func(args ...any) (any, error) {
+	return func(args ...any) (any, error) {
+		if err := checkArgs(args, 1, 1); err != nil {
+			return nil, err
+		}
+		c, err := complexNumber(args[0])
+		if err != nil {
+			return nil, err
+		}
+		return fn(c), nil
+	}
+}
+
+var (
+	// Imreal returns the real part of a complex number.
+	Imreal = complexFunc(func(c complex128) any { return real(c) })
+	// Imaginary returns the imaginary part of a complex number.
+	Imaginary = complexFunc(func(c complex128) any { return imag(c) })
+	// Imabs returns the absolute value of a complex number.
+	Imabs = complexFunc(func(c complex128) any { return cmplx.Abs(c) })
+)
+
+// Complex returns complex number of real and imaginary parts. The optional
+// suffix "i" or "j" is accepted for compatibility with Excel.
+func Complex(args ...any) (any, error) {
+	if err := checkArgs(args, 2, 3); err != nil {
+		return nil, err
+	}
+	re, err := number(args[0])
+	if err != nil {
+		return nil, err
+	}
+	im, err := number(args[1])
+	if err != nil {
+		return nil, err
+	}
+	if len(args) == 3 {
+		suffix, err := text(args[2])
+		if err != nil {
+			return nil, err
+		}
+		if suffix != "i" && suffix != "j" {
+			return nil, errorf(ErrValue, "suffix should be \"i\" or \"j\", got '%s'", suffix)
+		}
+	}
+	return complex(re, im), nil
+}
+
+// Imsum returns the sum of complex numbers.
+func Imsum(args ...any) (any, error) {
+	numbers, err := complexArgs(args)
+	if err != nil {
+		return nil, err
+	}
+	var res complex128
+	for _, c := range numbers {
+		res += c
+	}
+	return res, nil
+}
+
+// Improduct returns the product of complex numbers.
+func Improduct(args ...any) (any, error) {
+	numbers, err := complexArgs(args)
+	if err != nil {
+		return nil, err
+	}
+	res := complex(1, 0)
+	for _, c := range numbers {
+		res *= c
+	}
+	return res, nil
+}
+
+var complexDefinitions = []Definition{
+	{
+		Name:        "COMPLEX",
+		Func:        Complex,
+		Signature:   Signatures["Complex"],
+		Params:      []string{"real_num", "i_num", "suffix"},
+		Description: "Returns a complex number of real and imaginary parts.",
+		Examples:    []string{"COMPLEX(3; 4)"},
+		Category:    CategoryEngineering,
+		Pure:        true,
+	},
+	{
+		Name:        "IMREAL",
+		Func:        Imreal,
+		Signature:   Signatures["Imreal"],
+		Params:      []string{"inumber"},
+		Description: "Returns the real part of a complex number.",
+		Examples:    []string{`IMREAL("6-9i") = 6`},
+		Category:    CategoryEngineering,
+		Pure:        true,
+	},
+	{
+		Name:        "IMAGINARY",
+		Func:        Imaginary,
+		Signature:   Signatures["Imaginary"],
+		Params:      []string{"inumber"},
+		Description: "Returns the imaginary part of a complex number.",
+		Examples:    []string{`IMAGINARY("3+4i") = 4`},
+		Category:    CategoryEngineering,
+		Pure:        true,
+	},
+	{
+		Name:        "IMABS",
+		Func:        Imabs,
+		Signature:   Signatures["Imabs"],
+		Params:      []string{"inumber"},
+		Description: "Returns the absolute value of a complex number.",
+		Examples:    []string{`IMABS("5+12i") = 13`},
+		Category:    CategoryEngineering,
+		Pure:        true,
+	},
+	{
+		Name:        "IMSUM",
+		Func:        Imsum,
+		Signature:   Signatures["Imsum"],
+		Params:      []string{"inumber", "inumber"},
+		Description: "Returns the sum of complex numbers.",
+		Examples:    []string{`IMSUM("3+4i"; "5-3i")`},
+		Category:    CategoryEngineering,
+		Pure:        true,
+	},
+	{
+		Name:        "IMPRODUCT",
+		Func:        Improduct,
+		Signature:   Signatures["Improduct"],
+		Params:      []string{"inumber", "inumber"},
+		Description: "Returns the product of complex numbers.",
+		Examples:    []string{`IMPRODUCT("3+4i"; "5-3i")`},
+		Category:    CategoryEngineering,
+		Pure:        true,
+	},
+}
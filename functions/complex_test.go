@@ -0,0 +1,70 @@
+package functions
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestParseComplex(t *testing.T) {
+	cases := map[string]complex128{
+		"3+4i":      complex(3, 4),
+		"-2.5j":     complex(0, -2.5),
+		"i":         complex(0, 1),
+		"5-i":       complex(5, -1),
+		"4":         complex(4, 0),
+		"1e3-2e-1i": complex(1000, -0.2),
+	}
+	for input, expected := range cases {
+		c, err := parseComplex(input)
+		if err != nil {
+			t.Fatalf("%s: expected nil error, got %s", input, err)
+		}
+		if c != expected {
+			t.Errorf("%s: expected %v, got %v", input, expected, c)
+		}
+	}
+	for _, input := range []string{"", "3+4k", "(1+2i)", "Inf", "i3"} {
+		if _, err := parseComplex(input); !errors.Is(err, ErrNum) {
+			t.Errorf("%s: expected #NUM!, got %v", input, err)
+		}
+	}
+}
+
+func TestComplexFunctions(t *testing.T) {
+	cases := []struct {
+		name     string
+		fn       func(args ...any) (any, error)
+		args     []any
+		expected any
+	}{
+		{"Complex", Complex, []any{3., 4.}, complex(3, 4)},
+		{"Complex", Complex, []any{0., 1., "j"}, complex(0, 1)},
+		{"Imreal", Imreal, []any{"6-9i"}, 6.},
+		{"Imaginary", Imaginary, []any{complex(3, 4)}, 4.},
+		{"Imaginary", Imaginary, []any{5.}, 0.},
+		{"Imabs", Imabs, []any{"5+12i"}, 13.},
+		{"Imsum", Imsum, []any{"3+4i", "5-3i"}, complex(8, 1)},
+		{"Imsum", Imsum, []any{[]any{complex(1, 1), 2.}, []float64{3}, Blank}, complex(6, 1)},
+		{"Improduct", Improduct, []any{"3+4i", "5-3i"}, complex(27, 11)},
+		{"Improduct", Improduct, []any{"1+2i", 30.}, complex(30, 60)},
+	}
+	for _, c := range cases {
+		res, err := c.fn(c.args...)
+		if err != nil {
+			t.Fatalf("%s%v: expected nil error, got %s", c.name, c.args, err)
+		}
+		if res != c.expected {
+			t.Errorf("%s%v: expected %v, got %v", c.name, c.args, c.expected, res)
+		}
+	}
+	if _, err := Complex(1., 1., "k"); !errors.Is(err, ErrValue) {
+		t.Errorf("expected #VALUE! for invalid suffix, got %v", err)
+	}
+	if _, err := Imabs(1 == 1); !errors.Is(err, ErrValue) {
+		t.Errorf("expected #VALUE! for bool, got %v", err)
+	}
+	if res, _ := Imabs(complex(1, 1)); math.Abs(res.(float64)-math.Sqrt2) > 1e-12 {
+		t.Errorf("expected √2, got %v", res)
+	}
+}
@@ -0,0 +1,261 @@
+package functions
+
+// criteriaMask returns which elements of ranges satisfy all criteria.
+// args are pairs of range and criteria, n is expected length of ranges.
+func criteriaMask(args []any, n int) ([]bool, error) {
+	if len(args)%2 != 0 {
+		return nil, errorf(ErrValue, "expected pairs of range and criteria, got %d args", len(args))
+	}
+	mask := make([]bool, n)
+	for i := range mask {
+		mask[i] = true
+	}
+	for i := 0; i < len(args); i += 2 {
+		values, err := vector(args[i])
+		if err != nil {
+			return nil, err
+		}
+		if len(values) != n {
+			return nil, errorf(ErrValue, "expected ranges of length %d, got %d", n, len(values))
+		}
+		criteria, err := ParseCriteria(args[i+1])
+		if err != nil {
+			return nil, err
+		}
+		for j, val := range values {
+			mask[j] = mask[j] && criteria.Match(val)
+		}
+	}
+	return mask, nil
+}
+
+// selected returns numbers of values which satisfy criteria, other values are
+// ignored. Numbers are float64 or Decimal values.
+func selected(valuesArg any, criteria []any) ([]any, error) {
+	values, err := vector(valuesArg)
+	if err != nil {
+		return nil, err
+	}
+	mask, err := criteriaMask(criteria, len(values))
+	if err != nil {
+		return nil, err
+	}
+	numbers := make([]any, 0, len(values))
+	for i, val := range values {
+		switch val.(type) {
+		case float64, Decimal:
+			if mask[i] {
+				numbers = append(numbers, val)
+			}
+		}
+	}
+	return numbers, nil
+}
+
+// selectedIf returns numbers for functions like SUMIF: range, criteria and
+// optional range of values, the first range is used if it's omitted.
+func selectedIf(args []any) ([]any, error) {
+	if err := checkArgs(args, 2, 3); err != nil {
+		return nil, err
+	}
+	values := args[0]
+	if len(args) == 3 {
+		values = args[2]
+	}
+	return selected(values, args[:2])
+}
+
+// selectedIfs returns numbers for functions like SUMIFS: range of values
+// followed by pairs of range and criteria.
+func selectedIfs(args []any) ([]any, error) {
+	if err := checkArgs(args, 3, -1); err != nil {
+		return nil, err
+	}
+	return selected(args[0], args[1:])
+}
+
+func average(numbers []any) (any, error) {
+	if len(numbers) == 0 {
+		return nil, errorf(ErrDiv0, "no values satisfy criteria")
+	}
+	return Mean(numbers...)
+}
+
+// extremum returns the minimum (sign is -1) or maximum (sign is 1) number, 0 if there are no numbers.
+func extremum(numbers []any, sign int) (any, error) {
+	switch {
+	case len(numbers) == 0:
+		return 0., nil
+	case sign > 0:
+		return Max(numbers...)
+	default:
+		return Min(numbers...)
+	}
+}
+
+// Sumif returns sum of values whose range elements satisfy criteria.
+func Sumif(args ...any) (any, error) {
+	numbers, err := selectedIf(args)
+	if err != nil {
+		return nil, err
+	}
+	return Sum(numbers...)
+}
+
+// Sumifs returns sum of values whose range elements satisfy all criteria.
+func Sumifs(args ...any) (any, error) {
+	numbers, err := selectedIfs(args)
+	if err != nil {
+		return nil, err
+	}
+	return Sum(numbers...)
+}
+
+// Countif returns number of range elements which satisfy criteria.
+func Countif(args ...any) (any, error) {
+	if err := checkArgs(args, 2, 2); err != nil {
+		return nil, err
+	}
+	return Countifs(args...)
+}
+
+// Countifs returns number of positions where elements of all ranges satisfy their criteria.
+func Countifs(args ...any) (any, error) {
+	if err := checkArgs(args, 2, -1); err != nil {
+		return nil, err
+	}
+	values, err := vector(args[0])
+	if err != nil {
+		return nil, err
+	}
+	mask, err := criteriaMask(args, len(values))
+	if err != nil {
+		return nil, err
+	}
+	var count float64
+	for _, ok := range mask {
+		if ok {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// Averageif returns mean of values whose range elements satisfy criteria.
+func Averageif(args ...any) (any, error) {
+	numbers, err := selectedIf(args)
+	if err != nil {
+		return nil, err
+	}
+	return average(numbers)
+}
+
+// Averageifs returns mean of values whose range elements satisfy all criteria.
+func Averageifs(args ...any) (any, error) {
+	numbers, err := selectedIfs(args)
+	if err != nil {
+		return nil, err
+	}
+	return average(numbers)
+}
+
+// Maxifs returns the largest value whose range elements satisfy all criteria, 0 if there are none.
+func Maxifs(args ...any) (any, error) {
+	numbers, err := selectedIfs(args)
+	if err != nil {
+		return nil, err
+	}
+	return extremum(numbers, 1)
+}
+
+// Minifs returns the smallest value whose range elements satisfy all criteria, 0 if there are none.
+func Minifs(args ...any) (any, error) {
+	numbers, err := selectedIfs(args)
+	if err != nil {
+		return nil, err
+	}
+	return extremum(numbers, -1)
+}
+
+var conditionalDefinitions = []Definition{
+	{
+		Name:        "SUMIF",
+		Func:        Sumif,
+		Signature:   Signatures["Sumif"],
+		Params:      []string{"range", "criteria", "sum_range"},
+		Description: "Adds values whose range elements satisfy criteria.",
+		Examples:    []string{`SUMIF(Amounts; ">=10")`, `SUMIF(Regions; "north*"; Amounts)`},
+		Category:    CategoryMath,
+		Pure:        true,
+	},
+	{
+		Name:        "SUMIFS",
+		Func:        Sumifs,
+		Signature:   Signatures["Sumifs"],
+		Params:      []string{"sum_range", "range", "criteria", "range", "criteria"},
+		Description: "Adds values whose range elements satisfy all criteria.",
+		Examples:    []string{`SUMIFS(Amounts; Regions; "<>south"; Amounts; ">0")`},
+		Category:    CategoryMath,
+		Pure:        true,
+	},
+	{
+		Name:        "COUNTIF",
+		Func:        Countif,
+		Signature:   Signatures["Countif"],
+		Params:      []string{"range", "criteria"},
+		Description: "Counts range elements which satisfy criteria.",
+		Examples:    []string{`COUNTIF(Names; "a?c")`},
+		Category:    CategoryStatistical,
+		Pure:        true,
+	},
+	{
+		Name:        "COUNTIFS",
+		Func:        Countifs,
+		Signature:   Signatures["Countifs"],
+		Params:      []string{"range", "criteria", "range", "criteria"},
+		Description: "Counts positions where elements of all ranges satisfy their criteria.",
+		Examples:    []string{`COUNTIFS(Regions; "north"; Amounts; ">100")`},
+		Category:    CategoryStatistical,
+		Pure:        true,
+	},
+	{
+		Name:        "AVERAGEIF",
+		Func:        Averageif,
+		Signature:   Signatures["Averageif"],
+		Params:      []string{"range", "criteria", "average_range"},
+		Description: "Returns the mean of values whose range elements satisfy criteria.",
+		Examples:    []string{`AVERAGEIF(Amounts; "<>0")`},
+		Category:    CategoryStatistical,
+		Pure:        true,
+	},
+	{
+		Name:        "AVERAGEIFS",
+		Func:        Averageifs,
+		Signature:   Signatures["Averageifs"],
+		Params:      []string{"average_range", "range", "criteria", "range", "criteria"},
+		Description: "Returns the mean of values whose range elements satisfy all criteria.",
+		Examples:    []string{`AVERAGEIFS(Amounts; Regions; "north")`},
+		Category:    CategoryStatistical,
+		Pure:        true,
+	},
+	{
+		Name:        "MAXIFS",
+		Func:        Maxifs,
+		Signature:   Signatures["Maxifs"],
+		Params:      []string{"max_range", "range", "criteria", "range", "criteria"},
+		Description: "Returns the largest value whose range elements satisfy all criteria.",
+		Examples:    []string{`MAXIFS(Amounts; Regions; "north")`},
+		Category:    CategoryStatistical,
+		Pure:        true,
+	},
+	{
+		Name:        "MINIFS",
+		Func:        Minifs,
+		Signature:   Signatures["Minifs"],
+		Params:      []string{"min_range", "range", "criteria", "range", "criteria"},
+		Description: "Returns the smallest value whose range elements satisfy all criteria.",
+		Examples:    []string{`MINIFS(Amounts; Regions; "north")`},
+		Category:    CategoryStatistical,
+		Pure:        true,
+	},
+}
@@ -0,0 +1,54 @@
+package functions
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestConditional(t *testing.T) {
+	regions := []any{"north", "south", "North-East", "west", "south"}
+	amounts := []float64{100, 200, 300, 400, 500}
+	mixed := []any{10., "n/a", 30., nil, 50.}
+	cases := []struct {
+		name     string
+		fn       func(args ...any) (any, error)
+		args     []any
+		expected float64
+	}{
+		{"Sumif", Sumif, []any{amounts, ">=300"}, 1200},
+		{"Sumif", Sumif, []any{regions, "north*", amounts}, 400},
+		{"Sumif", Sumif, []any{mixed, "<>30"}, 60},
+		{"Sumifs", Sumifs, []any{amounts, regions, "south", amounts, ">200"}, 500},
+		{"Countif", Countif, []any{regions, "south"}, 2},
+		{"Countif", Countif, []any{mixed, ""}, 1},
+		{"Countif", Countif, []any{mixed, "<>"}, 4},
+		{"Countifs", Countifs, []any{regions, "<>south", amounts, "<400"}, 2},
+		{"Averageif", Averageif, []any{regions, "south", amounts}, 350},
+		{"Averageifs", Averageifs, []any{amounts, regions, "????"}, 400},
+		{"Maxifs", Maxifs, []any{amounts, regions, "south"}, 500},
+		{"Maxifs", Maxifs, []any{amounts, regions, "east"}, 0},
+		{"Minifs", Minifs, []any{amounts, regions, "*th*", amounts, ">100"}, 200},
+	}
+	for _, c := range cases {
+		res, err := c.fn(c.args...)
+		if err != nil {
+			t.Fatalf("%s%v: expected nil error, got %s", c.name, c.args, err)
+		}
+		if res != c.expected {
+			t.Errorf("%s%v: expected %v, got %v", c.name, c.args, c.expected, res)
+		}
+	}
+}
+
+func TestConditional_Errors(t *testing.T) {
+	amounts := []float64{100, 200}
+	if _, err := Averageif(amounts, ">1000"); !errors.Is(err, ErrDiv0) {
+		t.Errorf("expected #DIV/0!, got %v", err)
+	}
+	if _, err := Sumifs(amounts, []float64{1, 2, 3}, ">0"); !errors.Is(err, ErrValue) {
+		t.Errorf("expected #VALUE!, got %v", err)
+	}
+	if _, err := Countifs(amounts, ">0", amounts); !errors.Is(err, ErrValue) {
+		t.Errorf("expected #VALUE!, got %v", err)
+	}
+}
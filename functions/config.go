@@ -0,0 +1,47 @@
+package functions
+
+import (
+	"math/rand"
+	"time"
+)
+
+// Config configures functions which depend on environment.
+type Config struct {
+	// Clock returns current time for TODAY and NOW, time.Now if nil.
+	Clock func() time.Time
+	// Location of dates created by DATE, time.UTC if nil.
+	Location *time.Location
+	// SerialDates makes date functions return Excel serial numbers instead of time.Time.
+	SerialDates bool
+	// Solver configures iterative solvers of IRR, XIRR and RATE.
+	Solver Solver
+	// Regex configures REGEXMATCH, REGEXEXTRACT and REGEXREPLACE.
+	Regex Regex
+	// Random is a source of RAND, RANDBETWEEN and RANDARRAY, seeded with
+	// current time if nil. Seed it for reproducible results.
+	Random rand.Source
+	// Rates converts money in EXCHANGE, which fails with #N/A if nil.
+	Rates ExchangeRates
+	// Units is a table of units of QUANTITY and CONVERT, NewUnits() if nil.
+	Units *Units
+}
+
+// Solver configures Newton's method used to find roots of equations.
+type Solver struct {
+	// Tolerance is the maximum difference of two successive approximations
+	// of a converged solution, 1e-7 if zero.
+	Tolerance float64
+	// MaxIterations is the limit of iterations, 100 if zero.
+	MaxIterations int
+}
+
+// Regex configures limits of regular expression functions. Compiled patterns
+// are cached by the registry, so each interpreter has its own cache.
+type Regex struct {
+	// CacheSize is the number of compiled patterns kept, 64 if zero.
+	CacheSize int
+	// MaxPattern is the maximum length of a pattern in bytes, 1024 if zero.
+	MaxPattern int
+	// MaxInput is the maximum length of text and result in bytes, 1 MiB if zero.
+	MaxInput int
+}
@@ -0,0 +1,151 @@
+package functions
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Criteria is a condition of conditional functions like SUMIF and COUNTIF.
+//
+// A criteria string is an optional comparison operator (=, <>, <, <=, >, >=)
+// followed by an operand. Numeric operands are compared with numbers, TRUE and
+// FALSE with booleans, other operands with text ignoring case. Text operands of
+// = and <> may contain wildcards: * matches any sequence of characters, ? any
+// character and ~ escapes the next character. An empty operand matches blank
+// values: "" and "=" match blanks, "<>" matches everything else. Numbers and
+// booleans given as criteria match equal values.
+type Criteria struct {
+	op    criteriaOp
+	value any            // operand: float64, bool, string or nil for blank
+	re    *regexp.Regexp // wildcard pattern of text operand
+}
+
+// criteriaOp is a comparison operator of criteria.
+type criteriaOp string
+
+const (
+	criteriaEQ  criteriaOp = "="
+	criteriaNE  criteriaOp = "<>"
+	criteriaLT  criteriaOp = "<"
+	criteriaLTE criteriaOp = "<="
+	criteriaGT  criteriaOp = ">"
+	criteriaGTE criteriaOp = ">="
+)
+
+// operators are ordered so that longer operators are matched first.
+var criteriaOperators = []criteriaOp{criteriaLTE, criteriaGTE, criteriaNE, criteriaLT, criteriaGT, criteriaEQ}
+
+// ParseCriteria parses criteria from a number, boolean or criteria string.
+func ParseCriteria(arg any) (*Criteria, error) {
+	switch val := arg.(type) {
+	case float64, Decimal:
+		return &Criteria{op: criteriaEQ, value: val}, nil
+	case bool:
+		return &Criteria{op: criteriaEQ, value: val}, nil
+	case time.Time:
+		return &Criteria{op: criteriaEQ, value: Serial(val)}, nil
+	case string:
+		return parseCriteriaString(val)
+	default:
+		return nil, errorf(ErrValue, "expected criteria, got %T", arg)
+	}
+}
+
+func parseCriteriaString(s string) (*Criteria, error) {
+	c := &Criteria{op: criteriaEQ}
+	for _, op := range criteriaOperators {
+		if strings.HasPrefix(s, string(op)) {
+			c.op = op
+			s = s[len(op):]
+			break
+		}
+	}
+	switch {
+	case s == "":
+		return c, nil
+	case strings.EqualFold(s, "TRUE"):
+		c.value = true
+	case strings.EqualFold(s, "FALSE"):
+		c.value = false
+	default:
+		if number, err := strconv.ParseFloat(strings.Replace(s, ",", ".", 1), 64); err == nil {
+			c.value = number
+			return c, nil
+		}
+		c.value = s
+		if c.op == criteriaEQ || c.op == criteriaNE {
+			re, err := wildcardRegexp(s, true)
+			if err != nil {
+				return nil, err
+			}
+			c.re = re
+		}
+	}
+	return c, nil
+}
+
+// Match reports whether value satisfies the criteria.
+func (c *Criteria) Match(value any) bool {
+	if c.value == nil {
+		empty := value == nil || value == "" || value == Blank
+		return empty == (c.op == criteriaEQ)
+	}
+	cmp, ok := c.compare(value)
+	if !ok {
+		// values of different types are never equal
+		return c.op == criteriaNE
+	}
+	switch c.op {
+	case criteriaEQ:
+		return cmp == 0
+	case criteriaNE:
+		return cmp != 0
+	case criteriaLT:
+		return cmp < 0
+	case criteriaLTE:
+		return cmp <= 0
+	case criteriaGT:
+		return cmp > 0
+	default:
+		return cmp >= 0
+	}
+}
+
+// compare compares value with the operand. ok is false if they can't be compared.
+func (c *Criteria) compare(value any) (cmp int, ok bool) {
+	switch operand := c.value.(type) {
+	case float64, Decimal:
+		switch val := value.(type) {
+		case float64, Decimal:
+			return compareCells(val, operand)
+		case time.Time:
+			return compareCells(Serial(val), operand)
+		case string:
+			if c.op != criteriaEQ && c.op != criteriaNE {
+				return 0, false
+			}
+			number, err := strconv.ParseFloat(strings.Replace(val, ",", ".", 1), 64)
+			if err != nil {
+				return 0, false
+			}
+			return compareCells(number, operand)
+		}
+		return 0, false
+	case string:
+		val, ok := value.(string)
+		if !ok {
+			return 0, false
+		}
+		if c.re != nil {
+			if c.re.MatchString(val) {
+				return 0, true
+			}
+			return 1, true
+		}
+		return compareCells(val, operand)
+	default:
+		return compareCells(value, operand)
+	}
+}
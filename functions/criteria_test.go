@@ -0,0 +1,61 @@
+package functions
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCriteria_Match(t *testing.T) {
+	cases := []struct {
+		criteria any
+		value    any
+		expected bool
+	}{
+		{10., 10., true},
+		{10., "10", true},
+		{10., 11., false},
+		{MustDecimal("0.1"), 0.1, true},
+		{MustDecimal("0.1"), "0,1", true},
+		{">0,1", MustDecimal("0.2"), true},
+		{MustDecimal("10"), MustDecimal("10.00"), true},
+		{">=10", 10., true},
+		{">=10", 9.5, false},
+		{">10,5", 11., true},
+		{"<10", "5", false},
+		{"<>10", "foo", true},
+		{"<>10", 10., false},
+		{"=foo", "FOO", true},
+		{"foo", "food", false},
+		{"<>foo", "bar", true},
+		{"<>foo", "Foo", false},
+		{"fo*", "food", true},
+		{"f?o", "fao", true},
+		{"f?o", "fo", false},
+		{"~*", "*", true},
+		{"~*", "a", false},
+		{"<b", "Apple", true},
+		{"<b", 1., false},
+		{"TRUE", true, true},
+		{"TRUE", "TRUE", false},
+		{false, false, true},
+		{"", nil, true},
+		{"", "", true},
+		{"", 0., false},
+		{"=", "", true},
+		{"<>", "", false},
+		{"<>", 0., true},
+		{">=45351", time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC), true},
+	}
+	for _, c := range cases {
+		criteria, err := ParseCriteria(c.criteria)
+		if err != nil {
+			t.Fatalf("criteria %v: expected nil error, got %s", c.criteria, err)
+		}
+		if res := criteria.Match(c.value); res != c.expected {
+			t.Errorf("criteria %#v, value %#v: expected %v, got %v", c.criteria, c.value, c.expected, res)
+		}
+	}
+	if _, err := ParseCriteria([]any{}); err == nil {
+		t.Errorf("expected error for array criteria")
+	}
+}
@@ -0,0 +1,506 @@
+package functions
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+)
+
+// excelEpoch is the day before serial number 1 (1900-01-01) for dates after
+// the fictitious 1900-02-29 (serial 60) of Excel 1900 date system.
+var excelEpoch = time.Date(1899, 12, 30, 0, 0, 0, 0, time.UTC)
+
+// Serial returns Excel serial number of t in 1900 date system, including the
+// fictitious 1900-02-29 leap day: 1900-03-01 is 61, 1900-02-28 is 59.
+func Serial(t time.Time) float64 {
+	days := civilDays(excelEpoch, t)
+	if days < 61 {
+		days--
+	}
+	return float64(days) + timeOfDay(t)
+}
+
+// FromSerial returns time of Excel serial number in 1900 date system.
+// The fictitious 1900-02-29 (serial 60) is returned as 1900-03-01.
+func FromSerial(serial float64, loc *time.Location) time.Time {
+	days := math.Floor(serial)
+	if days <= 60 {
+		days++
+	}
+	t := time.Date(1899, 12, 30+int(days), 0, 0, 0, 0, loc)
+	return t.Add(time.Duration(math.Round((serial - math.Floor(serial)) * float64(24*time.Hour))))
+}
+
+// civilDays returns number of calendar days from a to b ignoring time of day.
+func civilDays(a, b time.Time) int {
+	ay, am, ad := a.Date()
+	by, bm, bd := b.Date()
+	from := time.Date(ay, am, ad, 0, 0, 0, 0, time.UTC)
+	to := time.Date(by, bm, bd, 0, 0, 0, 0, time.UTC)
+	// time.Duration can't hold differences longer than about 292 years
+	return int((to.Unix() - from.Unix()) / 86400)
+}
+
+// timeOfDay returns time of day as a fraction of day.
+func timeOfDay(t time.Time) float64 {
+	h, m, s := t.Clock()
+	return (float64(h*3600+m*60+s) + float64(t.Nanosecond())/1e9) / 86400
+}
+
+const (
+	// maxDaysShift is the number of days from 0001-01-01 to 9999-12-31.
+	maxDaysShift = 3652058
+	// maxSerial is the serial number of 9999-12-31, the last date of Excel.
+	maxSerial = 2958465
+	// maxMonthsShift is the number of months from 0001-01 to 9999-12.
+	maxMonthsShift = 9999 * 12
+)
+
+// AddDays returns t moved by days, fractional part is a time of day.
+func AddDays(t time.Time, days float64) (time.Time, error) {
+	if !(math.Abs(days) <= maxDaysShift) {
+		return time.Time{}, errorf(ErrNum, "can't move date by %v days", days)
+	}
+	whole := math.Floor(days)
+	return t.AddDate(0, 0, int(whole)).Add(time.Duration(math.Round((days - whole) * float64(24*time.Hour)))), nil
+}
+
+// DaysBetween returns number of days from a to b, fractional part is a difference of times of day.
+func DaysBetween(a, b time.Time) float64 {
+	return float64(civilDays(a, b)) + timeOfDay(b) - timeOfDay(a)
+}
+
+// dates implements date functions bound to a config.
+type dates struct {
+	config Config
+}
+
+func (d *dates) now() time.Time {
+	if d.config.Clock != nil {
+		return d.config.Clock()
+	}
+	return time.Now()
+}
+
+func (d *dates) location() *time.Location {
+	if d.config.Location != nil {
+		return d.config.Location
+	}
+	return time.UTC
+}
+
+// resultType returns type of dates returned by functions.
+func (d *dates) resultType() Type {
+	if d.config.SerialDates {
+		return TypeNumber
+	}
+	return TypeDate
+}
+
+// result returns date as time.Time or serial number depending on config.
+func (d *dates) result(t time.Time) any {
+	if d.config.SerialDates {
+		return Serial(t)
+	}
+	return t
+}
+
+// date returns arg as time: time.Time, serial number or ISO 8601 date string.
+func (d *dates) date(arg any) (time.Time, error) {
+	switch val := arg.(type) {
+	case time.Time:
+		return val, nil
+	case float64:
+		if !(val >= 0 && val < maxSerial+1) {
+			return time.Time{}, errorf(ErrNum, "date serial %v is out of range [0; %d]", val, maxSerial)
+		}
+		return FromSerial(val, d.location()), nil
+	case Decimal:
+		return d.date(val.Float64())
+	case string:
+		for _, layout := range []string{"2006-01-02", "2006-01-02 15:04:05", time.RFC3339} {
+			if t, err := time.ParseInLocation(layout, val, d.location()); err == nil {
+				return t, nil
+			}
+		}
+		return time.Time{}, errorf(ErrValue, "can't parse date '%s'", val)
+	default:
+		return time.Time{}, fmt.Errorf("expected date, got %T", arg)
+	}
+}
+
+func (d *dates) Date(args ...any) (any, error) {
+	if err := checkArgs(args, 3, 3); err != nil {
+		return nil, err
+	}
+	parts := make([]int, 3)
+	for i, arg := range args {
+		val, err := number(arg)
+		if err != nil {
+			return nil, err
+		}
+		if !(math.Abs(val) <= maxDaysShift) {
+			return nil, errorf(ErrNum, "date part %v is out of range", val)
+		}
+		parts[i] = int(math.Trunc(val))
+	}
+	year := parts[0]
+	if year < 1900 {
+		year += 1900
+	}
+	if year < 1900 || year > 9999 {
+		return nil, errorf(ErrNum, "year should be in [1900; 9999], got %d", year)
+	}
+	return d.checked(time.Date(year, time.Month(parts[1]), parts[2], 0, 0, 0, 0, d.location()))
+}
+
+// checked returns result of date t or #NUM! if it's after 9999-12-31 or
+// before 0001-01-01.
+func (d *dates) checked(t time.Time) (any, error) {
+	if t.Year() < 1 || t.Year() > 9999 {
+		return nil, errorf(ErrNum, "date %s is out of range", t.Format("2006-01-02"))
+	}
+	return d.result(t), nil
+}
+
+func (d *dates) Today(args ...any) (any, error) {
+	if err := checkArgs(args, 0, 0); err != nil {
+		return nil, err
+	}
+	y, m, day := d.now().In(d.location()).Date()
+	return d.result(time.Date(y, m, day, 0, 0, 0, 0, d.location())), nil
+}
+
+func (d *dates) Now(args ...any) (any, error) {
+	if err := checkArgs(args, 0, 0); err != nil {
+		return nil, err
+	}
+	return d.result(d.now()), nil
+}
+
+// datePart returns a function extracting a component of date.
+func (d *dates) datePart(part func(t time.Time) int) func(args ...any) (any, error) {
+	return func(args ...any) (any, error) {
+		if err := checkArgs(args, 1, 1); err != nil {
+			return nil, err
+		}
+		t, err := d.date(args[0])
+		if err != nil {
+			return nil, err
+		}
+		return float64(part(t)), nil
+	}
+}
+
+// Weekday returns day of week: 1 (Sunday) to 7 for type 1, 1 (Monday)
+// to 7 for type 2 and 0 (Monday) to 6 for type 3.
+func (d *dates) Weekday(args ...any) (any, error) {
+	if err := checkArgs(args, 1, 2); err != nil {
+		return nil, err
+	}
+	t, err := d.date(args[0])
+	if err != nil {
+		return nil, err
+	}
+	kind, err := optionalNumber(args, 1, 1)
+	if err != nil {
+		return nil, err
+	}
+	wd := int(t.Weekday())
+	switch kind {
+	case 1:
+		return float64(wd + 1), nil
+	case 2:
+		return float64((wd+6)%7 + 1), nil
+	case 3:
+		return float64((wd + 6) % 7), nil
+	default:
+		return nil, errorf(ErrNum, "unknown weekday type %f", kind)
+	}
+}
+
+// addMonths adds months to t clamping day to the end of month.
+func addMonths(t time.Time, months int) time.Time {
+	y, m, day := t.Date()
+	first := time.Date(y, m+time.Month(months), 1, 0, 0, 0, 0, t.Location())
+	if last := daysIn(first); day > last {
+		day = last
+	}
+	return time.Date(first.Year(), first.Month(), day, 0, 0, 0, 0, t.Location())
+}
+
+func daysIn(t time.Time) int {
+	return time.Date(t.Year(), t.Month()+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
+
+func (d *dates) dateAndMonths(args []any) (time.Time, int, error) {
+	if err := checkArgs(args, 2, 2); err != nil {
+		return time.Time{}, 0, err
+	}
+	t, err := d.date(args[0])
+	if err != nil {
+		return time.Time{}, 0, err
+	}
+	months, err := number(args[1])
+	if err != nil {
+		return time.Time{}, 0, err
+	}
+	if !(math.Abs(months) <= maxMonthsShift) {
+		return time.Time{}, 0, errorf(ErrNum, "can't move date by %v months", months)
+	}
+	return t, int(math.Trunc(months)), nil
+}
+
+// Edate returns the date months before or after start date.
+func (d *dates) Edate(args ...any) (any, error) {
+	t, months, err := d.dateAndMonths(args)
+	if err != nil {
+		return nil, err
+	}
+	return d.checked(addMonths(t, months))
+}
+
+// Eomonth returns the last day of the month months before or after start date.
+func (d *dates) Eomonth(args ...any) (any, error) {
+	t, months, err := d.dateAndMonths(args)
+	if err != nil {
+		return nil, err
+	}
+	t = addMonths(time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, t.Location()), months)
+	return d.checked(time.Date(t.Year(), t.Month(), daysIn(t), 0, 0, 0, 0, t.Location()))
+}
+
+// Days returns number of days from start date to end date, arguments are (end; start).
+func (d *dates) Days(args ...any) (any, error) {
+	if err := checkArgs(args, 2, 2); err != nil {
+		return nil, err
+	}
+	end, err := d.date(args[0])
+	if err != nil {
+		return nil, err
+	}
+	start, err := d.date(args[1])
+	if err != nil {
+		return nil, err
+	}
+	return float64(civilDays(start, end)), nil
+}
+
+// Datedif returns difference between dates in units: "Y", "M", "D", "MD", "YM" or "YD".
+func (d *dates) Datedif(args ...any) (any, error) {
+	if err := checkArgs(args, 3, 3); err != nil {
+		return nil, err
+	}
+	start, err := d.date(args[0])
+	if err != nil {
+		return nil, err
+	}
+	end, err := d.date(args[1])
+	if err != nil {
+		return nil, err
+	}
+	unit, err := text(args[2])
+	if err != nil {
+		return nil, err
+	}
+	if civilDays(start, end) < 0 {
+		return nil, errorf(ErrNum, "start date is after end date")
+	}
+	sy, sm, sd := start.Date()
+	ey, em, ed := end.Date()
+	months := (ey-sy)*12 + int(em) - int(sm)
+	if ed < sd {
+		months--
+	}
+	switch strings.ToUpper(unit) {
+	case "D":
+		return float64(civilDays(start, end)), nil
+	case "M":
+		return float64(months), nil
+	case "Y":
+		return float64(months / 12), nil
+	case "YM":
+		return float64(months % 12), nil
+	case "MD":
+		days := ed - sd
+		if days < 0 {
+			// start day is clamped to the end of the month before end date
+			last := daysIn(time.Date(ey, em-1, 1, 0, 0, 0, 0, time.UTC))
+			if sd > last {
+				sd = last
+			}
+			days = last - sd + ed
+		}
+		return float64(days), nil
+	case "YD":
+		shifted := time.Date(ey, sm, sd, 0, 0, 0, 0, time.UTC)
+		if civilDays(shifted, end) < 0 {
+			shifted = time.Date(ey-1, sm, sd, 0, 0, 0, 0, time.UTC)
+		}
+		return float64(civilDays(shifted, end)), nil
+	default:
+		return nil, errorf(ErrNum, "unknown unit '%s'", unit)
+	}
+}
+
+// Networkdays returns number of whole working days (Monday to Friday) between
+// dates inclusive, excluding holidays. The result is negative if start is after end.
+func (d *dates) Networkdays(args ...any) (any, error) {
+	if err := checkArgs(args, 2, 3); err != nil {
+		return nil, err
+	}
+	start, err := d.date(args[0])
+	if err != nil {
+		return nil, err
+	}
+	end, err := d.date(args[1])
+	if err != nil {
+		return nil, err
+	}
+	sign := 1.
+	if civilDays(start, end) < 0 {
+		sign, start, end = -1, end, start
+	}
+	holidays := map[int]bool{}
+	if len(args) == 3 {
+		values, err := vector(args[2])
+		if err != nil {
+			return nil, err
+		}
+		for _, val := range values {
+			if val == Blank {
+				continue
+			}
+			holiday, err := d.date(val)
+			if err != nil {
+				return nil, err
+			}
+			holidays[civilDays(start, holiday)] = true
+		}
+	}
+	var count float64
+	for i := 0; i <= civilDays(start, end); i++ {
+		switch start.AddDate(0, 0, i).Weekday() {
+		case time.Saturday, time.Sunday:
+			continue
+		}
+		if !holidays[i] {
+			count++
+		}
+	}
+	return sign * count, nil
+}
+
+func (d *dates) definitions() []Definition {
+	return []Definition{
+		{
+			Name:        "DATE",
+			Func:        d.Date,
+			Signature:   Signature{Params: []Type{TypeNumber, TypeNumber, TypeNumber}, Return: d.resultType()},
+			Params:      []string{"year", "month", "day"},
+			Description: "Returns the date of year, month and day.",
+			Examples:    []string{"DATE(2024; 2; 29)"},
+			Category:    CategoryDate,
+			Pure:        true,
+		},
+		{
+			Name:        "TODAY",
+			Func:        d.Today,
+			Signature:   Signature{Return: d.resultType()},
+			Description: "Returns the current date.",
+			Examples:    []string{"TODAY()"},
+			Category:    CategoryDate,
+		},
+		{
+			Name:        "NOW",
+			Func:        d.Now,
+			Signature:   Signature{Return: d.resultType()},
+			Description: "Returns the current date and time.",
+			Examples:    []string{"NOW()"},
+			Category:    CategoryDate,
+		},
+		d.partDefinition("YEAR", "Returns the year of a date.", func(t time.Time) int { return t.Year() }),
+		d.partDefinition("MONTH", "Returns the month of a date, 1 to 12.", func(t time.Time) int { return int(t.Month()) }),
+		d.partDefinition("DAY", "Returns the day of a date, 1 to 31.", func(t time.Time) int { return t.Day() }),
+		d.partDefinition("HOUR", "Returns the hour of a time, 0 to 23.", func(t time.Time) int { return t.Hour() }),
+		d.partDefinition("MINUTE", "Returns the minute of a time, 0 to 59.", func(t time.Time) int { return t.Minute() }),
+		d.partDefinition("SECOND", "Returns the second of a time, 0 to 59.", func(t time.Time) int { return t.Second() }),
+		{
+			Name:        "WEEKDAY",
+			Func:        d.Weekday,
+			Signature:   Signature{Params: []Type{dateType}, Optional: []Type{TypeNumber}, Return: TypeNumber},
+			Params:      []string{"date", "type"},
+			Description: "Returns the day of the week of a date.",
+			Examples:    []string{"WEEKDAY(DATE(2024; 1; 1)) = 2"},
+			Category:    CategoryDate,
+			Pure:        true,
+		},
+		{
+			Name:        "EDATE",
+			Func:        d.Edate,
+			Signature:   Signature{Params: []Type{dateType, TypeNumber}, Return: d.resultType()},
+			Params:      []string{"start_date", "months"},
+			Description: "Returns the date months before or after start date.",
+			Examples:    []string{"EDATE(DATE(2024; 1; 31); 1)"},
+			Category:    CategoryDate,
+			Pure:        true,
+		},
+		{
+			Name:        "EOMONTH",
+			Func:        d.Eomonth,
+			Signature:   Signature{Params: []Type{dateType, TypeNumber}, Return: d.resultType()},
+			Params:      []string{"start_date", "months"},
+			Description: "Returns the last day of the month months before or after start date.",
+			Examples:    []string{"EOMONTH(DATE(2024; 1; 15); 1)"},
+			Category:    CategoryDate,
+			Pure:        true,
+		},
+		{
+			Name:        "DAYS",
+			Func:        d.Days,
+			Signature:   Signature{Params: []Type{dateType, dateType}, Return: TypeNumber},
+			Params:      []string{"end_date", "start_date"},
+			Description: "Returns the number of days between two dates.",
+			Examples:    []string{"DAYS(DATE(2024; 3; 1); DATE(2024; 2; 1)) = 29"},
+			Category:    CategoryDate,
+			Pure:        true,
+		},
+		{
+			Name:        "DATEDIF",
+			Func:        d.Datedif,
+			Signature:   Signature{Params: []Type{dateType, dateType, TypeString}, Return: TypeNumber},
+			Params:      []string{"start_date", "end_date", "unit"},
+			Description: `Returns the difference between dates in "Y", "M", "D", "MD", "YM" or "YD" units.`,
+			Examples:    []string{`DATEDIF(DATE(2020; 5; 1); DATE(2024; 2; 1); "Y") = 3`},
+			Category:    CategoryDate,
+			Pure:        true,
+		},
+		{
+			Name:        "NETWORKDAYS",
+			Func:        d.Networkdays,
+			Signature:   Signature{Params: []Type{dateType, dateType}, Optional: []Type{dateType | TypeArray}, Return: TypeNumber},
+			Params:      []string{"start_date", "end_date", "holidays"},
+			Description: "Returns the number of working days between dates, excluding holidays.",
+			Examples:    []string{"NETWORKDAYS(DATE(2024; 1; 1); DATE(2024; 1; 31))"},
+			Category:    CategoryDate,
+			Pure:        true,
+		},
+	}
+}
+
+func (d *dates) partDefinition(name, description string, part func(t time.Time) int) Definition {
+	return Definition{
+		Name:        name,
+		Func:        d.datePart(part),
+		Signature:   Signature{Params: []Type{dateType}, Return: TypeNumber},
+		Params:      []string{"date"},
+		Description: description,
+		Examples:    []string{name + "(DATE(2024; 2; 29))"},
+		Category:    CategoryDate,
+		Pure:        true,
+	}
+}
+
+// dateType is a type of date arguments: serial numbers and ISO strings are accepted as dates.
+const dateType = TypeDate | TypeNumber | TypeString
@@ -0,0 +1,162 @@
+package functions
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+)
+
+func date(y int, m time.Month, d int) time.Time {
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+func TestSerial(t *testing.T) {
+	cases := map[float64]time.Time{
+		1:       date(1900, 1, 1),
+		59:      date(1900, 2, 28),
+		61:      date(1900, 3, 1),
+		45351:   date(2024, 2, 29),
+		45658:   date(2025, 1, 1),
+		146098:  date(2299, 12, 31),
+		2958465: date(9999, 12, 31),
+	}
+	for serial, tm := range cases {
+		if res := Serial(tm); res != serial {
+			t.Errorf("Serial(%s): expected %f, got %f", tm, serial, res)
+		}
+		if res := FromSerial(serial, time.UTC); !res.Equal(tm) {
+			t.Errorf("FromSerial(%f): expected %s, got %s", serial, tm, res)
+		}
+	}
+	// the fictitious 1900-02-29 is returned as the next day
+	if res := FromSerial(60, time.UTC); !res.Equal(date(1900, 3, 1)) {
+		t.Errorf("FromSerial(60): expected 1900-03-01, got %s", res)
+	}
+	if res := DaysBetween(date(2000, 1, 1), date(2300, 1, 1)); res != 109573 {
+		t.Errorf("expected 109573 days, got %v", res)
+	}
+	if res := DaysBetween(date(1900, 1, 1), date(9999, 1, 1)); res != 2958099 {
+		t.Errorf("expected 2958099 days, got %v", res)
+	}
+	if _, err := AddDays(date(2024, 1, 1), 1e20); !errors.Is(err, ErrNum) {
+		t.Errorf("expected #NUM! error, got '%v'", err)
+	}
+	if res, err := AddDays(date(2024, 1, 1), -0.5); err != nil || !res.Equal(time.Date(2023, 12, 31, 12, 0, 0, 0, time.UTC)) {
+		t.Errorf("expected 2023-12-31 12:00, got %v (%v)", res, err)
+	}
+	if res := Serial(time.Date(2024, 2, 29, 18, 0, 0, 0, time.UTC)); res != 45351.75 {
+		t.Errorf("expected 45351.75, got %f", res)
+	}
+}
+
+func TestDates(t *testing.T) {
+	now := time.Date(2024, 3, 15, 13, 30, 0, 0, time.UTC)
+	d := &dates{config: Config{Clock: func() time.Time { return now }}}
+	cases := []struct {
+		name     string
+		fn       func(args ...any) (any, error)
+		args     []any
+		expected any
+	}{
+		{"Date", d.Date, []any{2024., 2., 30.}, date(2024, 3, 1)},
+		{"Date", d.Date, []any{99., 1., 1.}, date(1999, 1, 1)},
+		{"Today", d.Today, nil, date(2024, 3, 15)},
+		{"Now", d.Now, nil, now},
+		{"Year", d.datePart(func(t time.Time) int { return t.Year() }), []any{45351.}, 2024.},
+		{"Weekday", d.Weekday, []any{date(2024, 1, 1)}, 2.},
+		{"Weekday", d.Weekday, []any{date(2024, 1, 1), 3.}, 0.},
+		{"Edate", d.Edate, []any{date(2024, 1, 31), 1.}, date(2024, 2, 29)},
+		{"Edate", d.Edate, []any{date(2024, 3, 31), -13.}, date(2023, 2, 28)},
+		{"Eomonth", d.Eomonth, []any{date(2024, 1, 15), 1.}, date(2024, 2, 29)},
+		{"Eomonth", d.Eomonth, []any{"2024-01-15", -1.}, date(2023, 12, 31)},
+		{"Days", d.Days, []any{date(2024, 3, 1), date(2024, 2, 1)}, 29.},
+		{"Datedif", d.Datedif, []any{date(2020, 5, 10), date(2024, 2, 1), "Y"}, 3.},
+		{"Datedif", d.Datedif, []any{date(2020, 5, 10), date(2024, 2, 1), "M"}, 44.},
+		{"Datedif", d.Datedif, []any{date(2020, 5, 10), date(2024, 2, 1), "YM"}, 8.},
+		{"Datedif", d.Datedif, []any{date(2020, 5, 10), date(2024, 2, 1), "MD"}, 22.},
+		{"Datedif", d.Datedif, []any{date(2020, 5, 10), date(2024, 2, 1), "YD"}, 267.},
+		{"Datedif", d.Datedif, []any{date(2024, 1, 1), date(2024, 1, 31), "D"}, 30.},
+		{"Datedif", d.Datedif, []any{date(2020, 1, 31), date(2020, 3, 1), "MD"}, 1.},
+		{"Datedif", d.Datedif, []any{date(2020, 1, 31), date(2020, 3, 31), "MD"}, 0.},
+		{"Networkdays", d.Networkdays, []any{date(2024, 1, 1), date(2024, 1, 31)}, 23.},
+		{"Networkdays", d.Networkdays, []any{date(2024, 1, 1), date(2024, 1, 31), []any{date(2024, 1, 1), date(2024, 1, 6)}}, 22.},
+		{"Networkdays", d.Networkdays, []any{date(2024, 1, 31), date(2024, 1, 1), date(2024, 1, 1)}, -22.},
+		{"Networkdays", d.Networkdays, []any{date(2024, 1, 1), date(2024, 1, 31), []float64{45292, 45293}}, 21.},
+		{"Networkdays", d.Networkdays, []any{date(2024, 1, 1), date(2024, 1, 31), []any{date(2024, 1, 1), Blank}}, 22.},
+		{"Year", d.datePart(func(t time.Time) int { return t.Year() }), []any{2958465.5}, 9999.},
+	}
+	for _, c := range cases {
+		res, err := c.fn(c.args...)
+		if err != nil {
+			t.Errorf("%s%v: expected nil error, got '%v'", c.name, c.args, err)
+			continue
+		}
+		if tm, ok := c.expected.(time.Time); ok {
+			if r, ok := res.(time.Time); !ok || !r.Equal(tm) {
+				t.Errorf("%s%v: expected %v, got %v", c.name, c.args, c.expected, res)
+			}
+			continue
+		}
+		if res != c.expected {
+			t.Errorf("%s%v: expected %v, got %v", c.name, c.args, c.expected, res)
+		}
+	}
+}
+
+func TestDates_OutOfRange(t *testing.T) {
+	d := &dates{}
+	cases := []struct {
+		name string
+		fn   func(args ...any) (any, error)
+		args []any
+	}{
+		{"Date", d.Date, []any{2020., 1., 1e300}},
+		{"Date", d.Date, []any{2020., -1e18, 1.}},
+		{"Date", d.Date, []any{9999., 12., 32.}},
+		{"Date", d.Date, []any{2020., 1., math.NaN()}},
+		{"Year", d.datePart(func(t time.Time) int { return t.Year() }), []any{1e300}},
+		{"Year", d.datePart(func(t time.Time) int { return t.Year() }), []any{2958466.}},
+		{"Edate", d.Edate, []any{date(2024, 1, 31), 1e18}},
+		{"Eomonth", d.Eomonth, []any{date(2024, 1, 31), -1e18}},
+		{"Eomonth", d.Eomonth, []any{date(9999, 12, 1), 1.}},
+	}
+	for _, c := range cases {
+		res, err := c.fn(c.args...)
+		if !errors.Is(err, ErrNum) || res != nil {
+			t.Errorf("%s%v: expected #NUM! error, got %v (%v)", c.name, c.args, res, err)
+		}
+	}
+}
+
+func TestDates_TodayLocation(t *testing.T) {
+	loc := time.FixedZone("UTC+10", 10*3600)
+	// late evening in UTC is the next day in the configured location
+	now := time.Date(2024, 3, 15, 20, 0, 0, 0, time.UTC)
+	d := &dates{config: Config{Clock: func() time.Time { return now }, Location: loc}}
+	today, err := d.Today()
+	if err != nil {
+		t.Fatalf("expected nil error, got '%v'", err)
+	}
+	expected, err := d.Date(2024., 3., 16.)
+	if err != nil {
+		t.Fatalf("expected nil error, got '%v'", err)
+	}
+	if !today.(time.Time).Equal(expected.(time.Time)) {
+		t.Errorf("expected %v, got %v", expected, today)
+	}
+}
+
+func TestDates_Serial(t *testing.T) {
+	d := &dates{config: Config{SerialDates: true}}
+	res, err := d.Date(2024., 2., 29.)
+	if err != nil {
+		t.Fatalf("expected nil error, got '%v'", err)
+	}
+	if res != 45351. {
+		t.Errorf("expected 45351, got %v", res)
+	}
+	if _, err := d.Datedif(2., 1., "D"); !errors.Is(err, ErrNum) {
+		t.Errorf("expected #NUM! error, got '%v'", err)
+	}
+}
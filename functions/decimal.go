@@ -0,0 +1,443 @@
+package functions
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// RoundingMode is a rule of rounding decimals.
+type RoundingMode uint8
+
+const (
+	RoundHalfUp   RoundingMode = iota // to nearest, ties away from zero
+	RoundHalfEven                     // to nearest, ties to even
+	RoundDown                         // towards zero
+	RoundUp                           // away from zero
+	RoundFloor                        // towards negative infinity
+	RoundCeiling                      // towards positive infinity
+)
+
+// DecimalContext configures inexact decimal operations.
+type DecimalContext struct {
+	Scale    int32 // number of digits after the decimal point of division results
+	Rounding RoundingMode
+}
+
+// A Decimal is an exact decimal number coef × 10^-scale.
+// The zero value is 0. Decimals are immutable.
+type Decimal struct {
+	coef  *big.Int
+	scale int32
+}
+
+var bigTen = big.NewInt(10)
+
+// NewDecimal returns decimal unscaled × 10^-scale.
+func NewDecimal(unscaled int64, scale int32) Decimal {
+	return Decimal{coef: big.NewInt(unscaled), scale: scale}.normalize()
+}
+
+// ParseDecimal parses decimal from string like "-12.345" or "1.5e3".
+func ParseDecimal(s string) (Decimal, error) {
+	mantissa, exponent := s, int64(0)
+	if i := strings.IndexAny(s, "eE"); i >= 0 {
+		exp, err := strconv.ParseInt(s[i+1:], 10, 32)
+		if err != nil {
+			return Decimal{}, fmt.Errorf("invalid decimal '%s'", s)
+		}
+		mantissa, exponent = s[:i], exp
+	}
+	digits := mantissa
+	var scale int64
+	if i := strings.IndexByte(mantissa, '.'); i >= 0 {
+		digits = mantissa[:i] + mantissa[i+1:]
+		scale = int64(len(mantissa) - i - 1)
+	}
+	coef, ok := new(big.Int).SetString(digits, 10)
+	if !ok || strings.ContainsAny(digits, "_") {
+		return Decimal{}, fmt.Errorf("invalid decimal '%s'", s)
+	}
+	scale -= exponent
+	if scale < math.MinInt32 || scale > math.MaxInt32 {
+		return Decimal{}, fmt.Errorf("decimal '%s' is out of range", s)
+	}
+	return Decimal{coef: coef, scale: int32(scale)}.normalize(), nil
+}
+
+// MustDecimal is like ParseDecimal but panics on error.
+func MustDecimal(s string) Decimal {
+	d, err := ParseDecimal(s)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+// DecimalFromFloat returns decimal with the shortest representation of f.
+func DecimalFromFloat(f float64) (Decimal, error) {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return Decimal{}, fmt.Errorf("can't convert %v to decimal", f)
+	}
+	return ParseDecimal(strconv.FormatFloat(f, 'g', -1, 64))
+}
+
+func pow10(n int32) *big.Int {
+	return new(big.Int).Exp(bigTen, big.NewInt(int64(n)), nil)
+}
+
+func (d Decimal) int() *big.Int {
+	if d.coef == nil {
+		return new(big.Int)
+	}
+	return d.coef
+}
+
+// normalize makes scale non-negative.
+func (d Decimal) normalize() Decimal {
+	if d.scale >= 0 {
+		return d
+	}
+	return Decimal{coef: new(big.Int).Mul(d.int(), pow10(-d.scale))}
+}
+
+// rescale returns coefficient of d at a greater scale.
+func (d Decimal) rescale(scale int32) *big.Int {
+	if scale == d.scale {
+		return d.int()
+	}
+	return new(big.Int).Mul(d.int(), pow10(scale-d.scale))
+}
+
+// align returns coefficients of decimals at the same scale.
+func align(a, b Decimal) (*big.Int, *big.Int, int32) {
+	scale := a.scale
+	if b.scale > scale {
+		scale = b.scale
+	}
+	return a.rescale(scale), b.rescale(scale), scale
+}
+
+// Scale returns number of digits after the decimal point.
+func (d Decimal) Scale() int32 {
+	return d.scale
+}
+
+// Precision returns number of digits of the unscaled value of d.
+func (d Decimal) Precision() int {
+	return len(new(big.Int).Abs(d.int()).String())
+}
+
+// Sign returns -1, 0 or 1.
+func (d Decimal) Sign() int {
+	return d.int().Sign()
+}
+
+// Add returns d + e.
+func (d Decimal) Add(e Decimal) Decimal {
+	a, b, scale := align(d, e)
+	return Decimal{coef: new(big.Int).Add(a, b), scale: scale}
+}
+
+// Sub returns d - e.
+func (d Decimal) Sub(e Decimal) Decimal {
+	a, b, scale := align(d, e)
+	return Decimal{coef: new(big.Int).Sub(a, b), scale: scale}
+}
+
+// Mul returns d × e.
+func (d Decimal) Mul(e Decimal) Decimal {
+	return Decimal{coef: new(big.Int).Mul(d.int(), e.int()), scale: d.scale + e.scale}
+}
+
+// Neg returns -d.
+func (d Decimal) Neg() Decimal {
+	return Decimal{coef: new(big.Int).Neg(d.int()), scale: d.scale}
+}
+
+// Cmp returns -1, 0 or 1 if d is less than, equal to or greater than e.
+func (d Decimal) Cmp(e Decimal) int {
+	a, b, _ := align(d, e)
+	return a.Cmp(b)
+}
+
+// Quo returns d / e rounded to ctx.Scale digits.
+func (d Decimal) Quo(e Decimal, ctx DecimalContext) (Decimal, error) {
+	if e.Sign() == 0 {
+		return Decimal{}, fmt.Errorf("zero division error")
+	}
+	// d/e = d.coef/e.coef × 10^(e.scale-d.scale), so the coefficient of the
+	// result is d.coef × 10^(ctx.Scale-d.scale+e.scale) / e.coef
+	num, den := new(big.Int).Set(d.int()), new(big.Int).Set(e.int())
+	if exp := ctx.Scale - d.scale + e.scale; exp >= 0 {
+		num.Mul(num, pow10(exp))
+	} else {
+		den.Mul(den, pow10(-exp))
+	}
+	return Decimal{coef: roundQuo(num, den, ctx.Rounding), scale: ctx.Scale}.normalize(), nil
+}
+
+// Pow returns d raised to integer power n. Negative powers are divided with ctx.
+func (d Decimal) Pow(n int64, ctx DecimalContext) (Decimal, error) {
+	if n < 0 {
+		p, err := d.Pow(-n, ctx)
+		if err != nil {
+			return Decimal{}, err
+		}
+		return NewDecimal(1, 0).Quo(p, ctx)
+	}
+	if int64(d.scale)*n > math.MaxInt32 {
+		return Decimal{}, fmt.Errorf("decimal power is out of range")
+	}
+	return Decimal{coef: new(big.Int).Exp(d.int(), big.NewInt(n), nil), scale: d.scale * int32(n)}, nil
+}
+
+// Round returns d rounded to scale digits after the decimal point.
+// Negative scale rounds to the left of the decimal point.
+func (d Decimal) Round(scale int32, mode RoundingMode) Decimal {
+	if d.scale <= scale {
+		return d
+	}
+	coef := roundQuo(d.int(), pow10(d.scale-scale), mode)
+	return Decimal{coef: coef, scale: scale}.normalize()
+}
+
+// trim removes trailing zeros of d after the decimal point down to scale digits.
+func (d Decimal) trim(scale int32) Decimal {
+	coef, r := new(big.Int).Set(d.int()), new(big.Int)
+	for d.scale > scale {
+		q, _ := new(big.Int).QuoRem(coef, bigTen, r)
+		if r.Sign() != 0 {
+			break
+		}
+		coef, d.scale = q, d.scale-1
+	}
+	return Decimal{coef: coef, scale: d.scale}
+}
+
+// IsInteger reports whether d has no fractional part.
+func (d Decimal) IsInteger() bool {
+	return d.scale == 0 || d.Round(0, RoundDown).Cmp(d) == 0
+}
+
+// Int64 returns integer part of d and whether it fits into int64.
+func (d Decimal) Int64() (int64, bool) {
+	i := d.Round(0, RoundDown).int()
+	return i.Int64(), i.IsInt64()
+}
+
+// Float64 returns the nearest float64 value of d.
+func (d Decimal) Float64() float64 {
+	f, _ := strconv.ParseFloat(d.String(), 64)
+	return f
+}
+
+// String returns d in plain notation with scale digits after the decimal point.
+func (d Decimal) String() string {
+	s := new(big.Int).Abs(d.int()).String()
+	if d.scale > 0 {
+		if pad := int(d.scale) - len(s) + 1; pad > 0 {
+			s = strings.Repeat("0", pad) + s
+		}
+		s = s[:len(s)-int(d.scale)] + "." + s[len(s)-int(d.scale):]
+	}
+	if d.Sign() < 0 {
+		return "-" + s
+	}
+	return s
+}
+
+// roundQuo returns num / den rounded with mode.
+func roundQuo(num, den *big.Int, mode RoundingMode) *big.Int {
+	q, r := new(big.Int).QuoRem(num, den, new(big.Int))
+	if r.Sign() == 0 {
+		return q
+	}
+	sign := num.Sign() * den.Sign()
+	// compare the remainder with a half of the divisor
+	half := new(big.Int).Abs(r)
+	half.Lsh(half, 1)
+	cmp := half.Cmp(new(big.Int).Abs(den))
+	var away bool
+	switch mode {
+	case RoundHalfUp:
+		away = cmp >= 0
+	case RoundHalfEven:
+		away = cmp > 0 || cmp == 0 && q.Bit(0) == 1
+	case RoundUp:
+		away = true
+	case RoundFloor:
+		away = sign < 0
+	case RoundCeiling:
+		away = sign > 0
+	}
+	if away {
+		q.Add(q, big.NewInt(int64(sign)))
+	}
+	return q
+}
+
+// decimalPair returns numbers as decimals if at least one of them is a decimal.
+func decimalPair(a, b any) (Decimal, Decimal, bool) {
+	x, xok := a.(Decimal)
+	y, yok := b.(Decimal)
+	if xok == yok {
+		return x, y, xok
+	}
+	var err error
+	if xok {
+		f, ok := b.(float64)
+		if !ok {
+			return Decimal{}, Decimal{}, false
+		}
+		y, err = DecimalFromFloat(f)
+	} else {
+		f, ok := a.(float64)
+		if !ok {
+			return Decimal{}, Decimal{}, false
+		}
+		x, err = DecimalFromFloat(f)
+	}
+	return x, y, err == nil
+}
+
+// hasDecimal reports whether args or their arrays contain a decimal.
+func hasDecimal(args []any) bool {
+	for _, arg := range args {
+		switch val := arg.(type) {
+		case Decimal:
+			return true
+		case []any:
+			if hasDecimal(val) {
+				return true
+			}
+		case Table:
+			for _, row := range val {
+				if hasDecimal(row) {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// decimalContext rounds quotients of decimal aggregates like AVERAGE.
+var decimalContext = DecimalContext{Scale: 16, Rounding: RoundHalfEven}
+
+// flattenDecimals returns numbers of args and their arrays as decimals.
+func flattenDecimals(args []any) ([]Decimal, error) {
+	var res []Decimal
+	for _, arg := range args {
+		switch val := arg.(type) {
+		case Decimal:
+			res = append(res, val)
+		case []any:
+			numbers, err := flattenDecimals(val)
+			if err != nil {
+				return nil, err
+			}
+			res = append(res, numbers...)
+		case Table:
+			for _, row := range val {
+				numbers, err := flattenDecimals(row)
+				if err != nil {
+					return nil, err
+				}
+				res = append(res, numbers...)
+			}
+		default:
+			numbers, err := flatten(arg)
+			if err != nil {
+				return nil, err
+			}
+			for _, f := range numbers {
+				d, err := DecimalFromFloat(f)
+				if err != nil {
+					return nil, err
+				}
+				res = append(res, d)
+			}
+		}
+	}
+	return res, nil
+}
+
+// sumDecimals adds numbers of args exactly.
+func sumDecimals(args []any) (any, error) {
+	numbers, err := flattenDecimals(args)
+	if err != nil {
+		return nil, err
+	}
+	var res Decimal
+	for _, d := range numbers {
+		res = res.Add(d)
+	}
+	return res, nil
+}
+
+// meanDecimals returns average of numbers of args rounded with decimalContext,
+// but never to fewer digits than the sum has. Trailing zeros are removed.
+func meanDecimals(args []any) (any, error) {
+	numbers, err := flattenDecimals(args)
+	if err != nil {
+		return nil, err
+	}
+	var total Decimal
+	for _, d := range numbers {
+		total = total.Add(d)
+	}
+	ctx := decimalContext
+	if total.Scale() > ctx.Scale {
+		ctx.Scale = total.Scale()
+	}
+	res, err := total.Quo(NewDecimal(int64(len(numbers)), 0), ctx)
+	if err != nil {
+		return nil, errorf(ErrDiv0, "average of no numbers")
+	}
+	return res.trim(total.Scale()), nil
+}
+
+// extremeDecimal returns the least (sign -1) or the greatest (sign 1) number of args.
+func extremeDecimal(args []any, sign int) (any, error) {
+	numbers, err := flattenDecimals(args)
+	if err != nil {
+		return nil, err
+	}
+	var res Decimal
+	for i, d := range numbers {
+		if i == 0 || d.Cmp(res) == sign {
+			res = d
+		}
+	}
+	return res, nil
+}
+
+// modDecimal returns n - d×FLOOR(n/d) exactly.
+func modDecimal(n, d Decimal) (any, error) {
+	if d.Sign() == 0 {
+		return nil, errorf(ErrDiv0, "division by zero")
+	}
+	q, err := n.Quo(d, DecimalContext{Scale: 0, Rounding: RoundFloor})
+	if err != nil {
+		return nil, err
+	}
+	return n.Sub(d.Mul(q)), nil
+}
+
+// decimal returns arg as Decimal: numbers are converted with their shortest
+// representation, Blank is 0.
+func decimal(arg any) (Decimal, error) {
+	switch val := arg.(type) {
+	case Decimal:
+		return val, nil
+	case float64:
+		return DecimalFromFloat(val)
+	case blank:
+		return Decimal{}, nil
+	default:
+		return Decimal{}, fmt.Errorf("expected decimal, got %T", arg)
+	}
+}
@@ -0,0 +1,126 @@
+package functions
+
+import "testing"
+
+func TestParseDecimal(t *testing.T) {
+	cases := map[string]string{
+		"0":        "0",
+		"-12.340":  "-12.340",
+		"0.05":     "0.05",
+		".5":       "0.5",
+		"1.5e3":    "1500",
+		"1.5E-3":   "0.0015",
+		"+7":       "7",
+		"-0.00001": "-0.00001",
+	}
+	for input, expected := range cases {
+		d, err := ParseDecimal(input)
+		if err != nil {
+			t.Fatalf("%s: expected nil error, got %s", input, err)
+		}
+		if d.String() != expected {
+			t.Errorf("%s: expected %s, got %s", input, expected, d)
+		}
+	}
+	for _, input := range []string{"", "1.2.3", "abc", "1e", "1_000"} {
+		if _, err := ParseDecimal(input); err == nil {
+			t.Errorf("%s: expected error", input)
+		}
+	}
+}
+
+func TestDecimal_Arithmetic(t *testing.T) {
+	a, b := MustDecimal("0.1"), MustDecimal("0.2")
+	if sum := a.Add(b); sum.Cmp(MustDecimal("0.3")) != 0 {
+		t.Errorf("expected 0.3, got %s", sum)
+	}
+	if diff := a.Sub(b); diff.String() != "-0.1" {
+		t.Errorf("expected -0.1, got %s", diff)
+	}
+	if prod := MustDecimal("1.25").Mul(MustDecimal("-0.4")); prod.String() != "-0.500" {
+		t.Errorf("expected -0.500, got %s", prod)
+	}
+	if p, err := MustDecimal("1.1").Pow(2, DecimalContext{}); err != nil || p.String() != "1.21" {
+		t.Errorf("expected 1.21, got %s (%v)", p, err)
+	}
+	if p, err := NewDecimal(2, 0).Pow(-2, DecimalContext{Scale: 4}); err != nil || p.String() != "0.2500" {
+		t.Errorf("expected 0.2500, got %s (%v)", p, err)
+	}
+	if _, err := a.Quo(Decimal{}, DecimalContext{}); err == nil {
+		t.Errorf("expected zero division error")
+	}
+}
+
+func TestDecimal_Quo(t *testing.T) {
+	cases := []struct {
+		a, b     string
+		ctx      DecimalContext
+		expected string
+	}{
+		{"1", "3", DecimalContext{Scale: 4}, "0.3333"},
+		{"2", "3", DecimalContext{Scale: 4}, "0.6667"},
+		{"2", "3", DecimalContext{Scale: 4, Rounding: RoundDown}, "0.6666"},
+		{"-2", "3", DecimalContext{Scale: 2, Rounding: RoundFloor}, "-0.67"},
+		{"-2", "3", DecimalContext{Scale: 2, Rounding: RoundCeiling}, "-0.66"},
+		{"1", "8", DecimalContext{Scale: 2, Rounding: RoundHalfEven}, "0.12"},
+		{"3", "8", DecimalContext{Scale: 2, Rounding: RoundHalfEven}, "0.38"},
+		{"10.5", "0.05", DecimalContext{Scale: 0}, "210"},
+		{"1234", "1", DecimalContext{Scale: -2}, "1200"},
+	}
+	for _, c := range cases {
+		res, err := MustDecimal(c.a).Quo(MustDecimal(c.b), c.ctx)
+		if err != nil {
+			t.Fatalf("%s / %s: expected nil error, got %s", c.a, c.b, err)
+		}
+		if res.String() != c.expected {
+			t.Errorf("%s / %s: expected %s, got %s", c.a, c.b, c.expected, res)
+		}
+	}
+}
+
+func TestDecimal_Round(t *testing.T) {
+	cases := []struct {
+		value    string
+		scale    int32
+		mode     RoundingMode
+		expected string
+	}{
+		{"2.675", 2, RoundHalfUp, "2.68"},
+		{"-2.675", 2, RoundHalfUp, "-2.68"},
+		{"2.665", 2, RoundHalfEven, "2.66"},
+		{"2.671", 2, RoundUp, "2.68"},
+		{"-2.679", 2, RoundDown, "-2.67"},
+		{"-2.671", 2, RoundFloor, "-2.68"},
+		{"2.671", 2, RoundCeiling, "2.68"},
+		{"1250", -2, RoundHalfUp, "1300"},
+		{"1.5", 3, RoundHalfUp, "1.5"},
+	}
+	for _, c := range cases {
+		if res := MustDecimal(c.value).Round(c.scale, c.mode); res.String() != c.expected {
+			t.Errorf("%s rounded to %d with mode %d: expected %s, got %s", c.value, c.scale, c.mode, c.expected, res)
+		}
+	}
+}
+
+func TestDecimal_Functions(t *testing.T) {
+	res, err := Sum(MustDecimal("0.1"), []any{MustDecimal("0.2"), 0.3})
+	if err != nil {
+		t.Fatalf("expected nil error, got %s", err)
+	}
+	if res.(Decimal).String() != "0.6" {
+		t.Errorf("expected 0.6, got %v", res)
+	}
+	res, err = Round(MustDecimal("1.005"), 2.)
+	if err != nil {
+		t.Fatalf("expected nil error, got %s", err)
+	}
+	if res.(Decimal).String() != "1.01" {
+		t.Errorf("expected 1.01, got %v", res)
+	}
+	if res, err := Sqrt(MustDecimal("2.25")); err != nil || res != 1.5 {
+		t.Errorf("expected 1.5, got %v (%v)", res, err)
+	}
+	if TypeOf(MustDecimal("1")) != TypeNumber {
+		t.Errorf("expected decimal to be a number")
+	}
+}
@@ -0,0 +1,158 @@
+package functions
+
+import (
+	"math"
+	"strconv"
+	"strings"
+)
+
+// numeral is a positional numeral system of base conversion functions.
+// Negative numbers are represented as ten-digit two's complement.
+type numeral struct {
+	name  string
+	title string
+	radix int
+	bits  uint // number of bits of ten digits
+}
+
+var (
+	binNumeral = &numeral{name: "BIN", title: "binary", radix: 2, bits: 10}
+	octNumeral = &numeral{name: "OCT", title: "octal", radix: 8, bits: 30}
+	hexNumeral = &numeral{name: "HEX", title: "hexadecimal", radix: 16, bits: 40}
+	decNumeral = &numeral{name: "DEC", title: "decimal", radix: 10}
+)
+
+// parse returns integer value of number written in the numeral system.
+func (n *numeral) parse(arg any) (int64, error) {
+	if n == decNumeral {
+		val, err := number(arg)
+		if err != nil {
+			return 0, err
+		}
+		return int64(math.Trunc(val)), nil
+	}
+	s, err := text(arg)
+	if err != nil {
+		return 0, err
+	}
+	if len(s) > 10 {
+		return 0, errorf(ErrNum, "%s number '%s' has more than 10 digits", n.name, s)
+	}
+	val, err := strconv.ParseInt(s, n.radix, 64)
+	if err != nil {
+		return 0, errorf(ErrNum, "invalid %s number '%s'", n.name, s)
+	}
+	if val >= 1<<(n.bits-1) {
+		val -= 1 << n.bits
+	}
+	return val, nil
+}
+
+// format writes integer in the numeral system padded with zeros to places.
+// Zero places means no padding. Padding is ignored for negative numbers.
+func (n *numeral) format(val int64, places int) (string, error) {
+	if n == decNumeral {
+		return strconv.FormatInt(val, 10), nil
+	}
+	limit := int64(1) << (n.bits - 1)
+	if val < -limit || val >= limit {
+		return "", errorf(ErrNum, "%d is out of %s range [%d; %d]", val, n.name, -limit, limit-1)
+	}
+	if val < 0 {
+		return strings.ToUpper(strconv.FormatInt(val+1<<n.bits, n.radix)), nil
+	}
+	s := strings.ToUpper(strconv.FormatInt(val, n.radix))
+	if places == 0 {
+		return s, nil
+	}
+	if len(s) > places {
+		return "", errorf(ErrNum, "%s requires more than %d places", s, places)
+	}
+	return strings.Repeat("0", places-len(s)) + s, nil
+}
+
+// convert returns function converting numbers from one numeral system to another.
+func convert(from, to *numeral) func(args ...any) (any, error) {
+	return func(args ...any) (any, error) {
+		max := 2
+		if to == decNumeral {
+			max = 1
+		}
+		if err := checkArgs(args, 1, max); err != nil {
+			return nil, err
+		}
+		val, err := from.parse(args[0])
+		if err != nil {
+			return nil, err
+		}
+		if to == decNumeral {
+			return float64(val), nil
+		}
+		places, err := optionalNumber(args, 1, 0)
+		if err != nil {
+			return nil, err
+		}
+		if len(args) == 2 && (places < 1 || places > 10) {
+			return nil, errorf(ErrNum, "places should be in [1; 10], got %v", places)
+		}
+		return to.format(val, int(places))
+	}
+}
+
+// Base conversion functions. Functions converting to decimal return numbers,
+// others return text and accept optional number of places.
+var (
+	Bin2dec = convert(binNumeral, decNumeral)
+	Bin2oct = convert(binNumeral, octNumeral)
+	Bin2hex = convert(binNumeral, hexNumeral)
+	Oct2dec = convert(octNumeral, decNumeral)
+	Oct2bin = convert(octNumeral, binNumeral)
+	Oct2hex = convert(octNumeral, hexNumeral)
+	Hex2dec = convert(hexNumeral, decNumeral)
+	Hex2bin = convert(hexNumeral, binNumeral)
+	Hex2oct = convert(hexNumeral, octNumeral)
+	Dec2bin = convert(decNumeral, binNumeral)
+	Dec2oct = convert(decNumeral, octNumeral)
+	Dec2hex = convert(decNumeral, hexNumeral)
+)
+
+// engineeringDefinitions returns definitions of all base conversion functions.
+func engineeringDefinitions() []Definition {
+	conversions := []struct {
+		fn       func(args ...any) (any, error)
+		goName   string
+		from, to *numeral
+		example  string
+	}{
+		{Bin2dec, "Bin2dec", binNumeral, decNumeral, `BIN2DEC("1100100") = 100`},
+		{Bin2oct, "Bin2oct", binNumeral, octNumeral, `BIN2OCT("1001"; 3) = "011"`},
+		{Bin2hex, "Bin2hex", binNumeral, hexNumeral, `BIN2HEX("11111011") = "FB"`},
+		{Oct2dec, "Oct2dec", octNumeral, decNumeral, `OCT2DEC("54") = 44`},
+		{Oct2bin, "Oct2bin", octNumeral, binNumeral, `OCT2BIN("3"; 3) = "011"`},
+		{Oct2hex, "Oct2hex", octNumeral, hexNumeral, `OCT2HEX("100") = "40"`},
+		{Hex2dec, "Hex2dec", hexNumeral, decNumeral, `HEX2DEC("FF") = 255`},
+		{Hex2bin, "Hex2bin", hexNumeral, binNumeral, `HEX2BIN("F"; 8) = "00001111"`},
+		{Hex2oct, "Hex2oct", hexNumeral, octNumeral, `HEX2OCT("F") = "17"`},
+		{Dec2bin, "Dec2bin", decNumeral, binNumeral, `DEC2BIN(9; 4) = "1001"`},
+		{Dec2oct, "Dec2oct", decNumeral, octNumeral, `DEC2OCT(58) = "72"`},
+		{Dec2hex, "Dec2hex", decNumeral, hexNumeral, `DEC2HEX(-54) = "FFFFFFFFCA"`},
+	}
+	defs := make([]Definition, len(conversions))
+	for i, c := range conversions {
+		params := []string{"number", "places"}
+		if c.to == decNumeral {
+			params = params[:1]
+		}
+		defs[i] = Definition{
+			Name:        c.from.name + "2" + c.to.name,
+			Func:        c.fn,
+			Signature:   Signatures[c.goName],
+			Params:      params,
+			Description: "Converts a number from " + c.from.title + " to " + c.to.title + ".",
+			Examples:    []string{c.example},
+			Category:    CategoryEngineering,
+			Pure:        true,
+		}
+	}
+	return defs
+}
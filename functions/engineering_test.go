@@ -0,0 +1,65 @@
+package functions
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestEngineering(t *testing.T) {
+	cases := []struct {
+		name     string
+		fn       func(args ...any) (any, error)
+		args     []any
+		expected any
+	}{
+		{"Bin2dec", Bin2dec, []any{"1100100"}, 100.},
+		{"Bin2dec", Bin2dec, []any{1111111111.}, -1.},
+		{"Bin2oct", Bin2oct, []any{"1001", 3.}, "011"},
+		{"Bin2oct", Bin2oct, []any{"1100100"}, "144"},
+		{"Bin2hex", Bin2hex, []any{"11111011", 4.}, "00FB"},
+		{"Bin2hex", Bin2hex, []any{"1111111111"}, "FFFFFFFFFF"},
+		{"Oct2dec", Oct2dec, []any{"54"}, 44.},
+		{"Oct2dec", Oct2dec, []any{"7777777533"}, -165.},
+		{"Oct2bin", Oct2bin, []any{"3", 3.}, "011"},
+		{"Oct2bin", Oct2bin, []any{"7777777000"}, "1000000000"},
+		{"Oct2hex", Oct2hex, []any{"100", 4.}, "0040"},
+		{"Hex2dec", Hex2dec, []any{"a5"}, 165.},
+		{"Hex2dec", Hex2dec, []any{"FFFFFFFF5B"}, -165.},
+		{"Hex2bin", Hex2bin, []any{"F", 8.}, "00001111"},
+		{"Hex2oct", Hex2oct, []any{"FFFFFFFF00"}, "7777777400"},
+		{"Dec2bin", Dec2bin, []any{9., 4.}, "1001"},
+		{"Dec2bin", Dec2bin, []any{-100.}, "1110011100"},
+		{"Dec2oct", Dec2oct, []any{58., 3.}, "072"},
+		{"Dec2hex", Dec2hex, []any{100., 4.}, "0064"},
+		{"Dec2hex", Dec2hex, []any{-54.}, "FFFFFFFFCA"},
+	}
+	for _, c := range cases {
+		res, err := c.fn(c.args...)
+		if err != nil {
+			t.Fatalf("%s%v: expected nil error, got %s", c.name, c.args, err)
+		}
+		if res != c.expected {
+			t.Errorf("%s%v: expected %v, got %v", c.name, c.args, c.expected, res)
+		}
+	}
+}
+
+func TestEngineering_Errors(t *testing.T) {
+	cases := []struct {
+		name string
+		fn   func(args ...any) (any, error)
+		args []any
+	}{
+		{"Bin2dec", Bin2dec, []any{"102"}},
+		{"Bin2dec", Bin2dec, []any{"11111111111"}},
+		{"Dec2bin", Dec2bin, []any{512.}},
+		{"Dec2bin", Dec2bin, []any{9., 2.}},
+		{"Dec2hex", Dec2hex, []any{1., 11.}},
+		{"Hex2bin", Hex2bin, []any{"FFFFFFFDFF"}},
+	}
+	for _, c := range cases {
+		if _, err := c.fn(c.args...); !errors.Is(err, ErrNum) {
+			t.Errorf("%s%v: expected #NUM!, got %v", c.name, c.args, err)
+		}
+	}
+}
@@ -0,0 +1,40 @@
+package functions
+
+import "fmt"
+
+// ErrorCode is an Excel error value. Errors returned by functions wrap
+// one of the codes, so they can be tested with errors.Is.
+type ErrorCode string
+
+const (
+	ErrNA    ErrorCode = "#N/A"
+	ErrNum   ErrorCode = "#NUM!"
+	ErrDiv0  ErrorCode = "#DIV/0!"
+	ErrValue ErrorCode = "#VALUE!"
+	ErrRef   ErrorCode = "#REF!"
+)
+
+func (c ErrorCode) Error() string {
+	return string(c)
+}
+
+type codeError struct {
+	code ErrorCode
+	msg  string
+}
+
+func (e *codeError) Error() string {
+	return string(e.code) + " " + e.msg
+}
+
+func (e *codeError) Unwrap() error {
+	return e.code
+}
+
+// errorf returns error with the code and formatted description.
+func errorf(code ErrorCode, format string, args ...any) error {
+	return &codeError{
+		code: code,
+		msg:  fmt.Sprintf(format, args...),
+	}
+}
@@ -0,0 +1,402 @@
+package functions
+
+import (
+	"math"
+)
+
+const (
+	defaultTolerance     = 1e-7
+	defaultMaxIterations = 100
+	defaultGuess         = 0.1
+)
+
+// Solve finds root of f with Newton's method starting from guess.
+// f returns value of the function and its derivative at x.
+// Error wraps ErrNum if the method doesn't converge.
+func (s Solver) Solve(f func(x float64) (y, dy float64), guess float64) (float64, error) {
+	tolerance := s.Tolerance
+	if tolerance <= 0 {
+		tolerance = defaultTolerance
+	}
+	iterations := s.MaxIterations
+	if iterations <= 0 {
+		iterations = defaultMaxIterations
+	}
+	x := guess
+	for i := 0; i < iterations; i++ {
+		y, dy := f(x)
+		if dy == 0 || math.IsNaN(y) || math.IsInf(y, 0) {
+			break
+		}
+		next := x - y/dy
+		if math.Abs(next-x) < tolerance {
+			return next, nil
+		}
+		x = next
+	}
+	return 0, errorf(ErrNum, "solution not found in %d iterations", iterations)
+}
+
+// annuity returns arguments of an annuity function: three required numbers
+// followed by optional numbers with zero default.
+func annuity(args []any, optional int) ([]float64, error) {
+	if err := checkArgs(args, 3, 3+optional); err != nil {
+		return nil, err
+	}
+	values := make([]float64, 3+optional)
+	for i := range values {
+		val, err := optionalNumber(args, i, 0)
+		if err != nil {
+			return nil, err
+		}
+		values[i] = val
+	}
+	return values, nil
+}
+
+// annuityFactor returns ((1+rate)^nper - 1) / rate, the future value of unit payments.
+func annuityFactor(rate, nper float64) float64 {
+	if rate == 0 {
+		return nper
+	}
+	return (math.Pow(1+rate, nper) - 1) / rate
+}
+
+// Pmt returns payment of a loan with constant payments and constant interest rate.
+// Arguments are rate, number of periods, present value, future value (0 by default)
+// and type: 0 (default) for payments at the end of periods, 1 at the beginning.
+func Pmt(args ...any) (any, error) {
+	a, err := annuity(args, 2)
+	if err != nil {
+		return nil, err
+	}
+	rate, nper, pv, fv, typ := a[0], a[1], a[2], a[3], a[4]
+	if nper == 0 {
+		return nil, errorf(ErrNum, "number of periods is zero")
+	}
+	factor := (1 + rate*typ) * annuityFactor(rate, nper)
+	if factor == 0 {
+		return nil, errorf(ErrNum, "payment of rate %v and %v periods is undefined", rate, nper)
+	}
+	return -(pv*math.Pow(1+rate, nper) + fv) / factor, nil
+}
+
+// Pv returns present value of an investment. Arguments are rate, number of periods,
+// payment, future value (0 by default) and type.
+func Pv(args ...any) (any, error) {
+	a, err := annuity(args, 2)
+	if err != nil {
+		return nil, err
+	}
+	rate, nper, pmt, fv, typ := a[0], a[1], a[2], a[3], a[4]
+	return -(fv + pmt*(1+rate*typ)*annuityFactor(rate, nper)) / math.Pow(1+rate, nper), nil
+}
+
+// Fv returns future value of an investment. Arguments are rate, number of periods,
+// payment, present value (0 by default) and type.
+func Fv(args ...any) (any, error) {
+	a, err := annuity(args, 2)
+	if err != nil {
+		return nil, err
+	}
+	rate, nper, pmt, pv, typ := a[0], a[1], a[2], a[3], a[4]
+	return -(pv*math.Pow(1+rate, nper) + pmt*(1+rate*typ)*annuityFactor(rate, nper)), nil
+}
+
+// Nper returns number of periods of an investment. Arguments are rate, payment,
+// present value, future value (0 by default) and type.
+func Nper(args ...any) (any, error) {
+	a, err := annuity(args, 2)
+	if err != nil {
+		return nil, err
+	}
+	rate, pmt, pv, fv, typ := a[0], a[1], a[2], a[3], a[4]
+	if rate == 0 {
+		if pmt == 0 {
+			return nil, errorf(ErrNum, "payment is zero")
+		}
+		return -(pv + fv) / pmt, nil
+	}
+	k := pmt * (1 + rate*typ) / rate
+	res := math.Log((k-fv)/(k+pv)) / math.Log(1+rate)
+	if math.IsNaN(res) || math.IsInf(res, 0) {
+		return nil, errorf(ErrNum, "number of periods doesn't exist")
+	}
+	return res, nil
+}
+
+// Npv returns net present value of cash flows at the end of periods discounted by rate.
+func Npv(args ...any) (any, error) {
+	if err := checkArgs(args, 2, -1); err != nil {
+		return nil, err
+	}
+	rate, err := number(args[0])
+	if err != nil {
+		return nil, err
+	}
+	values, err := flatten(args[1:]...)
+	if err != nil {
+		return nil, err
+	}
+	var npv float64
+	for i, val := range values {
+		npv += val / math.Pow(1+rate, float64(i+1))
+	}
+	return npv, nil
+}
+
+// cashFlows checks that there are both positive and negative values.
+func cashFlows(values []float64) error {
+	var positive, negative bool
+	for _, val := range values {
+		positive = positive || val > 0
+		negative = negative || val < 0
+	}
+	if !positive || !negative {
+		return errorf(ErrNum, "expected at least one positive and one negative value")
+	}
+	return nil
+}
+
+// presentValue returns value of cash flows at given times discounted by rate
+// and its derivative by rate.
+func presentValue(rate float64, values, times []float64) (pv, dpv float64) {
+	for i, val := range values {
+		d := math.Pow(1+rate, -times[i])
+		pv += val * d
+		dpv -= times[i] * val * d / (1 + rate)
+	}
+	return pv, dpv
+}
+
+// finance implements financial functions which use iterative solvers or dates.
+type finance struct {
+	config Config
+	dates  *dates
+}
+
+// solve returns root of fn found with the configured solver.
+func (f *finance) solve(fn func(rate float64) (float64, float64), guess float64) (any, error) {
+	rate, err := f.config.Solver.Solve(fn, guess)
+	if err != nil {
+		return nil, err
+	}
+	return rate, nil
+}
+
+// Irr returns internal rate of return of cash flows at regular periods.
+// Optional guess is a starting point of the solver, 0.1 by default.
+func (f *finance) Irr(args ...any) (any, error) {
+	if err := checkArgs(args, 1, 2); err != nil {
+		return nil, err
+	}
+	values, err := flatten(args[0])
+	if err != nil {
+		return nil, err
+	}
+	guess, err := optionalNumber(args, 1, defaultGuess)
+	if err != nil {
+		return nil, err
+	}
+	if err := cashFlows(values); err != nil {
+		return nil, err
+	}
+	times := make([]float64, len(values))
+	for i := range times {
+		times[i] = float64(i)
+	}
+	return f.solve(func(rate float64) (float64, float64) {
+		return presentValue(rate, values, times)
+	}, guess)
+}
+
+// schedule returns cash flows and their times in years from the first date.
+func (f *finance) schedule(valuesArg, datesArg any) ([]float64, []float64, error) {
+	values, err := flatten(valuesArg)
+	if err != nil {
+		return nil, nil, err
+	}
+	dates, err := vector(datesArg)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(values) != len(dates) {
+		return nil, nil, errorf(ErrNum, "expected the same number of values and dates, got %d and %d", len(values), len(dates))
+	}
+	times := make([]float64, len(dates))
+	for i, el := range dates {
+		t, err := f.dates.date(el)
+		if err != nil {
+			return nil, nil, err
+		}
+		times[i] = Serial(t)
+	}
+	for i := len(times) - 1; i >= 0; i-- {
+		times[i] = math.Trunc(times[i]-times[0]) / 365
+		if times[i] < 0 {
+			return nil, nil, errorf(ErrNum, "date %v precedes the first date", dates[i])
+		}
+	}
+	return values, times, nil
+}
+
+// Xnpv returns net present value of cash flows at given dates.
+func (f *finance) Xnpv(args ...any) (any, error) {
+	if err := checkArgs(args, 3, 3); err != nil {
+		return nil, err
+	}
+	rate, err := number(args[0])
+	if err != nil {
+		return nil, err
+	}
+	values, times, err := f.schedule(args[1], args[2])
+	if err != nil {
+		return nil, err
+	}
+	pv, _ := presentValue(rate, values, times)
+	return pv, nil
+}
+
+// Xirr returns internal rate of return of cash flows at given dates.
+func (f *finance) Xirr(args ...any) (any, error) {
+	if err := checkArgs(args, 2, 3); err != nil {
+		return nil, err
+	}
+	values, times, err := f.schedule(args[0], args[1])
+	if err != nil {
+		return nil, err
+	}
+	guess, err := optionalNumber(args, 2, defaultGuess)
+	if err != nil {
+		return nil, err
+	}
+	if err := cashFlows(values); err != nil {
+		return nil, err
+	}
+	return f.solve(func(rate float64) (float64, float64) {
+		return presentValue(rate, values, times)
+	}, guess)
+}
+
+// Rate returns interest rate per period of an annuity. Arguments are number of
+// periods, payment, present value, future value (0 by default), type and guess.
+func (f *finance) Rate(args ...any) (any, error) {
+	a, err := annuity(args, 3)
+	if err != nil {
+		return nil, err
+	}
+	nper, pmt, pv, fv, typ := a[0], a[1], a[2], a[3], a[4]
+	guess := defaultGuess
+	if len(args) == 6 {
+		guess = a[5]
+	}
+	return f.solve(func(rate float64) (float64, float64) {
+		if rate == 0 {
+			// limits of the function and its derivative at zero rate
+			return pv + pmt*nper + fv, pv*nper + pmt*(typ*nper+nper*(nper-1)/2)
+		}
+		g := math.Pow(1+rate, nper)
+		dg := nper * math.Pow(1+rate, nper-1)
+		y := pv*g + pmt*(1+rate*typ)*(g-1)/rate + fv
+		dy := pv*dg + pmt*(typ*(g-1)/rate+(1+rate*typ)*(dg*rate-(g-1))/(rate*rate))
+		return y, dy
+	}, guess)
+}
+
+func (f *finance) definitions() []Definition {
+	return []Definition{
+		{
+			Name:        "IRR",
+			Func:        f.Irr,
+			Signature:   Signatures["Irr"],
+			Params:      []string{"values", "guess"},
+			Description: "Returns the internal rate of return of cash flows at regular periods.",
+			Examples:    []string{"IRR(CashFlows)", "IRR(CashFlows; -0,1)"},
+			Category:    CategoryFinancial,
+			Pure:        true,
+		},
+		{
+			Name:        "XNPV",
+			Func:        f.Xnpv,
+			Signature:   Signatures["Xnpv"],
+			Params:      []string{"rate", "values", "dates"},
+			Description: "Returns the net present value of cash flows at given dates.",
+			Examples:    []string{"XNPV(0,09; CashFlows; Dates)"},
+			Category:    CategoryFinancial,
+			Pure:        true,
+		},
+		{
+			Name:        "XIRR",
+			Func:        f.Xirr,
+			Signature:   Signatures["Xirr"],
+			Params:      []string{"values", "dates", "guess"},
+			Description: "Returns the internal rate of return of cash flows at given dates.",
+			Examples:    []string{"XIRR(CashFlows; Dates)"},
+			Category:    CategoryFinancial,
+			Pure:        true,
+		},
+		{
+			Name:        "RATE",
+			Func:        f.Rate,
+			Signature:   Signatures["Rate"],
+			Params:      []string{"nper", "pmt", "pv", "fv", "type", "guess"},
+			Description: "Returns the interest rate per period of an annuity.",
+			Examples:    []string{"RATE(48; -200; 8000) * 12"},
+			Category:    CategoryFinancial,
+			Pure:        true,
+		},
+	}
+}
+
+var financialDefinitions = []Definition{
+	{
+		Name:        "PMT",
+		Func:        Pmt,
+		Signature:   Signatures["Pmt"],
+		Params:      []string{"rate", "nper", "pv", "fv", "type"},
+		Description: "Returns the payment of a loan with constant payments and interest rate.",
+		Examples:    []string{"PMT(0,08 / 12; 10; 10000)"},
+		Category:    CategoryFinancial,
+		Pure:        true,
+	},
+	{
+		Name:        "PV",
+		Func:        Pv,
+		Signature:   Signatures["Pv"],
+		Params:      []string{"rate", "nper", "pmt", "fv", "type"},
+		Description: "Returns the present value of an investment.",
+		Examples:    []string{"PV(0,08 / 12; 240; 500)"},
+		Category:    CategoryFinancial,
+		Pure:        true,
+	},
+	{
+		Name:        "FV",
+		Func:        Fv,
+		Signature:   Signatures["Fv"],
+		Params:      []string{"rate", "nper", "pmt", "pv", "type"},
+		Description: "Returns the future value of an investment.",
+		Examples:    []string{"FV(0,06 / 12; 10; -200; -500; 1)"},
+		Category:    CategoryFinancial,
+		Pure:        true,
+	},
+	{
+		Name:        "NPER",
+		Func:        Nper,
+		Signature:   Signatures["Nper"],
+		Params:      []string{"rate", "pmt", "pv", "fv", "type"},
+		Description: "Returns the number of periods of an investment.",
+		Examples:    []string{"NPER(0,01; -100; -1000; 10000)"},
+		Category:    CategoryFinancial,
+		Pure:        true,
+	},
+	{
+		Name:        "NPV",
+		Func:        Npv,
+		Signature:   Signatures["Npv"],
+		Params:      []string{"rate", "value", "value"},
+		Description: "Returns the net present value of cash flows at the end of periods.",
+		Examples:    []string{"NPV(0,1; -10000; 3000; 4200; 6800)"},
+		Category:    CategoryFinancial,
+		Pure:        true,
+	},
+}
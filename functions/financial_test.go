@@ -0,0 +1,88 @@
+package functions
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestFinancial(t *testing.T) {
+	f := &finance{dates: &dates{}}
+	flows := []float64{-70000, 12000, 15000, 18000, 21000, 26000}
+	values := []float64{-10000, 2750, 4250, 3250, 2750}
+	days := []any{"2008-01-01", "2008-03-01", "2008-10-30", "2009-02-15", "2009-04-01"}
+	// expected values are results of Excel
+	cases := []struct {
+		name     string
+		fn       func(args ...any) (any, error)
+		args     []any
+		expected float64
+	}{
+		{"Pmt", Pmt, []any{0.08 / 12, 10., 10000.}, -1037.032089},
+		{"Pmt", Pmt, []any{0.06 / 12, 18. * 12, 0., 50000.}, -129.0811609},
+		{"Pmt", Pmt, []any{0., 10., 1000.}, -100},
+		{"Pv", Pv, []any{0.08 / 12, 12. * 20, 500.}, -59777.14585},
+		{"Fv", Fv, []any{0.06 / 12, 10., -200., -500., 1.}, 2581.403374},
+		{"Fv", Fv, []any{0.12 / 12, 12., -1000.}, 12682.50301},
+		{"Nper", Nper, []any{0.12 / 12, -100., -1000., 10000., 1.}, 59.67386567},
+		{"Nper", Nper, []any{0.12 / 12, -100., -1000., 10000.}, 60.08212285},
+		{"Nper", Nper, []any{0.12 / 12, -100., -1000.}, -9.578594039},
+		{"Npv", Npv, []any{0.1, -10000., 3000., 4200., 6800.}, 1188.443412},
+		{"Irr", f.Irr, []any{flows[:5]}, -0.021244848},
+		{"Irr", f.Irr, []any{flows}, 0.086630948},
+		{"Irr", f.Irr, []any{flows[:3], -0.1}, -0.443506941},
+		{"Xnpv", f.Xnpv, []any{0.09, values, days}, 2086.647602},
+		{"Xirr", f.Xirr, []any{values, days, 0.1}, 0.373362535},
+		{"Rate", f.Rate, []any{48., -200., 8000.}, 0.007701472},
+		{"Rate", f.Rate, []any{10., -100., 1000.}, 0},
+	}
+	for _, c := range cases {
+		res, err := c.fn(c.args...)
+		if err != nil {
+			t.Fatalf("%s%v: expected nil error, got %s", c.name, c.args, err)
+		}
+		if math.Abs(res.(float64)-c.expected) > 1e-6*math.Max(1, math.Abs(c.expected)) {
+			t.Errorf("%s%v: expected %.9f, got %.9f", c.name, c.args, c.expected, res)
+		}
+	}
+}
+
+func TestFinancial_NotConverged(t *testing.T) {
+	f := &finance{dates: &dates{}}
+	if _, err := f.Irr([]float64{1, 2, 3}); !errors.Is(err, ErrNum) {
+		t.Errorf("expected #NUM!, got %v", err)
+	}
+	f.config.Solver = Solver{MaxIterations: 1}
+	if res, err := f.Irr([]float64{-70000, 12000, 15000, 18000, 21000, 26000}); !errors.Is(err, ErrNum) || res != nil {
+		t.Errorf("expected #NUM!, got %v (%v)", res, err)
+	}
+	if res, err := f.Rate(10., 100., 1000.); !errors.Is(err, ErrNum) || res != nil {
+		t.Errorf("expected #NUM!, got %v (%v)", res, err)
+	}
+	f.config.Solver = Solver{Tolerance: 1e-3}
+	res, err := f.Rate(48., -200., 8000.)
+	if err != nil {
+		t.Fatalf("expected nil error, got %s", err)
+	}
+	if math.Abs(res.(float64)-0.007701472) > 1e-3 {
+		t.Errorf("expected 0.0077, got %v", res)
+	}
+}
+
+func TestFinancial_Errors(t *testing.T) {
+	cases := []struct {
+		name string
+		fn   func(args ...any) (any, error)
+		args []any
+	}{
+		{"Pmt", Pmt, []any{0.1, 0., 1000.}},
+		{"Pmt", Pmt, []any{-2., 2., 1000.}},
+		{"Pmt", Pmt, []any{-1., 2., 1000., 0., 1.}},
+	}
+	for _, c := range cases {
+		res, err := c.fn(c.args...)
+		if !errors.Is(err, ErrNum) || res != nil {
+			t.Errorf("%s%v: expected #NUM!, got %v (%v)", c.name, c.args, res, err)
+		}
+	}
+}
@@ -6,18 +6,19 @@ import (
 )
 
 func Sum(args ...any) (any, error) {
+	if hasMoney(args) {
+		return sumMoney(args)
+	}
+	if hasDecimal(args) {
+		return sumDecimals(args)
+	}
+	numbers, err := flatten(args...)
+	if err != nil {
+		return nil, err
+	}
 	var result float64
-	for _, el := range args {
-		switch val := el.(type) {
-		case float64:
-			result += val
-		case []float64:
-			for _, subarg := range val {
-				result += subarg
-			}
-		default:
-			return nil, fmt.Errorf("expected float64, got %T", el)
-		}
+	for _, val := range numbers {
+		result += val
 	}
 	return result, nil
 }
@@ -28,9 +29,9 @@ func Len(args ...any) (any, error) {
 
 func And(args ...any) (any, error) {
 	for _, el := range args {
-		cond, ok := el.(bool)
-		if !ok {
-			return nil, fmt.Errorf("expected bool, got %T", el)
+		cond, err := boolean(el)
+		if err != nil {
+			return nil, err
 		}
 		if !cond {
 			return false, nil
@@ -41,9 +42,9 @@ func And(args ...any) (any, error) {
 
 func Or(args ...any) (any, error) {
 	for _, el := range args {
-		cond, ok := el.(bool)
-		if !ok {
-			return nil, fmt.Errorf("expected bool, got %T", el)
+		cond, err := boolean(el)
+		if err != nil {
+			return nil, err
 		}
 		if cond {
 			return true, nil
@@ -53,12 +54,12 @@ func Or(args ...any) (any, error) {
 }
 
 func If(args ...any) (any, error) {
-	if length := len(args); length != 3 {
-		return nil, fmt.Errorf("expected 3 args, got %d", length)
+	if err := checkArgs(args, 3, 3); err != nil {
+		return nil, err
 	}
-	cond, ok := args[0].(bool)
-	if !ok {
-		return nil, fmt.Errorf("expected bool, got %T", args[0])
+	cond, err := boolean(args[0])
+	if err != nil {
+		return nil, err
 	}
 	if cond {
 		return args[1], nil
@@ -66,59 +67,46 @@ func If(args ...any) (any, error) {
 	return args[2], nil
 }
 
+// Round rounds a number half away from zero to a specified number of digits.
+// Negative digits round to the left of the decimal point.
 func Round(args ...any) (any, error) {
-	if length := len(args); length == 0 || length > 2 {
-		return nil, fmt.Errorf("expected 1 or 2 args, got %d", length)
-	}
-	val, ok := args[0].(float64)
-	if !ok {
-		return nil, fmt.Errorf("expected float64, got %T", args[0])
-	}
-	var precision float64
-	if len(args) == 2 {
-		var ok bool
-		precision, ok = args[1].(float64)
-		if !ok {
-			return nil, fmt.Errorf("expected float64, got %T", args[1])
-		}
-	}
-	if precision < 0 {
-		return nil, fmt.Errorf("round precision should be >= 0, got %f", precision)
-	}
-	ratio := math.Pow(10, precision)
-	return math.Round(val*ratio) / ratio, nil
+	return roundFunc(math.Round, RoundHalfUp)(args...)
 }
 
 func Mean(args ...any) (any, error) {
+	if hasDecimal(args) {
+		return meanDecimals(args)
+	}
+	numbers, err := flatten(args...)
+	if err != nil {
+		return nil, err
+	}
+	if len(numbers) == 0 {
+		return nil, errorf(ErrDiv0, "average of no numbers")
+	}
 	var total float64
-	var count float64
-	for _, arg := range args {
-		switch val := arg.(type) {
-		case float64:
-			total += val
-			count++
-		case []float64:
-			for _, sub := range val {
-				total += sub
-				count++
-			}
-		default:
-			return nil, fmt.Errorf("expected float64, got %T", arg)
-		}
+	for _, val := range numbers {
+		total += val
 	}
-	return total / count, nil
+	return total / float64(len(numbers)), nil
 }
 
 func Min(args ...any) (any, error) {
-	if len(args) == 0 {
-		return nil, fmt.Errorf("expected 1 or more args, got 0")
+	if err := checkArgs(args, 1, -1); err != nil {
+		return nil, err
+	}
+	if hasDecimal(args) {
+		return extremeDecimal(args, -1)
+	}
+	numbers, err := flatten(args...)
+	if err != nil {
+		return nil, err
+	}
+	if len(numbers) == 0 {
+		return 0., nil
 	}
 	min := math.Inf(1)
-	for _, arg := range args {
-		val, ok := arg.(float64)
-		if !ok {
-			return nil, fmt.Errorf("expected float64, got %T", arg)
-		}
+	for _, val := range numbers {
 		if val < min {
 			min = val
 		}
@@ -127,15 +115,21 @@ func Min(args ...any) (any, error) {
 }
 
 func Max(args ...any) (any, error) {
-	if len(args) == 0 {
-		return nil, fmt.Errorf("expected 1 or more args, got 0")
+	if err := checkArgs(args, 1, -1); err != nil {
+		return nil, err
+	}
+	if hasDecimal(args) {
+		return extremeDecimal(args, 1)
+	}
+	numbers, err := flatten(args...)
+	if err != nil {
+		return nil, err
+	}
+	if len(numbers) == 0 {
+		return 0., nil
 	}
 	max := math.Inf(-1)
-	for _, arg := range args {
-		val, ok := arg.(float64)
-		if !ok {
-			return nil, fmt.Errorf("expected float64, got %T", arg)
-		}
+	for _, val := range numbers {
 		if val > max {
 			max = val
 		}
@@ -151,14 +145,14 @@ func Ifs(args ...any) (any, error) {
 		return nil, fmt.Errorf("expected even number of arguments, got %d", len(args))
 	}
 	for i := 0; i < len(args); i += 2 {
-		cond, ok := args[i].(bool)
-		if !ok {
-			return nil, fmt.Errorf("expected bool condition, got %T", args[i])
+		cond, err := boolean(args[i])
+		if err != nil {
+			return nil, err
 		}
 		if cond {
 			return args[i+1], nil
 		}
 	}
 
-	return nil, fmt.Errorf("none of the conditions turned out to be true")
+	return nil, errorf(ErrNA, "none of the conditions turned out to be true")
 }
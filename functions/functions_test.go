@@ -1,6 +1,9 @@
 package functions
 
-import "testing"
+import (
+	"errors"
+	"testing"
+)
 
 func TestLen(t *testing.T) {
 	cases := map[float64][]any{
@@ -44,3 +47,15 @@ func TestSum(t *testing.T) {
 		}
 	}
 }
+
+func TestMean(t *testing.T) {
+	res, err := Mean(1., []any{2., Blank}, 6.)
+	if err != nil || res != 3. {
+		t.Errorf("expected 3, got %v (%v)", res, err)
+	}
+	for _, args := range [][]any{{}, {Blank}, {[]any{}}} {
+		if res, err := Mean(args...); !errors.Is(err, ErrDiv0) || res != nil {
+			t.Errorf("Mean%v: expected #DIV/0!, got %v (%v)", args, res, err)
+		}
+	}
+}
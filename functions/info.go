@@ -0,0 +1,257 @@
+package functions
+
+import (
+	"errors"
+	"math"
+)
+
+type blank struct{}
+
+func (blank) String() string {
+	return ""
+}
+
+// Blank is a value of an empty variable, e.g. a missing optional input.
+// ISBLANK is TRUE only for Blank, while arithmetic and comparisons treat it
+// as 0, an empty string or FALSE, and aggregate functions skip it.
+var Blank any = blank{}
+
+// isFunc returns information function which tests one value. Errors of
+// the argument are passed as values.
+func isFunc(fn func(arg any) bool) func(args ...any) (any, error) {
+	return func(args ...any) (any, error) {
+		if err := checkArgs(args, 1, 1); err != nil {
+			return nil, err
+		}
+		return fn(args[0]), nil
+	}
+}
+
+func isError(arg any) bool {
+	_, ok := arg.(error)
+	return ok
+}
+
+func isNA(arg any) bool {
+	err, ok := arg.(error)
+	return ok && errors.Is(err, ErrNA)
+}
+
+var (
+	// Isblank reports whether value is Blank.
+	Isblank = isFunc(func(arg any) bool {
+		return arg == Blank
+	})
+	// Isnumber reports whether value is a number.
+	Isnumber = isFunc(func(arg any) bool {
+		switch arg.(type) {
+		case float64, Decimal:
+			return true
+		}
+		return false
+	})
+	// Istext reports whether value is a string.
+	Istext = isFunc(func(arg any) bool {
+		_, ok := arg.(string)
+		return ok
+	})
+	// Isnontext reports whether value is not a string.
+	Isnontext = isFunc(func(arg any) bool {
+		_, ok := arg.(string)
+		return !ok
+	})
+	// Islogical reports whether value is a boolean.
+	Islogical = isFunc(func(arg any) bool {
+		_, ok := arg.(bool)
+		return ok
+	})
+	// Iserror reports whether value is an error.
+	Iserror = isFunc(isError)
+	// Iserr reports whether value is an error other than #N/A.
+	Iserr = isFunc(func(arg any) bool {
+		return isError(arg) && !isNA(arg)
+	})
+	// Isna reports whether value is #N/A error.
+	Isna = isFunc(isNA)
+)
+
+// parity returns function which reports whether integer part of a number has the parity.
+func parity(odd bool) func(args ...any) (any, error) {
+	return func(args ...any) (any, error) {
+		if err := checkArgs(args, 1, 1); err != nil {
+			return nil, err
+		}
+		val, err := number(args[0])
+		if err != nil {
+			return nil, err
+		}
+		return (math.Mod(math.Trunc(val), 2) != 0) == odd, nil
+	}
+}
+
+var (
+	// Iseven reports whether integer part of a number is even.
+	Iseven = parity(false)
+	// Isodd reports whether integer part of a number is odd.
+	Isodd = parity(true)
+)
+
+// TypeCode returns Excel type code of value: 1 for numbers and Blank, 2 for text,
+// 4 for booleans, 16 for errors and 64 for arrays.
+func TypeCode(args ...any) (any, error) {
+	if err := checkArgs(args, 1, 1); err != nil {
+		return nil, err
+	}
+	switch args[0].(type) {
+	case string:
+		return 2., nil
+	case bool:
+		return 4., nil
+	case error:
+		return 16., nil
+	case []any, []float64, []string, Table:
+		return 64., nil
+	default:
+		return 1., nil
+	}
+}
+
+// Na returns #N/A error.
+func Na(args ...any) (any, error) {
+	if err := checkArgs(args, 0, 0); err != nil {
+		return nil, err
+	}
+	return nil, errorf(ErrNA, "value is not available")
+}
+
+var infoDefinitions = []Definition{
+	{
+		Name:        "ISBLANK",
+		Func:        Isblank,
+		Signature:   Signatures["Isblank"],
+		Params:      []string{"value"},
+		Description: "Returns TRUE if the value is blank.",
+		Examples:    []string{"ISBLANK(Discount)"},
+		Category:    CategoryInfo,
+		Pure:        true,
+		CatchErrors: true,
+	},
+	{
+		Name:        "ISNUMBER",
+		Func:        Isnumber,
+		Signature:   Signatures["Isnumber"],
+		Params:      []string{"value"},
+		Description: "Returns TRUE if the value is a number.",
+		Examples:    []string{"ISNUMBER(1) = TRUE"},
+		Category:    CategoryInfo,
+		Pure:        true,
+		CatchErrors: true,
+	},
+	{
+		Name:        "ISTEXT",
+		Func:        Istext,
+		Signature:   Signatures["Istext"],
+		Params:      []string{"value"},
+		Description: "Returns TRUE if the value is text.",
+		Examples:    []string{`ISTEXT("a") = TRUE`},
+		Category:    CategoryInfo,
+		Pure:        true,
+		CatchErrors: true,
+	},
+	{
+		Name:        "ISNONTEXT",
+		Func:        Isnontext,
+		Signature:   Signatures["Isnontext"],
+		Params:      []string{"value"},
+		Description: "Returns TRUE if the value is not text.",
+		Examples:    []string{"ISNONTEXT(1) = TRUE"},
+		Category:    CategoryInfo,
+		Pure:        true,
+		CatchErrors: true,
+	},
+	{
+		Name:        "ISLOGICAL",
+		Func:        Islogical,
+		Signature:   Signatures["Islogical"],
+		Params:      []string{"value"},
+		Description: "Returns TRUE if the value is a logical value.",
+		Examples:    []string{"ISLOGICAL(1 = 1) = TRUE"},
+		Category:    CategoryInfo,
+		Pure:        true,
+		CatchErrors: true,
+	},
+	{
+		Name:        "ISERROR",
+		Func:        Iserror,
+		Signature:   Signatures["Iserror"],
+		Params:      []string{"value"},
+		Description: "Returns TRUE if the value is an error.",
+		Examples:    []string{"ISERROR(1 / 0) = TRUE"},
+		Category:    CategoryInfo,
+		Pure:        true,
+		CatchErrors: true,
+	},
+	{
+		Name:        "ISERR",
+		Func:        Iserr,
+		Signature:   Signatures["Iserr"],
+		Params:      []string{"value"},
+		Description: "Returns TRUE if the value is an error other than #N/A.",
+		Examples:    []string{"ISERR(NA()) = FALSE"},
+		Category:    CategoryInfo,
+		Pure:        true,
+		CatchErrors: true,
+	},
+	{
+		Name:        "ISNA",
+		Func:        Isna,
+		Signature:   Signatures["Isna"],
+		Params:      []string{"value"},
+		Description: "Returns TRUE if the value is #N/A error.",
+		Examples:    []string{"ISNA(NA()) = TRUE"},
+		Category:    CategoryInfo,
+		Pure:        true,
+		CatchErrors: true,
+	},
+	{
+		Name:        "ISEVEN",
+		Func:        Iseven,
+		Signature:   Signatures["Iseven"],
+		Params:      []string{"number"},
+		Description: "Returns TRUE if the integer part of a number is even.",
+		Examples:    []string{"ISEVEN(2,5) = TRUE"},
+		Category:    CategoryInfo,
+		Pure:        true,
+	},
+	{
+		Name:        "ISODD",
+		Func:        Isodd,
+		Signature:   Signatures["Isodd"],
+		Params:      []string{"number"},
+		Description: "Returns TRUE if the integer part of a number is odd.",
+		Examples:    []string{"ISODD(3) = TRUE"},
+		Category:    CategoryInfo,
+		Pure:        true,
+	},
+	{
+		Name:        "TYPE",
+		Func:        TypeCode,
+		Signature:   Signatures["TypeCode"],
+		Params:      []string{"value"},
+		Description: "Returns the type of the value: 1 for numbers, 2 for text, 4 for logical values, 16 for errors and 64 for arrays.",
+		Examples:    []string{`TYPE("a") = 2`},
+		Category:    CategoryInfo,
+		Pure:        true,
+		CatchErrors: true,
+	},
+	{
+		Name:        "NA",
+		Func:        Na,
+		Signature:   Signatures["Na"],
+		Params:      []string{},
+		Description: "Returns #N/A error.",
+		Examples:    []string{"IFNA(NA(); 0) = 0"},
+		Category:    CategoryInfo,
+		Pure:        true,
+	},
+}
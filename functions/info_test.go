@@ -0,0 +1,71 @@
+package functions
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestInfo(t *testing.T) {
+	na := errorf(ErrNA, "not found")
+	div0 := fmt.Errorf("zero division error")
+	cases := []struct {
+		name     string
+		fn       func(args ...any) (any, error)
+		args     []any
+		expected any
+	}{
+		{"Isblank", Isblank, []any{Blank}, true},
+		{"Isblank", Isblank, []any{0.}, false},
+		{"Isblank", Isblank, []any{""}, false},
+		{"Isnumber", Isnumber, []any{1.}, true},
+		{"Isnumber", Isnumber, []any{MustDecimal("1.5")}, true},
+		{"Isnumber", Isnumber, []any{"1"}, false},
+		{"Isnumber", Isnumber, []any{div0}, false},
+		{"Istext", Istext, []any{"a"}, true},
+		{"Isnontext", Isnontext, []any{Blank}, true},
+		{"Islogical", Islogical, []any{false}, true},
+		{"Iserror", Iserror, []any{na}, true},
+		{"Iserror", Iserror, []any{1.}, false},
+		{"Iserr", Iserr, []any{na}, false},
+		{"Iserr", Iserr, []any{div0}, true},
+		{"Isna", Isna, []any{na}, true},
+		{"Isna", Isna, []any{div0}, false},
+		{"Iseven", Iseven, []any{-2.5}, true},
+		{"Isodd", Isodd, []any{3.}, true},
+		{"Isodd", Isodd, []any{Blank}, false},
+		{"TypeCode", TypeCode, []any{1.}, 1.},
+		{"TypeCode", TypeCode, []any{Blank}, 1.},
+		{"TypeCode", TypeCode, []any{"a"}, 2.},
+		{"TypeCode", TypeCode, []any{true}, 4.},
+		{"TypeCode", TypeCode, []any{na}, 16.},
+		{"TypeCode", TypeCode, []any{[]float64{1}}, 64.},
+	}
+	for _, c := range cases {
+		res, err := c.fn(c.args...)
+		if err != nil {
+			t.Fatalf("%s%v: expected nil error, got %s", c.name, c.args, err)
+		}
+		if res != c.expected {
+			t.Errorf("%s%v: expected %v, got %v", c.name, c.args, c.expected, res)
+		}
+	}
+}
+
+func TestBlank(t *testing.T) {
+	res, err := Sum(1., Blank, []any{2., Blank})
+	if err != nil {
+		t.Fatalf("expected nil error, got %s", err)
+	}
+	if res != 3. {
+		t.Errorf("expected 3, got %v", res)
+	}
+	if res, _ := Mean(1., Blank, 3.); res != 2. {
+		t.Errorf("expected blank to be skipped by mean, got %v", res)
+	}
+	if res, _ := Upper(Blank); res != "" {
+		t.Errorf("expected empty string, got %v", res)
+	}
+	if res, _ := Countif([]any{Blank, 0., ""}, ""); res != 2. {
+		t.Errorf("expected 2 blanks, got %v", res)
+	}
+}
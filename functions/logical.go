@@ -0,0 +1,165 @@
+package functions
+
+import (
+	"errors"
+	"fmt"
+)
+
+// valueOf returns arg or the error if arg is an error value.
+func valueOf(arg any) (any, error) {
+	if err, ok := arg.(error); ok {
+		return nil, err
+	}
+	return arg, nil
+}
+
+// Not reverses a logical value.
+func Not(args ...any) (any, error) {
+	if err := checkArgs(args, 1, 1); err != nil {
+		return nil, err
+	}
+	val, err := boolean(args[0])
+	if err != nil {
+		return nil, err
+	}
+	return !val, nil
+}
+
+// appendBools collects booleans from scalars and []any arrays.
+func appendBools(bools []bool, arg any) ([]bool, error) {
+	switch val := arg.(type) {
+	case bool:
+		return append(bools, val), nil
+	case []bool:
+		return append(bools, val...), nil
+	case []any:
+		for _, el := range val {
+			var err error
+			bools, err = appendBools(bools, el)
+			if err != nil {
+				return nil, err
+			}
+		}
+		return bools, nil
+	default:
+		return nil, fmt.Errorf("expected bool, got %T", arg)
+	}
+}
+
+// Xor returns TRUE if an odd number of arguments are TRUE.
+func Xor(args ...any) (any, error) {
+	if err := checkArgs(args, 1, -1); err != nil {
+		return nil, err
+	}
+	var bools []bool
+	for _, arg := range args {
+		var err error
+		bools, err = appendBools(bools, arg)
+		if err != nil {
+			return nil, err
+		}
+	}
+	res := false
+	for _, val := range bools {
+		res = res != val
+	}
+	return res, nil
+}
+
+// Switch compares expression with values and returns the result corresponding
+// to the first equal value. Arguments are expression, pairs of value and result
+// and optional default returned if there is no equal value.
+func Switch(args ...any) (any, error) {
+	if err := checkArgs(args, 3, -1); err != nil {
+		return nil, err
+	}
+	for i := 1; i+1 < len(args); i += 2 {
+		if cmp, ok := compareCells(args[0], args[i]); ok && cmp == 0 {
+			return args[i+1], nil
+		}
+	}
+	if len(args)%2 == 0 {
+		return args[len(args)-1], nil
+	}
+	return nil, errorf(ErrNA, "no value matches %v", args[0])
+}
+
+// Iferror returns the value or the fallback if the value is an error.
+func Iferror(args ...any) (any, error) {
+	if err := checkArgs(args, 2, 2); err != nil {
+		return nil, err
+	}
+	if isError(args[0]) {
+		return valueOf(args[1])
+	}
+	return args[0], nil
+}
+
+// Ifna returns the value or the fallback if the value is #N/A error.
+func Ifna(args ...any) (any, error) {
+	if err := checkArgs(args, 2, 2); err != nil {
+		return nil, err
+	}
+	if err, ok := args[0].(error); ok {
+		if !errors.Is(err, ErrNA) {
+			return nil, err
+		}
+		return valueOf(args[1])
+	}
+	return args[0], nil
+}
+
+var logicalDefinitions = []Definition{
+	{
+		Name:        "NOT",
+		Func:        Not,
+		Signature:   Signatures["Not"],
+		Params:      []string{"logical"},
+		Description: "Reverses a logical value.",
+		Examples:    []string{"NOT(1 > 2) = TRUE"},
+		Category:    CategoryLogical,
+		Pure:        true,
+	},
+	{
+		Name:        "XOR",
+		Func:        Xor,
+		Signature:   Signatures["Xor"],
+		Params:      []string{"logical", "logical"},
+		Description: "Returns TRUE if an odd number of arguments are TRUE.",
+		Examples:    []string{"XOR(1 < 2; 2 < 3) = FALSE"},
+		Category:    CategoryLogical,
+		Pure:        true,
+	},
+	{
+		Name:        "SWITCH",
+		Func:        Switch,
+		Signature:   Signatures["Switch"],
+		Params:      []string{"expression", "value", "result", "value_or_default"},
+		Description: "Returns the result corresponding to the first value equal to the expression, or the default.",
+		Examples:    []string{`SWITCH(Tier; "gold"; 0,2; "silver"; 0,1; 0)`},
+		Category:    CategoryLogical,
+		Pure:        true,
+	},
+	{
+		Name:        "IFERROR",
+		Func:        Iferror,
+		Signature:   Signatures["Iferror"],
+		Params:      []string{"value", "value_if_error"},
+		Description: "Returns the value or the fallback if the value is an error.",
+		Examples:    []string{"IFERROR(1 / 0; 0) = 0"},
+		Category:    CategoryLogical,
+		Pure:        true,
+		CatchErrors: true,
+	},
+	{
+		Name:        "IFNA",
+		Func:        Ifna,
+		Signature:   Signatures["Ifna"],
+		Params:      []string{"value", "value_if_na"},
+		Description: "Returns the value or the fallback if the value is #N/A error.",
+		Examples:    []string{`IFNA(VLOOKUP(Code; Rates; 2; 1=0); 0)`},
+		Category:    CategoryLogical,
+		Pure:        true,
+		CatchErrors: true,
+	},
+}
@@ -0,0 +1,59 @@
+package functions
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestLogical(t *testing.T) {
+	na := errorf(ErrNA, "not found")
+	div0 := fmt.Errorf("zero division error")
+	cases := []struct {
+		name     string
+		fn       func(args ...any) (any, error)
+		args     []any
+		expected any
+	}{
+		{"Not", Not, []any{false}, true},
+		{"Not", Not, []any{Blank}, true},
+		{"And", And, []any{true, Blank}, false},
+		{"Or", Or, []any{Blank, true}, true},
+		{"If", If, []any{Blank, 1., 2.}, 2.},
+		{"Ifs", Ifs, []any{Blank, 1., true, 2.}, 2.},
+		{"Xor", Xor, []any{true, false}, true},
+		{"Xor", Xor, []any{true, []any{true, true}}, true},
+		{"Xor", Xor, []any{true, true}, false},
+		{"Switch", Switch, []any{"Gold", "silver", 0.1, "gold", 0.2}, 0.2},
+		{"Switch", Switch, []any{3., 1., "a", 2., "b", "other"}, "other"},
+		{"Iferror", Iferror, []any{div0, 0.}, 0.},
+		{"Iferror", Iferror, []any{1., 0.}, 1.},
+		{"Ifna", Ifna, []any{na, "none"}, "none"},
+		{"Ifna", Ifna, []any{"a", "none"}, "a"},
+	}
+	for _, c := range cases {
+		res, err := c.fn(c.args...)
+		if err != nil {
+			t.Fatalf("%s%v: expected nil error, got %s", c.name, c.args, err)
+		}
+		if res != c.expected {
+			t.Errorf("%s%v: expected %v, got %v", c.name, c.args, c.expected, res)
+		}
+	}
+}
+
+func TestLogical_Errors(t *testing.T) {
+	if _, err := Switch(3., 1., "a"); !errors.Is(err, ErrNA) {
+		t.Errorf("expected #N/A, got %v", err)
+	}
+	if _, err := Ifs(false, 1., false, 2.); !errors.Is(err, ErrNA) {
+		t.Errorf("expected #N/A, got %v", err)
+	}
+	div0 := fmt.Errorf("zero division error")
+	if _, err := Ifna(div0, 0.); err != div0 {
+		t.Errorf("expected original error, got %v", err)
+	}
+	if _, err := Iferror(div0, div0); err != div0 {
+		t.Errorf("expected error of fallback, got %v", err)
+	}
+}
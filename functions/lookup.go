@@ -0,0 +1,554 @@
+package functions
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// A Table is a two-dimensional array of values stored by rows.
+type Table [][]any
+
+// NewTable converts rows of Go values into a Table. Supported types are
+// Table, [][]any, [][]float64 and [][]string.
+func NewTable(rows any) (Table, error) {
+	switch val := rows.(type) {
+	case Table:
+		return val, nil
+	case [][]any:
+		return val, nil
+	case [][]float64:
+		return convertRows(val), nil
+	case [][]string:
+		return convertRows(val), nil
+	default:
+		return nil, fmt.Errorf("expected table, got %T", rows)
+	}
+}
+
+func convertRows[T any](rows [][]T) Table {
+	t := make(Table, len(rows))
+	for i, row := range rows {
+		t[i] = make([]any, len(row))
+		for j, el := range row {
+			t[i][j] = el
+		}
+	}
+	return t
+}
+
+// Rows returns number of rows.
+func (t Table) Rows() int {
+	return len(t)
+}
+
+// Cols returns number of columns of the widest row.
+func (t Table) Cols() int {
+	var cols int
+	for _, row := range t {
+		if len(row) > cols {
+			cols = len(row)
+		}
+	}
+	return cols
+}
+
+// Cell returns value at 0-based row and column, Blank for missing cells.
+func (t Table) Cell(row, col int) any {
+	if row < 0 || row >= len(t) || col < 0 || col >= len(t[row]) {
+		return Blank
+	}
+	return t[row][col]
+}
+
+// Row returns copy of 0-based row padded to Cols with Blank.
+func (t Table) Row(row int) []any {
+	values := make([]any, t.Cols())
+	for i := copy(values, t[row]); i < len(values); i++ {
+		values[i] = Blank
+	}
+	return values
+}
+
+// Col returns 0-based column.
+func (t Table) Col(col int) []any {
+	values := make([]any, len(t))
+	for i := range t {
+		values[i] = t.Cell(i, col)
+	}
+	return values
+}
+
+// compareCells compares lookup values: numbers by value, strings ignoring case,
+// false is less than true. ok is false for values of different types.
+func compareCells(a, b any) (cmp int, ok bool) {
+	if x, y, ok := decimalPair(a, b); ok {
+		return x.Cmp(y), true
+	}
+	switch x := a.(type) {
+	case float64:
+		y, ok := b.(float64)
+		if !ok {
+			return 0, false
+		}
+		switch {
+		case x < y:
+			return -1, true
+		case x > y:
+			return 1, true
+		}
+		return 0, true
+	case string:
+		y, ok := b.(string)
+		if !ok {
+			return 0, false
+		}
+		return strings.Compare(strings.ToLower(x), strings.ToLower(y)), true
+	case bool:
+		y, ok := b.(bool)
+		if !ok {
+			return 0, false
+		}
+		switch {
+		case x == y:
+			return 0, true
+		case !x:
+			return -1, true
+		}
+		return 1, true
+	default:
+		return 0, false
+	}
+}
+
+// Match modes of lookup functions.
+const (
+	matchExact       = 0
+	matchNextSmaller = -1
+	matchNextLarger  = 1
+	matchWildcard    = 2
+)
+
+// matcher finds position of value in array by match mode.
+type matcher struct {
+	value any
+	mode  int
+	re    interface{ MatchString(string) bool }
+}
+
+func newMatcher(value any, mode int) (*matcher, error) {
+	m := &matcher{value: value, mode: mode}
+	if s, ok := value.(string); ok && mode == matchWildcard {
+		re, err := wildcardRegexp(s, true)
+		if err != nil {
+			return nil, err
+		}
+		m.re = re
+	}
+	return m, nil
+}
+
+func (m *matcher) exact(el any) bool {
+	if m.re != nil {
+		s, ok := el.(string)
+		return ok && m.re.MatchString(s)
+	}
+	cmp, ok := compareCells(el, m.value)
+	return ok && cmp == 0
+}
+
+// find scans values in order and returns index of match or -1. For next smaller
+// and next larger modes the closest value is returned if there is no exact match.
+func (m *matcher) find(values []any, order []int) int {
+	best := -1
+	for _, i := range order {
+		el := values[i]
+		if m.exact(el) {
+			return i
+		}
+		cmp, ok := compareCells(el, m.value)
+		if !ok {
+			continue
+		}
+		if best >= 0 {
+			// the closest value is the largest smaller or the smallest larger one
+			if c, _ := compareCells(el, values[best]); c*m.mode >= 0 {
+				continue
+			}
+		}
+		if m.mode == matchNextSmaller && cmp < 0 || m.mode == matchNextLarger && cmp > 0 {
+			best = i
+		}
+	}
+	return best
+}
+
+// sorted returns index of the last value not greater than lookup value in ascending
+// values (descending is false) or the last value not less than lookup value in
+// descending values. Values are scanned until the order breaks like in Excel.
+func (m *matcher) sorted(values []any, descending bool) int {
+	best := -1
+	for i, el := range values {
+		cmp, ok := compareCells(el, m.value)
+		if !ok {
+			continue
+		}
+		if descending {
+			cmp = -cmp
+		}
+		if cmp > 0 {
+			break
+		}
+		best = i
+	}
+	return best
+}
+
+// binary returns index of lookup value in sorted values using binary search.
+func (m *matcher) binary(values []any, descending bool) int {
+	lo, hi := 0, len(values)
+	for lo < hi {
+		mid := (lo + hi) / 2
+		cmp, _ := compareCells(values[mid], m.value)
+		if descending {
+			cmp = -cmp
+		}
+		if cmp < 0 {
+			lo = mid + 1
+		} else {
+			hi = mid
+		}
+	}
+	// lo is the first value not less than lookup value in search order
+	if lo < len(values) && m.exact(values[lo]) {
+		return lo
+	}
+	smaller, larger := lo-1, lo
+	if descending {
+		smaller, larger = lo, lo-1
+	}
+	switch {
+	case m.mode == matchNextSmaller && smaller >= 0 && smaller < len(values):
+		return smaller
+	case m.mode == matchNextLarger && larger >= 0 && larger < len(values):
+		return larger
+	}
+	return -1
+}
+
+func ascending(n int) []int {
+	order := make([]int, n)
+	for i := range order {
+		order[i] = i
+	}
+	return order
+}
+
+func descending(n int) []int {
+	order := make([]int, n)
+	for i := range order {
+		order[i] = n - 1 - i
+	}
+	return order
+}
+
+// index returns arg as 1-based index in [1; n].
+func index(arg any, n int) (int, error) {
+	val, err := number(arg)
+	if err != nil {
+		return 0, err
+	}
+	i := int(math.Trunc(val))
+	if i < 1 || i > n {
+		return 0, errorf(ErrValue, "index should be in [1; %d], got %d", n, i)
+	}
+	return i, nil
+}
+
+// lookupTable implements VLOOKUP (byRows is false) and HLOOKUP (byRows is true).
+func lookupTable(args []any, byRows bool) (any, error) {
+	if err := checkArgs(args, 3, 4); err != nil {
+		return nil, err
+	}
+	t, err := NewTable(args[1])
+	if err != nil {
+		return nil, err
+	}
+	n := t.Cols()
+	if byRows {
+		n = t.Rows()
+	}
+	i, err := index(args[2], n)
+	if err != nil {
+		return nil, err
+	}
+	approximate := true
+	if len(args) == 4 {
+		if approximate, err = boolean(args[3]); err != nil {
+			return nil, err
+		}
+	}
+	keys := t.Col(0)
+	if byRows {
+		keys = t.Row(0)
+	}
+	var pos int
+	if approximate {
+		m, _ := newMatcher(args[0], matchExact)
+		pos = m.sorted(keys, false)
+	} else {
+		m, err := newMatcher(args[0], matchWildcard)
+		if err != nil {
+			return nil, err
+		}
+		pos = m.find(keys, ascending(len(keys)))
+	}
+	if pos < 0 {
+		return nil, errorf(ErrNA, "%v not found", args[0])
+	}
+	if byRows {
+		return t.Cell(i-1, pos), nil
+	}
+	return t.Cell(pos, i-1), nil
+}
+
+// Vlookup looks for value in the first column of table and returns value from the
+// column of the found row. Approximate match (default) expects sorted first column.
+func Vlookup(args ...any) (any, error) {
+	return lookupTable(args, false)
+}
+
+// Hlookup looks for value in the first row of table and returns value from the
+// row of the found column. Approximate match (default) expects sorted first row.
+func Hlookup(args ...any) (any, error) {
+	return lookupTable(args, true)
+}
+
+// Xlookup looks for value in lookup array and returns corresponding element of
+// return array. Match mode is 0 (exact), -1 (exact or next smaller), 1 (exact or
+// next larger) or 2 (wildcard). Search mode is 1 (first to last), -1 (last to
+// first), 2 (binary search in ascending array) or -2 (binary search in descending array).
+func Xlookup(args ...any) (any, error) {
+	if err := checkArgs(args, 3, 6); err != nil {
+		return nil, err
+	}
+	keys, err := vector(args[1])
+	if err != nil {
+		return nil, err
+	}
+	mode, err := optionalNumber(args, 4, matchExact)
+	if err != nil {
+		return nil, err
+	}
+	search, err := optionalNumber(args, 5, 1)
+	if err != nil {
+		return nil, err
+	}
+	switch mode {
+	case matchExact, matchNextSmaller, matchNextLarger, matchWildcard:
+	default:
+		return nil, errorf(ErrValue, "unknown match mode %v", mode)
+	}
+	m, err := newMatcher(args[0], int(mode))
+	if err != nil {
+		return nil, err
+	}
+	var pos int
+	switch search {
+	case 1:
+		pos = m.find(keys, ascending(len(keys)))
+	case -1:
+		pos = m.find(keys, descending(len(keys)))
+	case 2:
+		pos = m.binary(keys, false)
+	case -2:
+		pos = m.binary(keys, true)
+	default:
+		return nil, errorf(ErrValue, "unknown search mode %v", search)
+	}
+	if pos < 0 {
+		if len(args) >= 4 && args[3] != nil {
+			return args[3], nil
+		}
+		return nil, errorf(ErrNA, "%v not found", args[0])
+	}
+	if t, ok := args[2].(Table); ok && t.Rows() == len(keys) && t.Cols() > 1 {
+		return t.Row(pos), nil
+	}
+	values, err := vector(args[2])
+	if err != nil {
+		return nil, err
+	}
+	if len(values) != len(keys) {
+		return nil, errorf(ErrValue, "lookup and return arrays have different length: %d and %d", len(keys), len(values))
+	}
+	return values[pos], nil
+}
+
+// Match returns 1-based position of value in array. Match type is 1 (default, the
+// largest value not greater than lookup value in ascending array), 0 (exact, with
+// wildcards) or -1 (the smallest value not less than lookup value in descending array).
+func Match(args ...any) (any, error) {
+	if err := checkArgs(args, 2, 3); err != nil {
+		return nil, err
+	}
+	values, err := vector(args[1])
+	if err != nil {
+		return nil, err
+	}
+	kind, err := optionalNumber(args, 2, 1)
+	if err != nil {
+		return nil, err
+	}
+	var pos int
+	switch {
+	case kind == 0:
+		m, err := newMatcher(args[0], matchWildcard)
+		if err != nil {
+			return nil, err
+		}
+		pos = m.find(values, ascending(len(values)))
+	default:
+		m, _ := newMatcher(args[0], matchExact)
+		pos = m.sorted(values, kind < 0)
+	}
+	if pos < 0 {
+		return nil, errorf(ErrNA, "%v not found", args[0])
+	}
+	return float64(pos + 1), nil
+}
+
+// Index returns element of array or table at 1-based row and column.
+// Row or column 0 returns the whole column or row of a table.
+func Index(args ...any) (any, error) {
+	if err := checkArgs(args, 2, 3); err != nil {
+		return nil, err
+	}
+	row, err := number(args[1])
+	if err != nil {
+		return nil, err
+	}
+	col, err := optionalNumber(args, 2, 0)
+	if err != nil {
+		return nil, err
+	}
+	t, err := NewTable(args[0])
+	if err != nil {
+		values, err := vector(args[0])
+		if err != nil {
+			return nil, err
+		}
+		i := row
+		if len(args) == 3 && row <= 1 {
+			i = col
+		}
+		pos, err := index(i, len(values))
+		if err != nil {
+			return nil, errorf(ErrRef, "index %v is out of range", i)
+		}
+		return values[pos-1], nil
+	}
+	switch {
+	case row == 0 && col == 0:
+		return t, nil
+	case row == 0:
+		c, err := index(col, t.Cols())
+		if err != nil {
+			return nil, errorf(ErrRef, "column %v is out of range", col)
+		}
+		return t.Col(c - 1), nil
+	case col == 0 && t.Cols() > 1:
+		r, err := index(row, t.Rows())
+		if err != nil {
+			return nil, errorf(ErrRef, "row %v is out of range", row)
+		}
+		return t.Row(r - 1), nil
+	}
+	if col == 0 {
+		col = 1
+	}
+	r, err := index(row, t.Rows())
+	if err != nil {
+		return nil, errorf(ErrRef, "row %v is out of range", row)
+	}
+	c, err := index(col, t.Cols())
+	if err != nil {
+		return nil, errorf(ErrRef, "column %v is out of range", col)
+	}
+	return t.Cell(r-1, c-1), nil
+}
+
+// Choose returns value at 1-based index from the list of values.
+func Choose(args ...any) (any, error) {
+	if err := checkArgs(args, 2, -1); err != nil {
+		return nil, err
+	}
+	i, err := index(args[0], len(args)-1)
+	if err != nil {
+		return nil, err
+	}
+	return args[i], nil
+}
+
+var lookupDefinitions = []Definition{
+	{
+		Name:        "VLOOKUP",
+		Func:        Vlookup,
+		Signature:   Signatures["Vlookup"],
+		Params:      []string{"lookup_value", "table", "col_index", "approximate"},
+		Description: "Looks for a value in the first column of a table and returns a value from the given column.",
+		Examples:    []string{"VLOOKUP(120; Rates; 2)", `VLOOKUP("gold"; Tiers; 3; 1=0)`},
+		Category:    CategoryLookup,
+		Pure:        true,
+	},
+	{
+		Name:        "HLOOKUP",
+		Func:        Hlookup,
+		Signature:   Signatures["Hlookup"],
+		Params:      []string{"lookup_value", "table", "row_index", "approximate"},
+		Description: "Looks for a value in the first row of a table and returns a value from the given row.",
+		Examples:    []string{"HLOOKUP(2024; Years; 2)"},
+		Category:    CategoryLookup,
+		Pure:        true,
+	},
+	{
+		Name:        "XLOOKUP",
+		Func:        Xlookup,
+		Signature:   Signatures["Xlookup"],
+		Params:      []string{"lookup_value", "lookup_array", "return_array", "if_not_found", "match_mode", "search_mode"},
+		Description: "Looks for a value in an array and returns the corresponding element of another array.",
+		Examples:    []string{`XLOOKUP("b"; Keys; Values; "none")`},
+		Category:    CategoryLookup,
+		Pure:        true,
+	},
+	{
+		Name:        "MATCH",
+		Func:        Match,
+		Signature:   Signatures["Match"],
+		Params:      []string{"lookup_value", "lookup_array", "match_type"},
+		Description: "Returns the position of a value in an array.",
+		Examples:    []string{`MATCH("b"; Keys; 0)`},
+		Category:    CategoryLookup,
+		Pure:        true,
+	},
+	{
+		Name:        "INDEX",
+		Func:        Index,
+		Signature:   Signatures["Index"],
+		Params:      []string{"array", "row", "col"},
+		Description: "Returns the element of an array or table at the given row and column.",
+		Examples:    []string{"INDEX(Rates; 2; 1)"},
+		Category:    CategoryLookup,
+		Pure:        true,
+	},
+	{
+		Name:        "CHOOSE",
+		Func:        Choose,
+		Signature:   Signatures["Choose"],
+		Params:      []string{"index", "value", "value"},
+		Description: "Returns a value from the list by its index.",
+		Examples:    []string{`CHOOSE(2; "a"; "b"; "c") = "b"`},
+		Category:    CategoryLookup,
+		Pure:        true,
+	},
+}
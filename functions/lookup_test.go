@@ -0,0 +1,106 @@
+package functions
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+var rates = Table{
+	{0., "none", 0.},
+	{100., "bronze", 0.05},
+	{500., "silver", 0.1},
+	{1000., "gold", 0.15},
+}
+
+func TestLookup(t *testing.T) {
+	keys := []any{"apple", "banana", "cherry", "banana"}
+	values := []float64{1, 2, 3, 4}
+	sorted := []float64{10, 20, 30, 40}
+	cases := []struct {
+		name     string
+		fn       func(args ...any) (any, error)
+		args     []any
+		expected any
+	}{
+		{"Vlookup", Vlookup, []any{120., rates, 2.}, "bronze"},
+		{"Vlookup", Vlookup, []any{1000., rates, 3.}, 0.15},
+		{"Vlookup", Vlookup, []any{5000., rates, 2.}, "gold"},
+		{"Vlookup", Vlookup, []any{500., rates, 2., false}, "silver"},
+		{"Hlookup", Hlookup, []any{"b", Table{{"a", "b"}, {1., 2.}}, 2., false}, 2.},
+		{"Hlookup", Hlookup, []any{"b*", Table{{"abc", "bcd"}, {1., 2.}}, 2., false}, 2.},
+		{"Xlookup", Xlookup, []any{"BANANA", keys, values}, 2.},
+		{"Xlookup", Xlookup, []any{"banana", keys, values, nil, 0., -1.}, 4.},
+		{"Xlookup", Xlookup, []any{"kiwi", keys, values, "none"}, "none"},
+		{"Xlookup", Xlookup, []any{"ch*", keys, values, nil, 2.}, 3.},
+		{"Xlookup", Xlookup, []any{25., sorted, keys, nil, -1.}, "banana"},
+		{"Xlookup", Xlookup, []any{25., sorted, keys, nil, 1.}, "cherry"},
+		{"Xlookup", Xlookup, []any{25., sorted, keys, nil, 1., 2.}, "cherry"},
+		{"Xlookup", Xlookup, []any{25., []float64{40, 30, 20, 10}, keys, nil, -1., -2.}, "cherry"},
+		{"Xlookup", Xlookup, []any{30., sorted, keys, nil, 0., 2.}, "cherry"},
+		{"Xlookup", Xlookup, []any{100., rates.Col(0), rates}, []any{100., "bronze", 0.05}},
+		{"Match", Match, []any{"cherry", keys, 0.}, 3.},
+		{"Match", Match, []any{35., sorted}, 3.},
+		{"Match", Match, []any{25., []float64{40, 30, 20, 10}, -1.}, 2.},
+		{"Index", Index, []any{rates, 3., 2.}, "silver"},
+		{"Index", Index, []any{rates, 0., 1.}, []any{0., 100., 500., 1000.}},
+		{"Index", Index, []any{rates, 2.}, []any{100., "bronze", 0.05}},
+		{"Index", Index, []any{keys, 2.}, "banana"},
+		{"Index", Index, []any{Table{{1., 2., 3.}}, 1., 3.}, 3.},
+		{"Index", Index, []any{Table{{1., 2.}, {3.}}, 2., 2.}, Blank},
+		{"Vlookup", Vlookup, []any{3., Table{{1., 2.}, {3.}}, 2., false}, Blank},
+		{"Choose", Choose, []any{2., "a", "b", "c"}, "b"},
+	}
+	for _, c := range cases {
+		res, err := c.fn(c.args...)
+		if err != nil {
+			t.Fatalf("%s%v: expected nil error, got %s", c.name, c.args, err)
+		}
+		if !reflect.DeepEqual(res, c.expected) {
+			t.Errorf("%s%v: expected %v, got %v", c.name, c.args, c.expected, res)
+		}
+	}
+}
+
+func TestLookup_NotFound(t *testing.T) {
+	cases := []struct {
+		name string
+		fn   func(args ...any) (any, error)
+		args []any
+		code ErrorCode
+	}{
+		{"Vlookup", Vlookup, []any{-1., rates, 2.}, ErrNA},
+		{"Vlookup", Vlookup, []any{120., rates, 2., false}, ErrNA},
+		{"Vlookup", Vlookup, []any{120., rates, 4.}, ErrValue},
+		{"Xlookup", Xlookup, []any{"kiwi", []any{"apple"}, []any{1.}}, ErrNA},
+		{"Xlookup", Xlookup, []any{5., []float64{10, 20}, []any{1., 2.}, nil, -1.}, ErrNA},
+		{"Match", Match, []any{"kiwi", []any{"apple"}, 0.}, ErrNA},
+		{"Index", Index, []any{rates, 5., 1.}, ErrRef},
+		{"Choose", Choose, []any{4., "a", "b", "c"}, ErrValue},
+	}
+	for _, c := range cases {
+		_, err := c.fn(c.args...)
+		if !errors.Is(err, c.code) {
+			t.Errorf("%s%v: expected %s, got %v", c.name, c.args, c.code, err)
+		}
+	}
+}
+
+func TestNewTable(t *testing.T) {
+	table, err := NewTable([][]float64{{1, 2}, {3}})
+	if err != nil {
+		t.Fatalf("expected nil error, got %s", err)
+	}
+	if table.Rows() != 2 || table.Cols() != 2 {
+		t.Errorf("expected 2x2 table, got %dx%d", table.Rows(), table.Cols())
+	}
+	if cell := table.Cell(1, 1); cell != Blank {
+		t.Errorf("expected Blank for missing cell, got %v", cell)
+	}
+	if row := table.Row(1); row[1] != Blank {
+		t.Errorf("expected row padded with Blank, got %v", row)
+	}
+	if _, err := NewTable([]float64{1}); err == nil {
+		t.Errorf("expected error for one-dimensional array")
+	}
+}
@@ -0,0 +1,762 @@
+package functions
+
+import (
+	"math"
+	"strconv"
+)
+
+// significant rounds x to 15 significant digits, the precision of Excel numbers.
+// It removes binary representation artifacts like 2.675*100 = 267.49999999999997.
+func significant(x float64) float64 {
+	if x == 0 || math.IsInf(x, 0) || math.IsNaN(x) {
+		return x
+	}
+	res, _ := strconv.ParseFloat(strconv.FormatFloat(x, 'g', 15, 64), 64)
+	return res
+}
+
+// roundDigits rounds x to digits after the decimal point with rounding function fn.
+// Negative digits round to the left of the decimal point.
+// Results greater than the largest float64 are infinite.
+func roundDigits(x, digits float64, fn func(float64) float64) float64 {
+	digits = math.Trunc(digits)
+	if digits < -maxFloatDigits {
+		// finite numbers are less than 10^309, they are rounded to 0 or overflow
+		if x == 0 || fn(math.Copysign(0.1, x)) == 0 {
+			return 0
+		}
+		return math.Copysign(math.Inf(1), x)
+	}
+	ratio := math.Pow(10, math.Abs(digits))
+	if digits < 0 {
+		return significant(fn(significant(significant(x)/ratio)) * ratio)
+	}
+	scaled := significant(significant(x) * ratio)
+	if !(math.Abs(scaled) < 1e15) {
+		// 15 significant digits of x are all before the position
+		return significant(x)
+	}
+	return significant(fn(scaled) / ratio)
+}
+
+// awayFromZero rounds x up to an integer away from zero.
+func awayFromZero(x float64) float64 {
+	if x < 0 {
+		return math.Floor(x)
+	}
+	return math.Ceil(x)
+}
+
+const (
+	// maxRoundDigits limits the number of digits of rounding functions.
+	maxRoundDigits = 1000
+	// maxFloatDigits is the number of digits of the largest float64 number.
+	maxFloatDigits = 308
+)
+
+// roundFunc returns rounding function with optional digits argument.
+// Decimals and money are rounded exactly with mode.
+func roundFunc(fn func(float64) float64, mode RoundingMode) func(args ...any) (any, error) {
+	return func(args ...any) (any, error) {
+		if err := checkArgs(args, 1, 2); err != nil {
+			return nil, err
+		}
+		digits, err := optionalNumber(args, 1, 0)
+		if err != nil {
+			return nil, err
+		}
+		if !(math.Abs(digits) <= maxRoundDigits) {
+			return nil, errorf(ErrNum, "number of digits should be in [-%d; %d], got %v", maxRoundDigits, maxRoundDigits, digits)
+		}
+		switch val := args[0].(type) {
+		case Decimal:
+			return val.Round(int32(digits), mode), nil
+		case Money:
+			return Money{Amount: val.Amount.Round(int32(digits), mode), Currency: val.Currency}, nil
+		}
+		val, err := number(args[0])
+		if err != nil {
+			return nil, err
+		}
+		res := roundDigits(val, digits, fn)
+		if math.IsInf(res, 0) && !math.IsInf(val, 0) {
+			return nil, errorf(ErrNum, "rounding of %v to %v digits overflows", val, digits)
+		}
+		return res, nil
+	}
+}
+
+var (
+	// Roundup rounds a number away from zero to a specified number of digits.
+	Roundup = roundFunc(awayFromZero, RoundUp)
+	// Rounddown rounds a number towards zero to a specified number of digits.
+	Rounddown = roundFunc(math.Trunc, RoundDown)
+	// Trunc truncates a number to a specified number of digits.
+	Trunc = roundFunc(math.Trunc, RoundDown)
+)
+
+// numberFunc returns function of one number argument. fn returns an error for
+// numbers outside of its domain.
+func numberFunc(fn func(float64) (float64, error)) func(args ...any) (any, error) {
+	return func(args ...any) (any, error) {
+		if err := checkArgs(args, 1, 1); err != nil {
+			return nil, err
+		}
+		val, err := number(args[0])
+		if err != nil {
+			return nil, err
+		}
+		res, err := fn(val)
+		if err != nil {
+			return nil, err
+		}
+		return res, nil
+	}
+}
+
+// anyNumber wraps a function defined for all numbers.
+func anyNumber(fn func(float64) float64) func(float64) (float64, error) {
+	return func(x float64) (float64, error) {
+		return fn(x), nil
+	}
+}
+
+var (
+	// Int rounds a number down to the nearest integer.
+	Int = numberFunc(anyNumber(math.Floor))
+	// Abs returns absolute value of a number.
+	Abs = numberFunc(anyNumber(math.Abs))
+	// Exp returns e raised to the power of a number.
+	Exp = numberFunc(anyNumber(math.Exp))
+	// Sign returns 1 for positive numbers, -1 for negative numbers and 0 for zero.
+	Sign = numberFunc(anyNumber(func(x float64) float64 {
+		switch {
+		case x > 0:
+			return 1
+		case x < 0:
+			return -1
+		}
+		return 0
+	}))
+	// Even rounds a number away from zero to the nearest even integer.
+	Even = numberFunc(anyNumber(func(x float64) float64 {
+		return awayFromZero(significant(x/2)) * 2
+	}))
+	// Odd rounds a number away from zero to the nearest odd integer.
+	Odd = numberFunc(anyNumber(func(x float64) float64 {
+		if x < 0 {
+			return -(math.Ceil(significant((-x-1)/2))*2 + 1)
+		}
+		return math.Ceil(significant((x-1)/2))*2 + 1
+	}))
+	// Sqrt returns square root of a number.
+	Sqrt = numberFunc(func(x float64) (float64, error) {
+		if x < 0 {
+			return 0, errorf(ErrNum, "square root of negative number %v", x)
+		}
+		return math.Sqrt(x), nil
+	})
+	// Ln returns natural logarithm of a number.
+	Ln = numberFunc(func(x float64) (float64, error) {
+		return logarithm(x, math.E)
+	})
+	// Log10 returns base-10 logarithm of a number.
+	Log10 = numberFunc(func(x float64) (float64, error) {
+		return logarithm(x, 10)
+	})
+)
+
+func logarithm(x, base float64) (float64, error) {
+	if x <= 0 || base <= 0 {
+		return 0, errorf(ErrNum, "logarithm of non-positive number")
+	}
+	if base == 1 {
+		return 0, errorf(ErrDiv0, "logarithm base is 1")
+	}
+	switch base {
+	case math.E:
+		return math.Log(x), nil
+	case 10:
+		return math.Log10(x), nil
+	case 2:
+		return math.Log2(x), nil
+	}
+	return significant(math.Log(x) / math.Log(base)), nil
+}
+
+// Log returns logarithm of a number to base, 10 by default.
+func Log(args ...any) (any, error) {
+	if err := checkArgs(args, 1, 2); err != nil {
+		return nil, err
+	}
+	val, err := number(args[0])
+	if err != nil {
+		return nil, err
+	}
+	base, err := optionalNumber(args, 1, 10)
+	if err != nil {
+		return nil, err
+	}
+	res, err := logarithm(val, base)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// twoNumbers returns arguments of a function of two numbers.
+func twoNumbers(args []any) (float64, float64, error) {
+	if err := checkArgs(args, 2, 2); err != nil {
+		return 0, 0, err
+	}
+	a, err := number(args[0])
+	if err != nil {
+		return 0, 0, err
+	}
+	b, err := number(args[1])
+	if err != nil {
+		return 0, 0, err
+	}
+	return a, b, nil
+}
+
+// modEpsilon is relative tolerance of remainders treated as 0 by Mod.
+const modEpsilon = 1e-14
+
+// Mod returns remainder of division. The result has the sign of the divisor.
+func Mod(args ...any) (any, error) {
+	if len(args) == 2 {
+		if n, d, ok := decimalPair(args[0], args[1]); ok {
+			return modDecimal(n, d)
+		}
+	}
+	n, d, err := twoNumbers(args)
+	if err != nil {
+		return nil, err
+	}
+	if d == 0 {
+		return nil, errorf(ErrDiv0, "division by zero")
+	}
+	if math.IsNaN(n) || math.IsNaN(d) || math.IsInf(n, 0) || math.IsInf(d, 0) {
+		return nil, errorf(ErrNum, "expected finite numbers, got %v and %v", n, d)
+	}
+	res := n - d*math.Floor(n/d)
+	// residuals of rounding errors of n/d are snapped to 0, so the result
+	// never has the sign opposite to the divisor
+	eps := modEpsilon * math.Max(math.Abs(n), math.Abs(d))
+	if math.Abs(res) <= eps || math.Abs(d-res) <= eps || res*d < 0 {
+		return 0., nil
+	}
+	return significant(res), nil
+}
+
+// Quotient returns integer part of division.
+func Quotient(args ...any) (any, error) {
+	n, d, err := twoNumbers(args)
+	if err != nil {
+		return nil, err
+	}
+	if d == 0 {
+		return nil, errorf(ErrDiv0, "division by zero")
+	}
+	return math.Trunc(significant(n / d)), nil
+}
+
+// Mround rounds a number to the nearest multiple. Number and multiple should have the same sign.
+func Mround(args ...any) (any, error) {
+	n, m, err := twoNumbers(args)
+	if err != nil {
+		return nil, err
+	}
+	if m == 0 {
+		return 0., nil
+	}
+	if n*m < 0 {
+		return nil, errorf(ErrNum, "number and multiple have different signs")
+	}
+	return significant(math.Round(significant(n/m)) * m), nil
+}
+
+// multiple rounds n to a multiple of significance with rounding function fn.
+func multiple(n, significance float64, fn func(float64) float64) float64 {
+	return significant(fn(significant(n/significance)) * significance)
+}
+
+// Ceiling rounds a number up to the nearest multiple of significance.
+// Negative numbers with negative significance are rounded away from zero.
+func Ceiling(args ...any) (any, error) {
+	n, s, err := twoNumbers(args)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case s == 0:
+		return 0., nil
+	case n > 0 && s < 0:
+		return nil, errorf(ErrNum, "positive number with negative significance")
+	}
+	return multiple(n, s, math.Ceil), nil
+}
+
+// Floor rounds a number down to the nearest multiple of significance.
+// Negative numbers with negative significance are rounded towards zero.
+func Floor(args ...any) (any, error) {
+	n, s, err := twoNumbers(args)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case s == 0:
+		return nil, errorf(ErrDiv0, "zero significance")
+	case n > 0 && s < 0:
+		return nil, errorf(ErrNum, "positive number with negative significance")
+	}
+	return multiple(n, s, math.Floor), nil
+}
+
+// mathArgs returns number, absolute value of significance (1 by default)
+// and mode (0 by default) of CEILING.MATH and FLOOR.MATH.
+func mathArgs(args []any) (n, significance float64, mode bool, err error) {
+	if err := checkArgs(args, 1, 3); err != nil {
+		return 0, 0, false, err
+	}
+	if n, err = number(args[0]); err != nil {
+		return 0, 0, false, err
+	}
+	if significance, err = optionalNumber(args, 1, 1); err != nil {
+		return 0, 0, false, err
+	}
+	m, err := optionalNumber(args, 2, 0)
+	if err != nil {
+		return 0, 0, false, err
+	}
+	return n, math.Abs(significance), m != 0, nil
+}
+
+// CeilingMath rounds a number up to the nearest multiple of significance.
+// Negative numbers are rounded towards zero unless mode is non-zero.
+func CeilingMath(args ...any) (any, error) {
+	n, s, mode, err := mathArgs(args)
+	if err != nil {
+		return nil, err
+	}
+	if s == 0 {
+		return 0., nil
+	}
+	if n < 0 && mode {
+		return multiple(n, s, math.Floor), nil
+	}
+	return multiple(n, s, math.Ceil), nil
+}
+
+// FloorMath rounds a number down to the nearest multiple of significance.
+// Negative numbers are rounded away from zero unless mode is non-zero.
+func FloorMath(args ...any) (any, error) {
+	n, s, mode, err := mathArgs(args)
+	if err != nil {
+		return nil, err
+	}
+	if s == 0 {
+		return 0., nil
+	}
+	if n < 0 && mode {
+		return multiple(n, s, math.Ceil), nil
+	}
+	return multiple(n, s, math.Floor), nil
+}
+
+// maxExactInteger is the bound of integers exactly represented by float64.
+const maxExactInteger = 1 << 53
+
+// integers returns flattened args truncated to non-negative integers.
+func integers(args []any) ([]float64, error) {
+	if err := checkArgs(args, 1, -1); err != nil {
+		return nil, err
+	}
+	numbers, err := flatten(args...)
+	if err != nil {
+		return nil, err
+	}
+	for i, val := range numbers {
+		if val < 0 {
+			return nil, errorf(ErrNum, "expected non-negative number, got %v", val)
+		}
+		if !(val < maxExactInteger) {
+			return nil, errorf(ErrNum, "expected integer less than 2^53, got %v", val)
+		}
+		numbers[i] = math.Trunc(val)
+	}
+	return numbers, nil
+}
+
+func gcd(a, b float64) float64 {
+	for b != 0 {
+		a, b = b, math.Mod(a, b)
+	}
+	return a
+}
+
+// Gcd returns the greatest common divisor of integers.
+func Gcd(args ...any) (any, error) {
+	numbers, err := integers(args)
+	if err != nil {
+		return nil, err
+	}
+	var res float64
+	for _, val := range numbers {
+		res = gcd(res, val)
+	}
+	return res, nil
+}
+
+// Lcm returns the least common multiple of integers.
+func Lcm(args ...any) (any, error) {
+	numbers, err := integers(args)
+	if err != nil {
+		return nil, err
+	}
+	res := 1.
+	for _, val := range numbers {
+		if val == 0 {
+			return 0., nil
+		}
+		res = res / gcd(res, val) * val
+	}
+	return res, nil
+}
+
+// Fact returns factorial of integer part of a number.
+func Fact(args ...any) (any, error) {
+	if err := checkArgs(args, 1, 1); err != nil {
+		return nil, err
+	}
+	val, err := number(args[0])
+	if err != nil {
+		return nil, err
+	}
+	if val < 0 {
+		return nil, errorf(ErrNum, "factorial of negative number %v", val)
+	}
+	res := permutations(math.Trunc(val), math.Trunc(val))
+	if math.IsInf(res, 0) {
+		return nil, errorf(ErrNum, "factorial of %v is too large", val)
+	}
+	return res, nil
+}
+
+// maxFactorial is the largest number which factorial is a finite float64.
+const maxFactorial = 170
+
+// permutations returns number of ordered selections of k items from n items.
+// The result is +Inf if it is too large: it is at least k! for k <= n.
+func permutations(n, k float64) float64 {
+	if k > maxFactorial {
+		return math.Inf(1)
+	}
+	res := 1.
+	for i := 0.; i < k; i++ {
+		res *= n - i
+	}
+	return res
+}
+
+// selection returns integer parts of number of items and number chosen
+// of COMBIN and PERMUT functions.
+func selection(args []any) (n, k float64, err error) {
+	n, k, err = twoNumbers(args)
+	if err != nil {
+		return 0, 0, err
+	}
+	n, k = math.Trunc(n), math.Trunc(k)
+	if n < 0 || k < 0 || k > n {
+		return 0, 0, errorf(ErrNum, "expected 0 <= number_chosen <= number, got %v and %v", k, n)
+	}
+	return n, k, nil
+}
+
+// Combin returns number of combinations of number_chosen items from number items.
+func Combin(args ...any) (any, error) {
+	n, k, err := selection(args)
+	if err != nil {
+		return nil, err
+	}
+	k = math.Min(k, n-k)
+	// the number is at least 2^k for k <= n/2
+	if k > 1024 {
+		return nil, errorf(ErrNum, "number of combinations is too large")
+	}
+	res := 1.
+	for i := 1.; i <= k; i++ {
+		res = res * (n - k + i) / i
+	}
+	if math.IsInf(res, 0) {
+		return nil, errorf(ErrNum, "number of combinations is too large")
+	}
+	return math.Round(res), nil
+}
+
+// Permut returns number of permutations of number_chosen items from number items.
+func Permut(args ...any) (any, error) {
+	n, k, err := selection(args)
+	if err != nil {
+		return nil, err
+	}
+	res := permutations(n, k)
+	if math.IsInf(res, 0) {
+		return nil, errorf(ErrNum, "number of permutations is too large")
+	}
+	return res, nil
+}
+
+var mathDefinitions = []Definition{
+	{
+		Name:        "ROUNDUP",
+		Func:        Roundup,
+		Signature:   Signatures["Roundup"],
+		Params:      []string{"number", "digits"},
+		Description: "Rounds a number away from zero to a specified number of digits.",
+		Examples:    []string{"ROUNDUP(3,141; 2) = 3,15", "ROUNDUP(-1234; -2) = -1300"},
+		Category:    CategoryMath,
+		Pure:        true,
+	},
+	{
+		Name:        "ROUNDDOWN",
+		Func:        Rounddown,
+		Signature:   Signatures["Rounddown"],
+		Params:      []string{"number", "digits"},
+		Description: "Rounds a number towards zero to a specified number of digits.",
+		Examples:    []string{"ROUNDDOWN(3,149; 2) = 3,14"},
+		Category:    CategoryMath,
+		Pure:        true,
+	},
+	{
+		Name:        "TRUNC",
+		Func:        Trunc,
+		Signature:   Signatures["Trunc"],
+		Params:      []string{"number", "digits"},
+		Description: "Truncates a number to a specified number of digits.",
+		Examples:    []string{"TRUNC(-8,9) = -8"},
+		Category:    CategoryMath,
+		Pure:        true,
+	},
+	{
+		Name:        "MROUND",
+		Func:        Mround,
+		Signature:   Signatures["Mround"],
+		Params:      []string{"number", "multiple"},
+		Description: "Rounds a number to the nearest multiple.",
+		Examples:    []string{"MROUND(10; 3) = 9"},
+		Category:    CategoryMath,
+		Pure:        true,
+	},
+	{
+		Name:        "CEILING",
+		Func:        Ceiling,
+		Signature:   Signatures["Ceiling"],
+		Params:      []string{"number", "significance"},
+		Description: "Rounds a number up to the nearest multiple of significance.",
+		Examples:    []string{"CEILING(2,5; 1) = 3", "CEILING(-2,5; -2) = -4"},
+		Category:    CategoryMath,
+		Pure:        true,
+	},
+	{
+		Name:        "CEILING.MATH",
+		Func:        CeilingMath,
+		Signature:   Signatures["CeilingMath"],
+		Params:      []string{"number", "significance", "mode"},
+		Description: "Rounds a number up to the nearest multiple of significance, negative numbers towards zero unless mode is non-zero.",
+		Examples:    []string{"CEILING.MATH(-5,5; 2) = -4", "CEILING.MATH(-5,5; 2; -1) = -6"},
+		Category:    CategoryMath,
+		Pure:        true,
+	},
+	{
+		Name:        "FLOOR",
+		Func:        Floor,
+		Signature:   Signatures["Floor"],
+		Params:      []string{"number", "significance"},
+		Description: "Rounds a number down to the nearest multiple of significance.",
+		Examples:    []string{"FLOOR(2,5; 1) = 2", "FLOOR(-2,5; -2) = -2"},
+		Category:    CategoryMath,
+		Pure:        true,
+	},
+	{
+		Name:        "FLOOR.MATH",
+		Func:        FloorMath,
+		Signature:   Signatures["FloorMath"],
+		Params:      []string{"number", "significance", "mode"},
+		Description: "Rounds a number down to the nearest multiple of significance, negative numbers away from zero unless mode is non-zero.",
+		Examples:    []string{"FLOOR.MATH(-5,5; 2) = -6", "FLOOR.MATH(-5,5; 2; -1) = -4"},
+		Category:    CategoryMath,
+		Pure:        true,
+	},
+	{
+		Name:        "INT",
+		Func:        Int,
+		Signature:   Signatures["Int"],
+		Params:      []string{"number"},
+		Description: "Rounds a number down to the nearest integer.",
+		Examples:    []string{"INT(-8,9) = -9"},
+		Category:    CategoryMath,
+		Pure:        true,
+	},
+	{
+		Name:        "EVEN",
+		Func:        Even,
+		Signature:   Signatures["Even"],
+		Params:      []string{"number"},
+		Description: "Rounds a number away from zero to the nearest even integer.",
+		Examples:    []string{"EVEN(1,5) = 2", "EVEN(-1) = -2"},
+		Category:    CategoryMath,
+		Pure:        true,
+	},
+	{
+		Name:        "ODD",
+		Func:        Odd,
+		Signature:   Signatures["Odd"],
+		Params:      []string{"number"},
+		Description: "Rounds a number away from zero to the nearest odd integer.",
+		Examples:    []string{"ODD(2) = 3", "ODD(-1,5) = -3"},
+		Category:    CategoryMath,
+		Pure:        true,
+	},
+	{
+		Name:        "MOD",
+		Func:        Mod,
+		Signature:   Signatures["Mod"],
+		Params:      []string{"number", "divisor"},
+		Description: "Returns the remainder of division, the result has the sign of the divisor.",
+		Examples:    []string{"MOD(3; 2) = 1", "MOD(-3; 2) = 1", "MOD(3; -2) = -1"},
+		Category:    CategoryMath,
+		Pure:        true,
+	},
+	{
+		Name:        "QUOTIENT",
+		Func:        Quotient,
+		Signature:   Signatures["Quotient"],
+		Params:      []string{"numerator", "denominator"},
+		Description: "Returns the integer part of division.",
+		Examples:    []string{"QUOTIENT(-10; 3) = -3"},
+		Category:    CategoryMath,
+		Pure:        true,
+	},
+	{
+		Name:        "GCD",
+		Func:        Gcd,
+		Signature:   Signatures["Gcd"],
+		Params:      []string{"number", "number"},
+		Description: "Returns the greatest common divisor of integers.",
+		Examples:    []string{"GCD(24; 36) = 12"},
+		Category:    CategoryMath,
+		Pure:        true,
+	},
+	{
+		Name:        "LCM",
+		Func:        Lcm,
+		Signature:   Signatures["Lcm"],
+		Params:      []string{"number", "number"},
+		Description: "Returns the least common multiple of integers.",
+		Examples:    []string{"LCM(4; 6) = 12"},
+		Category:    CategoryMath,
+		Pure:        true,
+	},
+	{
+		Name:        "ABS",
+		Func:        Abs,
+		Signature:   Signatures["Abs"],
+		Params:      []string{"number"},
+		Description: "Returns the absolute value of a number.",
+		Examples:    []string{"ABS(-2) = 2"},
+		Category:    CategoryMath,
+		Pure:        true,
+	},
+	{
+		Name:        "SIGN",
+		Func:        Sign,
+		Signature:   Signatures["Sign"],
+		Params:      []string{"number"},
+		Description: "Returns 1 for positive numbers, -1 for negative numbers and 0 for zero.",
+		Examples:    []string{"SIGN(-2) = -1"},
+		Category:    CategoryMath,
+		Pure:        true,
+	},
+	{
+		Name:        "SQRT",
+		Func:        Sqrt,
+		Signature:   Signatures["Sqrt"],
+		Params:      []string{"number"},
+		Description: "Returns the square root of a number.",
+		Examples:    []string{"SQRT(16) = 4"},
+		Category:    CategoryMath,
+		Pure:        true,
+	},
+	{
+		Name:        "EXP",
+		Func:        Exp,
+		Signature:   Signatures["Exp"],
+		Params:      []string{"number"},
+		Description: "Returns e raised to the power of a number.",
+		Examples:    []string{"EXP(0) = 1"},
+		Category:    CategoryMath,
+		Pure:        true,
+	},
+	{
+		Name:        "LN",
+		Func:        Ln,
+		Signature:   Signatures["Ln"],
+		Params:      []string{"number"},
+		Description: "Returns the natural logarithm of a number.",
+		Examples:    []string{"LN(EXP(2)) = 2"},
+		Category:    CategoryMath,
+		Pure:        true,
+	},
+	{
+		Name:        "LOG",
+		Func:        Log,
+		Signature:   Signatures["Log"],
+		Params:      []string{"number", "base"},
+		Description: "Returns the logarithm of a number to the base, 10 by default.",
+		Examples:    []string{"LOG(100) = 2", "LOG(8; 2) = 3"},
+		Category:    CategoryMath,
+		Pure:        true,
+	},
+	{
+		Name:        "LOG10",
+		Func:        Log10,
+		Signature:   Signatures["Log10"],
+		Params:      []string{"number"},
+		Description: "Returns the base-10 logarithm of a number.",
+		Examples:    []string{"LOG10(1000) = 3"},
+		Category:    CategoryMath,
+		Pure:        true,
+	},
+	{
+		Name:        "FACT",
+		Func:        Fact,
+		Signature:   Signatures["Fact"],
+		Params:      []string{"number"},
+		Description: "Returns the factorial of a number.",
+		Examples:    []string{"FACT(5) = 120"},
+		Category:    CategoryMath,
+		Pure:        true,
+	},
+	{
+		Name:        "COMBIN",
+		Func:        Combin,
+		Signature:   Signatures["Combin"],
+		Params:      []string{"number", "number_chosen"},
+		Description: "Returns the number of combinations of items.",
+		Examples:    []string{"COMBIN(8; 2) = 28"},
+		Category:    CategoryMath,
+		Pure:        true,
+	},
+	{
+		Name:        "PERMUT",
+		Func:        Permut,
+		Signature:   Signatures["Permut"],
+		Params:      []string{"number", "number_chosen"},
+		Description: "Returns the number of permutations of items.",
+		Examples:    []string{"PERMUT(100; 3) = 970200"},
+		Category:    CategoryStatistical,
+		Pure:        true,
+	},
+}
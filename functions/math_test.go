@@ -0,0 +1,146 @@
+package functions
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestMath(t *testing.T) {
+	cases := []struct {
+		name     string
+		fn       func(args ...any) (any, error)
+		args     []any
+		expected float64
+	}{
+		{"Round", Round, []any{2.675, 2.}, 2.68},
+		{"Round", Round, []any{1.005, 2.}, 1.01},
+		{"Round", Round, []any{-2.5}, -3},
+		{"Round", Round, []any{1234., -2.}, 1200},
+		{"Round", Round, []any{1250., -2.}, 1300},
+		{"Round", Round, []any{0.1 + 0.2, 15.}, 0.3},
+		{"Round", Round, []any{1.5, 400.}, 1.5},
+		{"Round", Round, []any{1.5, 308.}, 1.5},
+		{"Round", Round, []any{1e10, 300.}, 1e10},
+		{"Round", Round, []any{1e-320, 400.}, 1e-320},
+		{"Round", Round, []any{1.5, -400.}, 0},
+		{"Round", Round, []any{1e308, -308.}, 1e308},
+		{"Roundup", Roundup, []any{1.5, 400.}, 1.5},
+		{"Rounddown", Rounddown, []any{-1.5, -400.}, 0},
+		{"Trunc", Trunc, []any{1.5, 309.}, 1.5},
+		{"Roundup", Roundup, []any{3.141, 2.}, 3.15},
+		{"Roundup", Roundup, []any{-1234., -2.}, -1300},
+		{"Roundup", Roundup, []any{0.1 * 3, 1.}, 0.3},
+		{"Rounddown", Rounddown, []any{3.149, 2.}, 3.14},
+		{"Rounddown", Rounddown, []any{4.35 * 100}, 435},
+		{"Trunc", Trunc, []any{-8.9}, -8},
+		{"Int", Int, []any{-8.9}, -9},
+		{"Mround", Mround, []any{10., 3.}, 9},
+		{"Mround", Mround, []any{-10., -3.}, -9},
+		{"Mround", Mround, []any{1.3, 0.2}, 1.4},
+		{"Ceiling", Ceiling, []any{2.5, 1.}, 3},
+		{"Ceiling", Ceiling, []any{-2.5, 2.}, -2},
+		{"Ceiling", Ceiling, []any{-2.5, -2.}, -4},
+		{"Ceiling", Ceiling, []any{0.234, 0.01}, 0.24},
+		{"CeilingMath", CeilingMath, []any{-5.5, 2.}, -4},
+		{"CeilingMath", CeilingMath, []any{-5.5, 2., -1.}, -6},
+		{"CeilingMath", CeilingMath, []any{24.3, 5.}, 25},
+		{"Floor", Floor, []any{2.5, 1.}, 2},
+		{"Floor", Floor, []any{-2.5, -2.}, -2},
+		{"Floor", Floor, []any{-2.5, 2.}, -4},
+		{"FloorMath", FloorMath, []any{-5.5, 2.}, -6},
+		{"FloorMath", FloorMath, []any{-5.5, 2., -1.}, -4},
+		{"FloorMath", FloorMath, []any{24.3, 5.}, 20},
+		{"Even", Even, []any{1.5}, 2},
+		{"Even", Even, []any{3.}, 4},
+		{"Even", Even, []any{-1.}, -2},
+		{"Odd", Odd, []any{1.5}, 3},
+		{"Odd", Odd, []any{2.}, 3},
+		{"Odd", Odd, []any{-1.}, -1},
+		{"Odd", Odd, []any{-2.}, -3},
+		{"Odd", Odd, []any{0.}, 1},
+		{"Mod", Mod, []any{3., 2.}, 1},
+		{"Mod", Mod, []any{-3., 2.}, 1},
+		{"Mod", Mod, []any{3., -2.}, -1},
+		{"Mod", Mod, []any{-3., -2.}, -1},
+		{"Mod", Mod, []any{0.3, 0.1}, 0},
+		{"Mod", Mod, []any{0.7, 0.1}, 0},
+		{"Mod", Mod, []any{-0.3, 0.1}, 0},
+		{"Mod", Mod, []any{0.3, -0.1}, 0},
+		{"Mod", Mod, []any{0.25, 0.1}, 0.05},
+		{"Mod", Mod, []any{-0.25, 0.1}, 0.05},
+		{"Quotient", Quotient, []any{-10., 3.}, -3},
+		{"Gcd", Gcd, []any{24., 36., []float64{18}}, 6},
+		{"Gcd", Gcd, []any{5., 0.}, 5},
+		{"Lcm", Lcm, []any{4., 6., 10.}, 60},
+		{"Lcm", Lcm, []any{4., 0.}, 0},
+		{"Abs", Abs, []any{-2.}, 2},
+		{"Sign", Sign, []any{-0.5}, -1},
+		{"Sqrt", Sqrt, []any{16.}, 4},
+		{"Exp", Exp, []any{0.}, 1},
+		{"Ln", Ln, []any{1.}, 0},
+		{"Log", Log, []any{100.}, 2},
+		{"Log", Log, []any{8., 2.}, 3},
+		{"Log", Log, []any{1000., 10.}, 3},
+		{"Log", Log, []any{81., 3.}, 4},
+		{"Log10", Log10, []any{1e-3}, -3},
+		{"Fact", Fact, []any{5.9}, 120},
+		{"Fact", Fact, []any{0.}, 1},
+		{"Combin", Combin, []any{8., 2.}, 28},
+		{"Combin", Combin, []any{60., 30.}, 118264581564861424},
+		{"Permut", Permut, []any{100., 3.}, 970200},
+		{"Permut", Permut, []any{1e20, 0.}, 1},
+		{"Combin", Combin, []any{1e300, 1.}, 1e300},
+	}
+	for _, c := range cases {
+		res, err := c.fn(c.args...)
+		if err != nil {
+			t.Fatalf("%s%v: expected nil error, got %s", c.name, c.args, err)
+		}
+		if res != c.expected {
+			t.Errorf("%s%v: expected %v, got %v", c.name, c.args, c.expected, res)
+		}
+	}
+}
+
+func TestMath_Errors(t *testing.T) {
+	cases := []struct {
+		name string
+		fn   func(args ...any) (any, error)
+		args []any
+		code ErrorCode
+	}{
+		{"Mod", Mod, []any{1., 0.}, ErrDiv0},
+		{"Mod", Mod, []any{math.Inf(1), 2.}, ErrNum},
+		{"Fact", Fact, []any{1e20}, ErrNum},
+		{"Permut", Permut, []any{1e20, 1e19}, ErrNum},
+		{"Combin", Combin, []any{1e300, 1e20}, ErrNum},
+		{"Combin", Combin, []any{2000., 1000.}, ErrNum},
+		{"Gcd", Gcd, []any{math.NaN(), 2.}, ErrNum},
+		{"Gcd", Gcd, []any{math.Inf(1), 2.}, ErrNum},
+		{"Lcm", Lcm, []any{math.Inf(1), 2.}, ErrNum},
+		{"Lcm", Lcm, []any{1e16, 2.}, ErrNum},
+		{"Quotient", Quotient, []any{1., 0.}, ErrDiv0},
+		{"Mround", Mround, []any{10., -3.}, ErrNum},
+		{"Ceiling", Ceiling, []any{2.5, -1.}, ErrNum},
+		{"Floor", Floor, []any{2.5, 0.}, ErrDiv0},
+		{"Gcd", Gcd, []any{-1., 2.}, ErrNum},
+		{"Sqrt", Sqrt, []any{-1.}, ErrNum},
+		{"Ln", Ln, []any{0.}, ErrNum},
+		{"Log", Log, []any{10., 1.}, ErrDiv0},
+		{"Log", Log, []any{0.}, ErrNum},
+		{"Log10", Log10, []any{-1.}, ErrNum},
+		{"Roundup", Roundup, []any{1.5, -400.}, ErrNum},
+		{"Roundup", Roundup, []any{1.5e308, -308.}, ErrNum},
+		{"Fact", Fact, []any{-1.}, ErrNum},
+		{"Fact", Fact, []any{171.}, ErrNum},
+		{"Combin", Combin, []any{2., 3.}, ErrNum},
+		{"Permut", Permut, []any{-2., 1.}, ErrNum},
+	}
+	for _, c := range cases {
+		res, err := c.fn(c.args...)
+		if !errors.Is(err, c.code) || res != nil {
+			t.Errorf("%s%v: expected %s, got %v (%v)", c.name, c.args, c.code, res, err)
+		}
+	}
+}
@@ -0,0 +1,304 @@
+package functions
+
+import "strings"
+
+// Money is an exact amount in a currency with three-letter ISO 4217 code.
+// Amounts in different currencies can't be added or compared.
+type Money struct {
+	Amount   Decimal
+	Currency string
+}
+
+// NewMoney returns amount in currency. The code is converted to upper case.
+func NewMoney(amount Decimal, currency string) (Money, error) {
+	code := strings.ToUpper(currency)
+	if len(code) != 3 || strings.Trim(code, "ABCDEFGHIJKLMNOPQRSTUVWXYZ") != "" {
+		return Money{}, errorf(ErrValue, "invalid currency code '%s'", currency)
+	}
+	return Money{Amount: amount, Currency: code}, nil
+}
+
+// String returns amount followed by currency code, like "10.50 USD".
+func (m Money) String() string {
+	return m.Amount.String() + " " + m.Currency
+}
+
+// same checks that n is in the currency of m.
+func (m Money) same(n Money, op string) error {
+	if m.Currency != n.Currency {
+		return errorf(ErrValue, "can't %s %s and %s amounts", op, m.Currency, n.Currency)
+	}
+	return nil
+}
+
+// Add returns m + n, both amounts should be in the same currency.
+func (m Money) Add(n Money) (Money, error) {
+	if err := m.same(n, "add"); err != nil {
+		return Money{}, err
+	}
+	return Money{Amount: m.Amount.Add(n.Amount), Currency: m.Currency}, nil
+}
+
+// Sub returns m - n, both amounts should be in the same currency.
+func (m Money) Sub(n Money) (Money, error) {
+	if err := m.same(n, "subtract"); err != nil {
+		return Money{}, err
+	}
+	return Money{Amount: m.Amount.Sub(n.Amount), Currency: m.Currency}, nil
+}
+
+// Cmp compares amounts in the same currency.
+func (m Money) Cmp(n Money) (int, error) {
+	if err := m.same(n, "compare"); err != nil {
+		return 0, err
+	}
+	return m.Amount.Cmp(n.Amount), nil
+}
+
+// Mul returns m scaled by k.
+func (m Money) Mul(k Decimal) Money {
+	return Money{Amount: m.Amount.Mul(k), Currency: m.Currency}
+}
+
+// Quo returns m divided by k and rounded with ctx.
+func (m Money) Quo(k Decimal, ctx DecimalContext) (Money, error) {
+	amount, err := m.Amount.Quo(k, ctx)
+	if err != nil {
+		return Money{}, err
+	}
+	return Money{Amount: amount, Currency: m.Currency}, nil
+}
+
+// Neg returns -m.
+func (m Money) Neg() Money {
+	return Money{Amount: m.Amount.Neg(), Currency: m.Currency}
+}
+
+// ExchangeRates is a hook converting money between currencies.
+type ExchangeRates interface {
+	// Rate returns the price of one unit of currency from in currency to.
+	Rate(from, to string) (Decimal, error)
+}
+
+// RateTable is ExchangeRates given by a table: RateTable["EUR"]["USD"] is
+// the price of one euro in dollars. Conversion uses only direct rates,
+// so both directions should be present if needed.
+type RateTable map[string]map[string]Decimal
+
+func (t RateTable) Rate(from, to string) (Decimal, error) {
+	rate, ok := t[from][to]
+	if !ok {
+		return Decimal{}, errorf(ErrNA, "no exchange rate from %s to %s", from, to)
+	}
+	return rate, nil
+}
+
+// money returns arg as Money.
+func money(arg any) (Money, error) {
+	m, ok := arg.(Money)
+	if !ok {
+		return Money{}, errorf(ErrValue, "expected money, got %T", arg)
+	}
+	return m, nil
+}
+
+// MoneyOf returns amount of money in a currency: MONEY(10; "USD").
+func MoneyOf(args ...any) (any, error) {
+	if err := checkArgs(args, 2, 2); err != nil {
+		return nil, err
+	}
+	amount, err := decimal(args[0])
+	if err != nil {
+		return nil, err
+	}
+	currency, err := text(args[1])
+	if err != nil {
+		return nil, err
+	}
+	m, err := NewMoney(amount, currency)
+	if err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// Amount returns amount of money without currency.
+func Amount(args ...any) (any, error) {
+	if err := checkArgs(args, 1, 1); err != nil {
+		return nil, err
+	}
+	m, err := money(args[0])
+	if err != nil {
+		return nil, err
+	}
+	return m.Amount, nil
+}
+
+// Currency returns currency code of money.
+func Currency(args ...any) (any, error) {
+	if err := checkArgs(args, 1, 1); err != nil {
+		return nil, err
+	}
+	m, err := money(args[0])
+	if err != nil {
+		return nil, err
+	}
+	return m.Currency, nil
+}
+
+// hasMoney reports whether args or their arrays contain money.
+func hasMoney(args []any) bool {
+	for _, arg := range args {
+		switch val := arg.(type) {
+		case Money:
+			return true
+		case []any:
+			if hasMoney(val) {
+				return true
+			}
+		case Table:
+			for _, row := range val {
+				if hasMoney(row) {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// appendMoney collects money from scalars, arrays and tables, Blank values are skipped.
+func appendMoney(amounts []Money, arg any) ([]Money, error) {
+	switch val := arg.(type) {
+	case Money:
+		return append(amounts, val), nil
+	case blank:
+		return amounts, nil
+	case []any:
+		for _, el := range val {
+			var err error
+			amounts, err = appendMoney(amounts, el)
+			if err != nil {
+				return nil, err
+			}
+		}
+		return amounts, nil
+	case Table:
+		for _, row := range val {
+			var err error
+			amounts, err = appendMoney(amounts, row)
+			if err != nil {
+				return nil, err
+			}
+		}
+		return amounts, nil
+	default:
+		return nil, errorf(ErrValue, "can't add %T to money", arg)
+	}
+}
+
+// sumMoney adds amounts of money in the same currency.
+func sumMoney(args []any) (any, error) {
+	var amounts []Money
+	for _, arg := range args {
+		var err error
+		amounts, err = appendMoney(amounts, arg)
+		if err != nil {
+			return nil, err
+		}
+	}
+	res := Money{Currency: amounts[0].Currency}
+	for _, m := range amounts {
+		var err error
+		res, err = res.Add(m)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
+// exchange converts money with caller supplied exchange rates.
+type exchange struct {
+	rates ExchangeRates
+}
+
+// Exchange converts money to another currency: EXCHANGE(Price; "EUR").
+func (e *exchange) Exchange(args ...any) (any, error) {
+	if err := checkArgs(args, 2, 2); err != nil {
+		return nil, err
+	}
+	m, err := money(args[0])
+	if err != nil {
+		return nil, err
+	}
+	currency, err := text(args[1])
+	if err != nil {
+		return nil, err
+	}
+	target, err := NewMoney(Decimal{}, currency)
+	if err != nil {
+		return nil, err
+	}
+	if target.Currency == m.Currency {
+		return m, nil
+	}
+	if e.rates == nil {
+		return nil, errorf(ErrNA, "no exchange rates")
+	}
+	rate, err := e.rates.Rate(m.Currency, target.Currency)
+	if err != nil {
+		return nil, err
+	}
+	target.Amount = m.Amount.Mul(rate)
+	return target, nil
+}
+
+var moneyDefinitions = []Definition{
+	{
+		Name:        "MONEY",
+		Func:        MoneyOf,
+		Signature:   Signatures["MoneyOf"],
+		Params:      []string{"amount", "currency"},
+		Description: "Returns an amount of money in a currency with ISO 4217 code.",
+		Examples:    []string{`MONEY(10; "USD") * 3`},
+		Category:    CategoryFinancial,
+		Pure:        true,
+	},
+	{
+		Name:        "AMOUNT",
+		Func:        Amount,
+		Signature:   Signatures["Amount"],
+		Params:      []string{"money"},
+		Description: "Returns the amount of money without currency.",
+		Examples:    []string{`AMOUNT(MONEY(10; "USD")) = 10`},
+		Category:    CategoryFinancial,
+		Pure:        true,
+	},
+	{
+		Name:        "CURRENCY",
+		Func:        Currency,
+		Signature:   Signatures["Currency"],
+		Params:      []string{"money"},
+		Description: "Returns the currency code of money.",
+		Examples:    []string{`CURRENCY(MONEY(10; "usd")) = "USD"`},
+		Category:    CategoryFinancial,
+		Pure:        true,
+	},
+}
+
+func (e *exchange) definitions() []Definition {
+	return []Definition{
+		{
+			Name:        "EXCHANGE",
+			Func:        e.Exchange,
+			Signature:   Signatures["Exchange"],
+			Params:      []string{"money", "currency"},
+			Description: "Converts money to another currency using configured exchange rates.",
+			Examples:    []string{`EXCHANGE(MONEY(10; "USD"); "EUR")`},
+			Category:    CategoryFinancial,
+			// rates supplied by the caller may change
+			Pure: false,
+		},
+	}
+}
@@ -0,0 +1,90 @@
+package functions
+
+import (
+	"errors"
+	"testing"
+)
+
+func usd(amount string) Money {
+	return Money{Amount: MustDecimal(amount), Currency: "USD"}
+}
+
+func TestNewMoney(t *testing.T) {
+	m, err := NewMoney(MustDecimal("10.50"), "usd")
+	if err != nil {
+		t.Fatalf("expected nil error, got %s", err)
+	}
+	if m.String() != "10.50 USD" {
+		t.Errorf("expected 10.50 USD, got %s", m)
+	}
+	for _, code := range []string{"", "US", "USDT", "U$D"} {
+		if _, err := NewMoney(Decimal{}, code); !errors.Is(err, ErrValue) {
+			t.Errorf("%q: expected #VALUE!, got %v", code, err)
+		}
+	}
+}
+
+func TestMoney_Arithmetic(t *testing.T) {
+	sum, err := usd("0.10").Add(usd("0.20"))
+	if err != nil || sum.String() != "0.30 USD" {
+		t.Errorf("expected 0.30 USD, got %s (%v)", sum, err)
+	}
+	if res := usd("19.99").Mul(MustDecimal("3")); res.String() != "59.97 USD" {
+		t.Errorf("expected 59.97 USD, got %s", res)
+	}
+	res, err := usd("10").Quo(MustDecimal("3"), DecimalContext{Scale: 2})
+	if err != nil || res.String() != "3.33 USD" {
+		t.Errorf("expected 3.33 USD, got %s (%v)", res, err)
+	}
+	eur := Money{Amount: MustDecimal("1"), Currency: "EUR"}
+	if _, err := usd("1").Add(eur); !errors.Is(err, ErrValue) {
+		t.Errorf("expected #VALUE! adding USD and EUR, got %v", err)
+	}
+	if _, err := usd("1").Cmp(eur); !errors.Is(err, ErrValue) {
+		t.Errorf("expected #VALUE! comparing USD and EUR, got %v", err)
+	}
+}
+
+func TestMoney_Functions(t *testing.T) {
+	res, err := MoneyOf(10., "usd")
+	if err != nil || res.(Money).String() != "10 USD" {
+		t.Errorf("MONEY: expected 10 USD, got %v (%v)", res, err)
+	}
+	res, err = Sum(usd("1.10"), []any{usd("2.20"), Blank})
+	if err != nil || res.(Money).String() != "3.30 USD" {
+		t.Errorf("SUM: expected 3.30 USD, got %v (%v)", res, err)
+	}
+	if _, err := Sum(usd("1"), Money{Amount: MustDecimal("1"), Currency: "EUR"}); !errors.Is(err, ErrValue) {
+		t.Errorf("SUM: expected #VALUE! for mixed currencies, got %v", err)
+	}
+	if _, err := Sum(usd("1"), 1.); !errors.Is(err, ErrValue) {
+		t.Errorf("SUM: expected #VALUE! for money and number, got %v", err)
+	}
+	res, err = Round(usd("2.675"), 2.)
+	if err != nil || res.(Money).String() != "2.68 USD" {
+		t.Errorf("ROUND: expected 2.68 USD, got %v (%v)", res, err)
+	}
+	if res, err := Currency(usd("1")); err != nil || res != "USD" {
+		t.Errorf("CURRENCY: expected USD, got %v (%v)", res, err)
+	}
+	if res, err := Amount(usd("1.5")); err != nil || res.(Decimal).String() != "1.5" {
+		t.Errorf("AMOUNT: expected 1.5, got %v (%v)", res, err)
+	}
+}
+
+func TestExchange(t *testing.T) {
+	e := &exchange{rates: RateTable{"USD": {"EUR": MustDecimal("0.92")}}}
+	res, err := e.Exchange(usd("100"), "eur")
+	if err != nil || res.(Money).String() != "92.00 EUR" {
+		t.Errorf("expected 92.00 EUR, got %v (%v)", res, err)
+	}
+	if res, err := e.Exchange(usd("100"), "USD"); err != nil || res.(Money).String() != "100 USD" {
+		t.Errorf("expected 100 USD, got %v (%v)", res, err)
+	}
+	if _, err := e.Exchange(usd("100"), "GBP"); !errors.Is(err, ErrNA) {
+		t.Errorf("expected #N/A for unknown rate, got %v", err)
+	}
+	if _, err := (&exchange{}).Exchange(usd("100"), "EUR"); !errors.Is(err, ErrNA) {
+		t.Errorf("expected #N/A without rates, got %v", err)
+	}
+}
@@ -0,0 +1,227 @@
+package functions
+
+import (
+	"math"
+	"strconv"
+)
+
+// Quantity is a number in a unit of measure. Quantities of different
+// dimensions can't be added or compared.
+type Quantity struct {
+	Value float64
+	Unit  Unit
+}
+
+// String returns value followed by unit symbol, like "10 km".
+func (q Quantity) String() string {
+	return strconv.FormatFloat(q.Value, 'g', -1, 64) + " " + q.Unit.Symbol
+}
+
+// Convert returns quantity in another unit of the same dimension.
+func (q Quantity) Convert(to Unit) (Quantity, error) {
+	if !q.Unit.Compatible(to) {
+		return Quantity{}, errorf(ErrNA, "can't convert %s to %s", q.Unit.Symbol, to.Symbol)
+	}
+	if q.Unit == to {
+		return q, nil
+	}
+	if q.Unit.Offset == 0 && to.Offset == 0 {
+		return Quantity{Value: significant(q.Value * q.Unit.Factor / to.Factor), Unit: to}, nil
+	}
+	// offsets of temperature scales cancel out, so the result is rounded to
+	// 15 significant digits of the largest term: 10 °C is 50 °F, not 49.9999999999999
+	kelvins := q.Value*q.Unit.Factor + q.Unit.Offset
+	scale := math.Max(math.Abs(kelvins), math.Abs(to.Offset)) / to.Factor
+	value := (kelvins - to.Offset) / to.Factor
+	if scale == 0 || math.IsInf(scale, 0) || math.IsNaN(scale) {
+		return Quantity{Value: value, Unit: to}, nil
+	}
+	return Quantity{Value: roundDigits(value, 14-math.Floor(math.Log10(scale)), math.Round), Unit: to}, nil
+}
+
+// Add returns q + r in unit of q.
+func (q Quantity) Add(r Quantity) (Quantity, error) {
+	r, err := q.same(r, "add")
+	if err != nil {
+		return Quantity{}, err
+	}
+	return Quantity{Value: q.Value + r.Value, Unit: q.Unit}, nil
+}
+
+// Sub returns q - r in unit of q.
+func (q Quantity) Sub(r Quantity) (Quantity, error) {
+	r, err := q.same(r, "subtract")
+	if err != nil {
+		return Quantity{}, err
+	}
+	return Quantity{Value: q.Value - r.Value, Unit: q.Unit}, nil
+}
+
+// Cmp compares quantities of the same dimension.
+func (q Quantity) Cmp(r Quantity) (int, error) {
+	r, err := q.same(r, "compare")
+	if err != nil {
+		return 0, err
+	}
+	switch {
+	case q.Value < r.Value:
+		return -1, nil
+	case q.Value > r.Value:
+		return 1, nil
+	}
+	return 0, nil
+}
+
+// same converts r to unit of q.
+func (q Quantity) same(r Quantity, op string) (Quantity, error) {
+	if !q.Unit.Compatible(r.Unit) {
+		return Quantity{}, errorf(ErrValue, "can't %s %s and %s: dimensions differ", op, q.Unit.Symbol, r.Unit.Symbol)
+	}
+	if q.Unit.Offset != 0 || r.Unit.Offset != 0 {
+		// differences of temperatures on scales with offset are converted without offset
+		return Quantity{Value: r.Value * r.Unit.Factor / q.Unit.Factor, Unit: q.Unit}, nil
+	}
+	return r.Convert(q.Unit)
+}
+
+// Mul returns product of quantities. Dimensionless product is returned as float64.
+func (q Quantity) Mul(r Quantity) (any, error) {
+	unit, err := q.Unit.Mul(r.Unit)
+	if err != nil {
+		return nil, err
+	}
+	return quantity(q.Value*r.Value, unit), nil
+}
+
+// Div returns quotient of quantities. Dimensionless quotient is returned as float64.
+func (q Quantity) Div(r Quantity) (any, error) {
+	if r.Value == 0 {
+		return nil, errorf(ErrDiv0, "division by zero %s", r.Unit.Symbol)
+	}
+	unit, err := q.Unit.Div(r.Unit)
+	if err != nil {
+		return nil, err
+	}
+	return quantity(q.Value/r.Value, unit), nil
+}
+
+// Pow returns quantity raised to integer power n.
+func (q Quantity) Pow(n int) (any, error) {
+	unit, err := q.Unit.Pow(n)
+	if err != nil {
+		return nil, err
+	}
+	res := 1.
+	for i := 0; i < abs(n); i++ {
+		res *= q.Value
+	}
+	if n < 0 {
+		res = 1 / res
+	}
+	return quantity(res, unit), nil
+}
+
+// Scale returns quantity multiplied by k.
+func (q Quantity) Scale(k float64) Quantity {
+	return Quantity{Value: q.Value * k, Unit: q.Unit}
+}
+
+// quantity returns value in unit or plain number for dimensionless units.
+func quantity(value float64, unit Unit) any {
+	if unit.Dim.IsZero() {
+		return significant(value * unit.Factor)
+	}
+	return Quantity{Value: value, Unit: unit}
+}
+
+// units implements functions using a table of units.
+type units struct {
+	table *Units
+}
+
+// Quantity returns number in a unit: QUANTITY(10; "km").
+func (u *units) Quantity(args ...any) (any, error) {
+	if err := checkArgs(args, 2, 2); err != nil {
+		return nil, err
+	}
+	value, err := number(args[0])
+	if err != nil {
+		return nil, err
+	}
+	symbol, err := text(args[1])
+	if err != nil {
+		return nil, err
+	}
+	unit, err := u.table.Parse(symbol)
+	if err != nil {
+		return nil, err
+	}
+	return Quantity{Value: value, Unit: unit}, nil
+}
+
+// Convert converts number from one unit to another: CONVERT(10; "km"; "mi").
+// Quantity is converted to another unit with CONVERT(Distance; "mi").
+func (u *units) Convert(args ...any) (any, error) {
+	if err := checkArgs(args, 2, 3); err != nil {
+		return nil, err
+	}
+	symbols, err := textArgs(args[1:])
+	if err != nil {
+		return nil, err
+	}
+	to, err := u.table.Parse(symbols[len(symbols)-1])
+	if err != nil {
+		return nil, err
+	}
+	if q, ok := args[0].(Quantity); ok {
+		if len(args) == 3 {
+			return nil, errorf(ErrValue, "unit of quantity %s is already known", q)
+		}
+		converted, err := q.Convert(to)
+		if err != nil {
+			return nil, err
+		}
+		return converted, nil
+	}
+	if len(args) == 2 {
+		return nil, errorf(ErrValue, "expected quantity or source unit")
+	}
+	value, err := number(args[0])
+	if err != nil {
+		return nil, err
+	}
+	from, err := u.table.Parse(symbols[0])
+	if err != nil {
+		return nil, err
+	}
+	res, err := Quantity{Value: value, Unit: from}.Convert(to)
+	if err != nil {
+		return nil, err
+	}
+	return res.Value, nil
+}
+
+func (u *units) definitions() []Definition {
+	return []Definition{
+		{
+			Name:        "QUANTITY",
+			Func:        u.Quantity,
+			Signature:   Signatures["Quantity"],
+			Params:      []string{"number", "unit"},
+			Description: "Returns a number in a unit of measure.",
+			Examples:    []string{`QUANTITY(100; "km") / QUANTITY(2; "hr")`},
+			Category:    CategoryEngineering,
+			Pure:        true,
+		},
+		{
+			Name:        "CONVERT",
+			Func:        u.Convert,
+			Signature:   Signatures["Convert"],
+			Params:      []string{"number", "from_unit", "to_unit"},
+			Description: "Converts a number or a quantity from one unit of measure to another.",
+			Examples:    []string{`CONVERT(10; "km"; "mi")`, `CONVERT(QUANTITY(1; "m/s"); "km/h")`},
+			Category:    CategoryEngineering,
+			Pure:        true,
+		},
+	}
+}
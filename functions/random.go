@@ -0,0 +1,147 @@
+package functions
+
+import (
+	"math"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// maxRandArray is the maximum number of elements of RANDARRAY result.
+const maxRandArray = 1 << 20
+
+// random implements random functions with a shared source.
+type random struct {
+	mu  sync.Mutex
+	rnd *rand.Rand
+}
+
+func newRandom(source rand.Source) *random {
+	if source == nil {
+		source = rand.NewSource(time.Now().UnixNano())
+	}
+	return &random{rnd: rand.New(source)}
+}
+
+func (r *random) float() float64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.rnd.Float64()
+}
+
+// between returns random integer from min to max inclusive.
+func (r *random) between(min, max float64) float64 {
+	return min + math.Floor(r.float()*(max-min+1))
+}
+
+// Rand returns random number from 0 inclusive to 1 exclusive.
+func (r *random) Rand(args ...any) (any, error) {
+	if err := checkArgs(args, 0, 0); err != nil {
+		return nil, err
+	}
+	return r.float(), nil
+}
+
+// Randbetween returns random integer from bottom to top inclusive.
+func (r *random) Randbetween(args ...any) (any, error) {
+	bottom, top, err := twoNumbers(args)
+	if err != nil {
+		return nil, err
+	}
+	bottom, top = math.Ceil(bottom), math.Floor(top)
+	if bottom > top {
+		return nil, errorf(ErrNum, "bottom %v is greater than top %v", bottom, top)
+	}
+	return r.between(bottom, top), nil
+}
+
+// Randarray returns array of random numbers. Arguments are number of rows and
+// columns (1 by default), minimum and maximum (0 and 1 by default) and whether
+// numbers should be integers (FALSE by default). A single column is returned
+// as []float64, several columns as Table.
+func (r *random) Randarray(args ...any) (any, error) {
+	if err := checkArgs(args, 0, 5); err != nil {
+		return nil, err
+	}
+	params := make([]float64, 4)
+	for i, def := range []float64{1, 1, 0, 1} {
+		val, err := optionalNumber(args, i, def)
+		if err != nil {
+			return nil, err
+		}
+		params[i] = val
+	}
+	rows, cols, min, max := math.Trunc(params[0]), math.Trunc(params[1]), params[2], params[3]
+	integer := false
+	if len(args) == 5 {
+		var err error
+		if integer, err = boolean(args[4]); err != nil {
+			return nil, err
+		}
+	}
+	switch {
+	case !(rows >= 1 && cols >= 1):
+		return nil, errorf(ErrValue, "expected positive number of rows and columns, got %v and %v", rows, cols)
+	case rows*cols > maxRandArray:
+		return nil, errorf(ErrValue, "array is larger than %d elements", maxRandArray)
+	case math.IsNaN(min) || math.IsNaN(max) || math.IsInf(min, 0) || math.IsInf(max, 0):
+		return nil, errorf(ErrNum, "expected finite minimum and maximum, got %v and %v", min, max)
+	case min > max:
+		return nil, errorf(ErrValue, "minimum %v is greater than maximum %v", min, max)
+	case integer && (min != math.Trunc(min) || max != math.Trunc(max)):
+		return nil, errorf(ErrValue, "expected integer minimum and maximum, got %v and %v", min, max)
+	}
+	next := func() float64 {
+		if integer {
+			return r.between(min, max)
+		}
+		return min + r.float()*(max-min)
+	}
+	if cols == 1 {
+		values := make([]float64, int(rows))
+		for i := range values {
+			values[i] = next()
+		}
+		return values, nil
+	}
+	table := make(Table, int(rows))
+	for i := range table {
+		table[i] = make([]any, int(cols))
+		for j := range table[i] {
+			table[i][j] = next()
+		}
+	}
+	return table, nil
+}
+
+func (r *random) definitions() []Definition {
+	return []Definition{
+		{
+			Name:        "RAND",
+			Func:        r.Rand,
+			Signature:   Signatures["Rand"],
+			Params:      []string{},
+			Description: "Returns a random number from 0 inclusive to 1 exclusive.",
+			Examples:    []string{"RAND() * 100"},
+			Category:    CategoryMath,
+		},
+		{
+			Name:        "RANDBETWEEN",
+			Func:        r.Randbetween,
+			Signature:   Signatures["Randbetween"],
+			Params:      []string{"bottom", "top"},
+			Description: "Returns a random integer between two numbers inclusive.",
+			Examples:    []string{"RANDBETWEEN(1; 6)"},
+			Category:    CategoryMath,
+		},
+		{
+			Name:        "RANDARRAY",
+			Func:        r.Randarray,
+			Signature:   Signatures["Randarray"],
+			Params:      []string{"rows", "columns", "min", "max", "integer"},
+			Description: "Returns an array of random numbers.",
+			Examples:    []string{"AVERAGE(RANDARRAY(1000))", "RANDARRAY(3; 2; 1; 6; 1=1)"},
+			Category:    CategoryMath,
+		},
+	}
+}
@@ -0,0 +1,78 @@
+package functions
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func TestRandom(t *testing.T) {
+	r := newRandom(rand.NewSource(1))
+	for i := 0; i < 1000; i++ {
+		res, err := r.Rand()
+		if err != nil {
+			t.Fatalf("expected nil error, got %s", err)
+		}
+		if val := res.(float64); val < 0 || val >= 1 {
+			t.Fatalf("expected number in [0; 1), got %v", val)
+		}
+		res, err = r.Randbetween(-2.5, 3.)
+		if err != nil {
+			t.Fatalf("expected nil error, got %s", err)
+		}
+		if val := res.(float64); val < -2 || val > 3 || val != float64(int(val)) {
+			t.Fatalf("expected integer in [-2; 3], got %v", val)
+		}
+	}
+
+	res, err := r.Randarray(3., 2., 1., 6., true)
+	if err != nil {
+		t.Fatalf("expected nil error, got %s", err)
+	}
+	table, ok := res.(Table)
+	if !ok || table.Rows() != 3 || table.Cols() != 2 {
+		t.Fatalf("expected 3x2 table, got %v", res)
+	}
+	res, err = r.Randarray(4.)
+	if err != nil {
+		t.Fatalf("expected nil error, got %s", err)
+	}
+	if values, ok := res.([]float64); !ok || len(values) != 4 {
+		t.Errorf("expected 4 numbers, got %v", res)
+	}
+}
+
+func TestRandom_Seed(t *testing.T) {
+	a, b := newRandom(rand.NewSource(42)), newRandom(rand.NewSource(42))
+	x, _ := a.Randarray(5., 1., 0., 100.)
+	y, _ := b.Randarray(5., 1., 0., 100.)
+	if !reflect.DeepEqual(x, y) {
+		t.Errorf("expected equal results of equally seeded sources, got %v and %v", x, y)
+	}
+}
+
+func TestRandom_Errors(t *testing.T) {
+	r := newRandom(rand.NewSource(1))
+	if _, err := r.Randbetween(3., 2.5); !errors.Is(err, ErrNum) {
+		t.Errorf("expected #NUM!, got %v", err)
+	}
+	if _, err := r.Randarray(0.); !errors.Is(err, ErrValue) {
+		t.Errorf("expected #VALUE!, got %v", err)
+	}
+	if _, err := r.Randarray(2., 2., 5., 1.); !errors.Is(err, ErrValue) {
+		t.Errorf("expected #VALUE!, got %v", err)
+	}
+	if _, err := r.Randarray(1e6, 1e6); !errors.Is(err, ErrValue) {
+		t.Errorf("expected #VALUE!, got %v", err)
+	}
+	for _, args := range [][]any{{math.NaN()}, {1., math.NaN()}, {math.Inf(1)}, {1e300, 1e300}} {
+		if _, err := r.Randarray(args...); !errors.Is(err, ErrValue) {
+			t.Errorf("%v: expected #VALUE!, got %v", args, err)
+		}
+	}
+	if _, err := r.Randarray(2., 2., math.NaN(), 1.); !errors.Is(err, ErrNum) {
+		t.Errorf("expected #NUM!, got %v", err)
+	}
+}
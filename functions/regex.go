@@ -0,0 +1,178 @@
+package functions
+
+import (
+	"container/list"
+	"regexp"
+	"sync"
+)
+
+const (
+	defaultRegexCacheSize = 64
+	defaultMaxPattern     = 1024
+	defaultMaxInput       = 1 << 20
+)
+
+// regexps implements regular expression functions with a cache of compiled patterns.
+type regexps struct {
+	config Regex
+
+	mu    sync.Mutex
+	cache map[string]*list.Element // values of elements are *cachedRegexp
+	order *list.List               // the most recently used pattern is at the front
+}
+
+type cachedRegexp struct {
+	pattern string
+	re      *regexp.Regexp
+}
+
+func newRegexps(config Regex) *regexps {
+	if config.CacheSize <= 0 {
+		config.CacheSize = defaultRegexCacheSize
+	}
+	if config.MaxPattern <= 0 {
+		config.MaxPattern = defaultMaxPattern
+	}
+	if config.MaxInput <= 0 {
+		config.MaxInput = defaultMaxInput
+	}
+	return &regexps{
+		config: config,
+		cache:  map[string]*list.Element{},
+		order:  list.New(),
+	}
+}
+
+// compile returns compiled pattern from the cache or compiles it.
+// The least recently used pattern is evicted when the cache is full.
+func (r *regexps) compile(pattern string) (*regexp.Regexp, error) {
+	if len(pattern) > r.config.MaxPattern {
+		return nil, errorf(ErrValue, "pattern is longer than %d bytes", r.config.MaxPattern)
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if el, ok := r.cache[pattern]; ok {
+		r.order.MoveToFront(el)
+		return el.Value.(*cachedRegexp).re, nil
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, errorf(ErrValue, "invalid pattern: %s", err)
+	}
+	r.cache[pattern] = r.order.PushFront(&cachedRegexp{pattern: pattern, re: re})
+	if r.order.Len() > r.config.CacheSize {
+		oldest := r.order.Back()
+		r.order.Remove(oldest)
+		delete(r.cache, oldest.Value.(*cachedRegexp).pattern)
+	}
+	return re, nil
+}
+
+// input checks length of text.
+func (r *regexps) input(s string) error {
+	if len(s) > r.config.MaxInput {
+		return errorf(ErrValue, "text is longer than %d bytes", r.config.MaxInput)
+	}
+	return nil
+}
+
+// args returns text and compiled pattern of the first two arguments.
+func (r *regexps) args(args []any, min, max int) ([]string, *regexp.Regexp, error) {
+	if err := checkArgs(args, min, max); err != nil {
+		return nil, nil, err
+	}
+	strs, err := textArgs(args)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := r.input(strs[0]); err != nil {
+		return nil, nil, err
+	}
+	re, err := r.compile(strs[1])
+	if err != nil {
+		return nil, nil, err
+	}
+	return strs, re, nil
+}
+
+// Regexmatch reports whether text contains a match of the pattern.
+func (r *regexps) Regexmatch(args ...any) (any, error) {
+	strs, re, err := r.args(args, 2, 2)
+	if err != nil {
+		return nil, err
+	}
+	return re.MatchString(strs[0]), nil
+}
+
+// Regexextract returns the first match of the pattern in text. If the pattern
+// has one capturing group, the group is returned, several groups are returned
+// as an array.
+func (r *regexps) Regexextract(args ...any) (any, error) {
+	strs, re, err := r.args(args, 2, 2)
+	if err != nil {
+		return nil, err
+	}
+	match := re.FindStringSubmatch(strs[0])
+	switch {
+	case match == nil:
+		return nil, errorf(ErrNA, "no match of '%s'", strs[1])
+	case len(match) == 1:
+		return match[0], nil
+	case len(match) == 2:
+		return match[1], nil
+	}
+	groups := make([]any, len(match)-1)
+	for i, group := range match[1:] {
+		groups[i] = group
+	}
+	return groups, nil
+}
+
+// Regexreplace replaces all matches of the pattern in text. Replacement may
+// refer to capturing groups as $1 or ${name}.
+func (r *regexps) Regexreplace(args ...any) (any, error) {
+	strs, re, err := r.args(args, 3, 3)
+	if err != nil {
+		return nil, err
+	}
+	res := re.ReplaceAllString(strs[0], strs[2])
+	if err := r.input(res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (r *regexps) definitions() []Definition {
+	return []Definition{
+		{
+			Name:        "REGEXMATCH",
+			Func:        r.Regexmatch,
+			Signature:   Signatures["Regexmatch"],
+			Params:      []string{"text", "pattern"},
+			Description: "Returns TRUE if text contains a match of the regular expression.",
+			Examples:    []string{`REGEXMATCH(Code; "^[A-Z]{3}-\d{4}$")`},
+			Category:    CategoryText,
+			Pure:        true,
+		},
+		{
+			Name:        "REGEXEXTRACT",
+			Func:        r.Regexextract,
+			Signature:   Signatures["Regexextract"],
+			Params:      []string{"text", "pattern"},
+			Description: "Returns the first match of the regular expression or its capturing groups.",
+			Examples:    []string{`REGEXEXTRACT("Order #123"; "\d+") = "123"`},
+			Category:    CategoryText,
+			Pure:        true,
+		},
+		{
+			Name:        "REGEXREPLACE",
+			Func:        r.Regexreplace,
+			Signature:   Signatures["Regexreplace"],
+			Params:      []string{"text", "pattern", "replacement"},
+			Description: "Replaces all matches of the regular expression.",
+			Examples:    []string{`REGEXREPLACE("2024-03-15"; "(\d+)-(\d+)-(\d+)"; "$3.$2.$1") = "15.03.2024"`},
+			Category:    CategoryText,
+			Pure:        true,
+		},
+	}
+}
@@ -0,0 +1,70 @@
+package functions
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestRegex(t *testing.T) {
+	r := newRegexps(Regex{})
+	cases := []struct {
+		name     string
+		fn       func(args ...any) (any, error)
+		args     []any
+		expected any
+	}{
+		{"Regexmatch", r.Regexmatch, []any{"ABC-1234", `^[A-Z]{3}-\d{4}$`}, true},
+		{"Regexmatch", r.Regexmatch, []any{"ABC-12", `^[A-Z]{3}-\d{4}$`}, false},
+		{"Regexmatch", r.Regexmatch, []any{12345., `^\d+$`}, true},
+		{"Regexextract", r.Regexextract, []any{"Order #123, #456", `#\d+`}, "#123"},
+		{"Regexextract", r.Regexextract, []any{"Order #123", `#(\d+)`}, "123"},
+		{"Regexextract", r.Regexextract, []any{"John Smith", `(\w+) (\w+)`}, []any{"John", "Smith"}},
+		{"Regexreplace", r.Regexreplace, []any{"2024-03-15", `(\d+)-(\d+)-(\d+)`, "$3.$2.$1"}, "15.03.2024"},
+		{"Regexreplace", r.Regexreplace, []any{"a  b   c", `\s+`, " "}, "a b c"},
+	}
+	for _, c := range cases {
+		res, err := c.fn(c.args...)
+		if err != nil {
+			t.Fatalf("%s%v: expected nil error, got %s", c.name, c.args, err)
+		}
+		if !reflect.DeepEqual(res, c.expected) {
+			t.Errorf("%s%v: expected %v, got %v", c.name, c.args, c.expected, res)
+		}
+	}
+}
+
+func TestRegex_Limits(t *testing.T) {
+	r := newRegexps(Regex{CacheSize: 2, MaxPattern: 8, MaxInput: 16})
+	if _, err := r.Regexextract("abc", `\d`); !errors.Is(err, ErrNA) {
+		t.Errorf("expected #N/A, got %v", err)
+	}
+	if _, err := r.Regexmatch("abc", `(`); !errors.Is(err, ErrValue) {
+		t.Errorf("expected #VALUE! for invalid pattern, got %v", err)
+	}
+	if _, err := r.Regexmatch("abc", `[a-z]{1,100}`); !errors.Is(err, ErrValue) {
+		t.Errorf("expected #VALUE! for long pattern, got %v", err)
+	}
+	if _, err := r.Regexmatch(strings.Repeat("a", 17), `a`); !errors.Is(err, ErrValue) {
+		t.Errorf("expected #VALUE! for long text, got %v", err)
+	}
+	if _, err := r.Regexreplace("aaaa", `a`, "bbbbb"); !errors.Is(err, ErrValue) {
+		t.Errorf("expected #VALUE! for long result, got %v", err)
+	}
+
+	for _, pattern := range []string{"a", "b", "a", "c"} {
+		if _, err := r.compile(pattern); err != nil {
+			t.Fatalf("expected nil error, got %s", err)
+		}
+	}
+	if r.order.Len() != 2 {
+		t.Errorf("expected 2 cached patterns, got %d", r.order.Len())
+	}
+	if _, ok := r.cache["b"]; ok {
+		t.Errorf("expected the least recently used pattern to be evicted")
+	}
+	if _, ok := r.cache["a"]; !ok {
+		t.Errorf("expected recently used pattern to be cached")
+	}
+}
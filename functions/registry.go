@@ -0,0 +1,249 @@
+package functions
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Function categories of built-in functions.
+const (
+	CategoryMath        = "math"
+	CategoryLogical     = "logical"
+	CategoryStatistical = "statistical"
+	CategoryText        = "text"
+	CategoryDate        = "date"
+	CategoryLookup      = "lookup"
+	CategoryFinancial   = "financial"
+	CategoryInfo        = "information"
+	CategoryEngineering = "engineering"
+)
+
+// A Definition is a function with metadata used for validation,
+// documentation and autocompletion.
+type Definition struct {
+	Name        string
+	Func        func(args ...any) (any, error)
+	Signature   Signature
+	Params      []string // names of Params, Optional and Variadic parameters in order
+	Description string
+	Examples    []string
+	Category    string
+	Pure        bool // result depends only on arguments
+	CatchErrors bool // failed arguments are passed to Func as error values instead of failing the call
+}
+
+// MinArgs returns minimum number of arguments.
+func (d *Definition) MinArgs() int {
+	return len(d.Signature.Params)
+}
+
+// MaxArgs returns maximum number of arguments, -1 means unlimited.
+func (d *Definition) MaxArgs() int {
+	if len(d.Signature.Variadic) != 0 {
+		return -1
+	}
+	return len(d.Signature.Params) + len(d.Signature.Optional)
+}
+
+// Usage returns call syntax, i.e. Round(number; [digits]).
+func (d *Definition) Usage() string {
+	s := d.Signature
+	params := make([]string, 0, len(d.Params)+1)
+	for i, name := range d.Params {
+		switch {
+		case i < len(s.Params):
+			params = append(params, name)
+		default:
+			params = append(params, "["+name+"]")
+		}
+	}
+	if len(s.Variadic) != 0 {
+		params = append(params, "...")
+	}
+	return d.Name + "(" + strings.Join(params, "; ") + ")"
+}
+
+// Help returns documentation of the function in plain text.
+func (d *Definition) Help() string {
+	var b strings.Builder
+	b.WriteString(d.Usage())
+	b.WriteString("\n")
+	if d.Description != "" {
+		b.WriteString("\n")
+		b.WriteString(d.Description)
+		b.WriteString("\n")
+	}
+	if len(d.Examples) != 0 {
+		b.WriteString("\nExamples:\n")
+		for _, example := range d.Examples {
+			b.WriteString("  ")
+			b.WriteString(example)
+			b.WriteString("\n")
+		}
+	}
+	return b.String()
+}
+
+// A Registry is a set of function definitions.
+type Registry struct {
+	definitions map[string]*Definition
+	aliases     map[string]string
+}
+
+func NewRegistry() *Registry {
+	return &Registry{
+		definitions: map[string]*Definition{},
+		aliases:     map[string]string{},
+	}
+}
+
+// Register adds definition to the registry.
+func (r *Registry) Register(def Definition) error {
+	if def.Name == "" {
+		return fmt.Errorf("function name is empty")
+	}
+	if def.Func == nil {
+		return fmt.Errorf("function '%s' has nil Func", def.Name)
+	}
+	if _, ok := r.Lookup(def.Name); ok {
+		return fmt.Errorf("function '%s' already registered", def.Name)
+	}
+	s := def.Signature
+	if n := len(s.Params) + len(s.Optional) + len(s.Variadic); def.Params != nil && len(def.Params) != n {
+		return fmt.Errorf("function '%s': expected %d parameter names, got %d", def.Name, n, len(def.Params))
+	}
+	r.definitions[def.Name] = &def
+	return nil
+}
+
+// MustRegister is like Register but panics on error.
+func (r *Registry) MustRegister(defs ...Definition) {
+	for _, def := range defs {
+		if err := r.Register(def); err != nil {
+			panic(err)
+		}
+	}
+}
+
+// Alias makes already registered function available under another name.
+func (r *Registry) Alias(alias, name string) error {
+	if _, ok := r.Lookup(alias); ok {
+		return fmt.Errorf("function '%s' already registered", alias)
+	}
+	if _, ok := r.definitions[name]; !ok {
+		return fmt.Errorf("function '%s' not found", name)
+	}
+	r.aliases[alias] = name
+	return nil
+}
+
+// MustAlias registers aliases keyed by alias name and panics on error.
+func (r *Registry) MustAlias(aliases map[string]string) {
+	for alias, name := range aliases {
+		if err := r.Alias(alias, name); err != nil {
+			panic(err)
+		}
+	}
+}
+
+// Lookup returns definition of the function by its name or alias.
+func (r *Registry) Lookup(name string) (*Definition, bool) {
+	if canonical, ok := r.aliases[name]; ok {
+		name = canonical
+	}
+	def, ok := r.definitions[name]
+	return def, ok
+}
+
+// Aliases returns sorted aliases of the function.
+func (r *Registry) Aliases(name string) []string {
+	aliases := make([]string, 0)
+	for alias, canonical := range r.aliases {
+		if canonical == name {
+			aliases = append(aliases, alias)
+		}
+	}
+	sort.Strings(aliases)
+	return aliases
+}
+
+// Filter returns a new registry with definitions for which keep returns true.
+func (r *Registry) Filter(keep func(def *Definition) bool) *Registry {
+	filtered := NewRegistry()
+	for name, def := range r.definitions {
+		if keep(def) {
+			filtered.definitions[name] = def
+		}
+	}
+	for alias, name := range r.aliases {
+		if _, ok := filtered.definitions[name]; ok {
+			filtered.aliases[alias] = name
+		}
+	}
+	return filtered
+}
+
+// All returns definitions keyed by every name they can be looked up by, including aliases.
+func (r *Registry) All() map[string]*Definition {
+	all := make(map[string]*Definition, len(r.definitions)+len(r.aliases))
+	for name, def := range r.definitions {
+		all[name] = def
+	}
+	for alias, name := range r.aliases {
+		all[alias] = r.definitions[name]
+	}
+	return all
+}
+
+// Len returns number of registered names including aliases.
+func (r *Registry) Len() int {
+	return len(r.definitions) + len(r.aliases)
+}
+
+// Names returns sorted names of all registered functions without aliases.
+func (r *Registry) Names() []string {
+	names := make([]string, 0, len(r.definitions))
+	for name := range r.definitions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Category returns definitions of the category sorted by name.
+func (r *Registry) Category(category string) []*Definition {
+	defs := make([]*Definition, 0)
+	for _, name := range r.Names() {
+		if def := r.definitions[name]; def.Category == category {
+			defs = append(defs, def)
+		}
+	}
+	return defs
+}
+
+// Categories returns sorted names of all categories.
+func (r *Registry) Categories() []string {
+	seen := map[string]bool{}
+	categories := make([]string, 0)
+	for _, def := range r.definitions {
+		if !seen[def.Category] {
+			seen[def.Category] = true
+			categories = append(categories, def.Category)
+		}
+	}
+	sort.Strings(categories)
+	return categories
+}
+
+// Complete returns sorted names of functions starting with prefix, ignoring case.
+func (r *Registry) Complete(prefix string) []string {
+	prefix = strings.ToLower(prefix)
+	names := make([]string, 0)
+	for _, name := range r.Names() {
+		if strings.HasPrefix(strings.ToLower(name), prefix) {
+			names = append(names, name)
+		}
+	}
+	return names
+}
@@ -0,0 +1,81 @@
+package functions
+
+import (
+	"reflect"
+	"testing"
+)
+
+func prepareRegistry() *Registry {
+	registry := NewRegistry()
+	registry.MustRegister(
+		Definition{
+			Name:        "Round",
+			Func:        Round,
+			Signature:   Signatures["Round"],
+			Params:      []string{"number", "digits"},
+			Description: "Rounds a number to a specified number of digits.",
+			Examples:    []string{"Round(2,5) = 3"},
+			Category:    CategoryMath,
+			Pure:        true,
+		},
+		Definition{
+			Name:      "Sum",
+			Func:      Sum,
+			Signature: Signatures["Sum"],
+			Params:    []string{"number"},
+			Category:  CategoryMath,
+			Pure:      true,
+		},
+		Definition{
+			Name:      "If",
+			Func:      If,
+			Signature: Signatures["If"],
+			Params:    []string{"condition", "then", "else"},
+			Category:  CategoryLogical,
+			Pure:      true,
+		},
+	)
+	return registry
+}
+
+func TestRegistry_Register(t *testing.T) {
+	registry := prepareRegistry()
+	invalid := []Definition{
+		{Name: "", Func: Sum},
+		{Name: "Nil"},
+		{Name: "Sum", Func: Sum},
+		{Name: "Names", Func: Sum, Signature: Signatures["If"], Params: []string{"a"}},
+	}
+	for _, def := range invalid {
+		if err := registry.Register(def); err == nil {
+			t.Errorf("definition '%s': expected error, got nil", def.Name)
+		}
+	}
+}
+
+func TestRegistry_Metadata(t *testing.T) {
+	registry := prepareRegistry()
+	round, ok := registry.Lookup("Round")
+	if !ok {
+		t.Fatalf("expected Round to be registered")
+	}
+	if round.MinArgs() != 1 || round.MaxArgs() != 2 {
+		t.Errorf("expected 1 to 2 args, got %d to %d", round.MinArgs(), round.MaxArgs())
+	}
+	if usage := round.Usage(); usage != "Round(number; [digits])" {
+		t.Errorf("unexpected usage '%s'", usage)
+	}
+	sum, _ := registry.Lookup("Sum")
+	if sum.MaxArgs() != -1 {
+		t.Errorf("expected unlimited args, got %d", sum.MaxArgs())
+	}
+	if names := registry.Category(CategoryMath); len(names) != 2 || names[0].Name != "Round" {
+		t.Errorf("unexpected math category %v", names)
+	}
+	if categories := registry.Categories(); !reflect.DeepEqual(categories, []string{CategoryLogical, CategoryMath}) {
+		t.Errorf("unexpected categories %v", categories)
+	}
+	if names := registry.Complete("r"); !reflect.DeepEqual(names, []string{"Round"}) {
+		t.Errorf("unexpected completion %v", names)
+	}
+}
@@ -0,0 +1,629 @@
+package functions
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Type is a static type of formula value. Types are bit flags,
+// so a parameter accepting several types is their union.
+type Type uint16
+
+const (
+	TypeNumber Type = 1 << iota
+	TypeString
+	TypeBool
+	TypeArray    // []float64 or []any
+	TypeDate     // time.Time
+	TypeTable    // Table
+	TypeMoney    // Money
+	TypeQuantity // Quantity
+	TypeComplex  // complex128
+
+	TypeAny = ^Type(0)
+)
+
+var typeNames = []struct {
+	t    Type
+	name string
+}{
+	{TypeNumber, "number"},
+	{TypeString, "string"},
+	{TypeBool, "bool"},
+	{TypeArray, "array"},
+	{TypeDate, "date"},
+	{TypeTable, "table"},
+	{TypeMoney, "money"},
+	{TypeQuantity, "quantity"},
+	{TypeComplex, "complex"},
+}
+
+func (t Type) String() string {
+	if t == TypeAny {
+		return "any"
+	}
+	names := make([]string, 0, len(typeNames))
+	for _, el := range typeNames {
+		if t&el.t != 0 {
+			names = append(names, el.name)
+		}
+	}
+	if len(names) == 0 {
+		return "none"
+	}
+	return strings.Join(names, "|")
+}
+
+// Accepts reports whether value of type v may be passed where t is expected.
+func (t Type) Accepts(v Type) bool {
+	return t&v != 0
+}
+
+// TypeOf returns static type of Go value.
+func TypeOf(value any) Type {
+	switch value.(type) {
+	case float64, Decimal:
+		return TypeNumber
+	case string:
+		return TypeString
+	case bool:
+		return TypeBool
+	case []float64, []any:
+		return TypeArray
+	case time.Time:
+		return TypeDate
+	case Table:
+		return TypeTable
+	case Money:
+		return TypeMoney
+	case Quantity:
+		return TypeQuantity
+	case complex128:
+		return TypeComplex
+	default:
+		return TypeAny
+	}
+}
+
+// Signature describes parameter and return types of a function.
+type Signature struct {
+	Params   []Type // required parameters
+	Optional []Type // optional parameters following required ones
+	Variadic []Type // group of parameters repeated zero or more times after optional ones
+	Return   Type
+}
+
+// ParamType returns expected type of i-th argument when the function
+// is called with n arguments. ok is false when n is not a valid arity.
+func (s Signature) ParamType(i, n int) (t Type, ok bool) {
+	if !s.ValidArity(n) {
+		return 0, false
+	}
+	if i < len(s.Params) {
+		return s.Params[i], true
+	}
+	i -= len(s.Params)
+	if i < len(s.Optional) {
+		return s.Optional[i], true
+	}
+	i -= len(s.Optional)
+	return s.Variadic[i%len(s.Variadic)], true
+}
+
+// ValidArity reports whether the function may be called with n arguments.
+func (s Signature) ValidArity(n int) bool {
+	if n < len(s.Params) {
+		return false
+	}
+	fixed := len(s.Params) + len(s.Optional)
+	if n <= fixed {
+		return true
+	}
+	if len(s.Variadic) == 0 {
+		return false
+	}
+	return (n-fixed)%len(s.Variadic) == 0
+}
+
+// Arity describes allowed number of arguments in a human-readable form.
+func (s Signature) Arity() string {
+	min := len(s.Params)
+	max := min + len(s.Optional)
+	switch {
+	case len(s.Variadic) > 0:
+		return ">=" + strconv.Itoa(min)
+	case min == max:
+		return strconv.Itoa(min)
+	default:
+		return strconv.Itoa(min) + " to " + strconv.Itoa(max)
+	}
+}
+
+// Signatures of built-in functions keyed by their Go names.
+var Signatures = map[string]Signature{
+	"Sum": {
+		Variadic: []Type{TypeNumber | TypeArray | TypeMoney},
+		Return:   TypeNumber | TypeMoney,
+	},
+	"Len": {
+		Variadic: []Type{TypeAny},
+		Return:   TypeNumber,
+	},
+	"And": {
+		Variadic: []Type{TypeBool},
+		Return:   TypeBool,
+	},
+	"Or": {
+		Variadic: []Type{TypeBool},
+		Return:   TypeBool,
+	},
+	"If": {
+		Params: []Type{TypeBool, TypeAny, TypeAny},
+		Return: TypeAny,
+	},
+	"Round":     roundSignature,
+	"Roundup":   roundSignature,
+	"Rounddown": roundSignature,
+	"Trunc":     roundSignature,
+	"Int":       numberSignature,
+	"Even":      numberSignature,
+	"Odd":       numberSignature,
+	"Abs":       numberSignature,
+	"Sign":      numberSignature,
+	"Sqrt":      numberSignature,
+	"Exp":       numberSignature,
+	"Ln":        numberSignature,
+	"Log10":     numberSignature,
+	"Mround":    twoNumbersSignature,
+	"Ceiling":   twoNumbersSignature,
+	"Floor":     twoNumbersSignature,
+	"Mod":       twoNumbersSignature,
+	"Quotient":  twoNumbersSignature,
+	"Gcd":       numbersSignature,
+	"Lcm":       numbersSignature,
+	"Log": {
+		Params:   []Type{TypeNumber},
+		Optional: []Type{TypeNumber},
+		Return:   TypeNumber,
+	},
+	"CeilingMath": {
+		Params:   []Type{TypeNumber},
+		Optional: []Type{TypeNumber, TypeNumber},
+		Return:   TypeNumber,
+	},
+	"FloorMath": {
+		Params:   []Type{TypeNumber},
+		Optional: []Type{TypeNumber, TypeNumber},
+		Return:   TypeNumber,
+	},
+	"Mean": {
+		Variadic: []Type{TypeNumber | TypeArray},
+		Return:   TypeNumber,
+	},
+	"Min": {
+		Params:   []Type{TypeNumber | TypeArray},
+		Variadic: []Type{TypeNumber | TypeArray},
+		Return:   TypeNumber,
+	},
+	"Max": {
+		Params:   []Type{TypeNumber | TypeArray},
+		Variadic: []Type{TypeNumber | TypeArray},
+		Return:   TypeNumber,
+	},
+	"Ifs": {
+		Params:   []Type{TypeBool, TypeAny},
+		Variadic: []Type{TypeBool, TypeAny},
+		Return:   TypeAny,
+	},
+	"Median": numbersSignature,
+	"Mode":   numbersSignature,
+	"VarS":   numbersSignature,
+	"VarP":   numbersSignature,
+	"StdevS": numbersSignature,
+	"StdevP": numbersSignature,
+	"PercentileInc": {
+		Params: []Type{TypeNumber | TypeArray, TypeNumber},
+		Return: TypeNumber,
+	},
+	"PercentileExc": {
+		Params: []Type{TypeNumber | TypeArray, TypeNumber},
+		Return: TypeNumber,
+	},
+	"QuartileInc": {
+		Params: []Type{TypeNumber | TypeArray, TypeNumber},
+		Return: TypeNumber,
+	},
+	"QuartileExc": {
+		Params: []Type{TypeNumber | TypeArray, TypeNumber},
+		Return: TypeNumber,
+	},
+	"Rank": {
+		Params:   []Type{TypeNumber, TypeNumber | TypeArray},
+		Optional: []Type{TypeNumber},
+		Return:   TypeNumber,
+	},
+	"Large": {
+		Params: []Type{TypeNumber | TypeArray, TypeNumber},
+		Return: TypeNumber,
+	},
+	"Small": {
+		Params: []Type{TypeNumber | TypeArray, TypeNumber},
+		Return: TypeNumber,
+	},
+	"Correl":      arraysSignature,
+	"CovarianceP": arraysSignature,
+	"CovarianceS": arraysSignature,
+	"Slope":       arraysSignature,
+	"Intercept":   arraysSignature,
+	"Left": {
+		Params:   []Type{textType},
+		Optional: []Type{TypeNumber},
+		Return:   TypeString,
+	},
+	"Right": {
+		Params:   []Type{textType},
+		Optional: []Type{TypeNumber},
+		Return:   TypeString,
+	},
+	"Mid": {
+		Params: []Type{textType, TypeNumber, TypeNumber},
+		Return: TypeString,
+	},
+	"TextLen": {
+		Params: []Type{textType},
+		Return: TypeNumber,
+	},
+	"Find": {
+		Params:   []Type{textType, textType},
+		Optional: []Type{TypeNumber},
+		Return:   TypeNumber,
+	},
+	"Search": {
+		Params:   []Type{textType, textType},
+		Optional: []Type{TypeNumber},
+		Return:   TypeNumber,
+	},
+	"Substitute": {
+		Params:   []Type{textType, textType, textType},
+		Optional: []Type{TypeNumber},
+		Return:   TypeString,
+	},
+	"Replace": {
+		Params: []Type{textType, TypeNumber, TypeNumber, textType},
+		Return: TypeString,
+	},
+	"Trim":   textSignature,
+	"Upper":  textSignature,
+	"Lower":  textSignature,
+	"Proper": textSignature,
+	"Clean":  textSignature,
+	"Rept": {
+		Params: []Type{textType, TypeNumber},
+		Return: TypeString,
+	},
+	"Exact": {
+		Params: []Type{textType, textType},
+		Return: TypeBool,
+	},
+	"TextJoin": {
+		Params:   []Type{textType, TypeBool, textType | TypeArray},
+		Variadic: []Type{textType | TypeArray},
+		Return:   TypeString,
+	},
+	"TextSplit": {
+		Params:   []Type{textType, textType},
+		Optional: []Type{textType, TypeBool},
+		Return:   TypeArray,
+	},
+	"Vlookup": {
+		Params:   []Type{TypeAny, TypeTable, TypeNumber},
+		Optional: []Type{TypeBool},
+		Return:   TypeAny,
+	},
+	"Hlookup": {
+		Params:   []Type{TypeAny, TypeTable, TypeNumber},
+		Optional: []Type{TypeBool},
+		Return:   TypeAny,
+	},
+	"Xlookup": {
+		Params:   []Type{TypeAny, TypeArray | TypeTable, TypeArray | TypeTable},
+		Optional: []Type{TypeAny, TypeNumber, TypeNumber},
+		Return:   TypeAny,
+	},
+	"Match": {
+		Params:   []Type{TypeAny, TypeArray | TypeTable},
+		Optional: []Type{TypeNumber},
+		Return:   TypeNumber,
+	},
+	"Index": {
+		Params:   []Type{TypeArray | TypeTable, TypeNumber},
+		Optional: []Type{TypeNumber},
+		Return:   TypeAny,
+	},
+	"Choose": {
+		Params:   []Type{TypeNumber, TypeAny},
+		Variadic: []Type{TypeAny},
+		Return:   TypeAny,
+	},
+	"Pmt":  annuitySignature,
+	"Pv":   annuitySignature,
+	"Fv":   annuitySignature,
+	"Nper": annuitySignature,
+	"Npv": {
+		Params:   []Type{TypeNumber, TypeNumber | TypeArray},
+		Variadic: []Type{TypeNumber | TypeArray},
+		Return:   TypeNumber,
+	},
+	"Irr": {
+		Params:   []Type{TypeArray},
+		Optional: []Type{TypeNumber},
+		Return:   TypeNumber,
+	},
+	"Xnpv": {
+		Params: []Type{TypeNumber, TypeArray, TypeArray},
+		Return: TypeNumber,
+	},
+	"Xirr": {
+		Params:   []Type{TypeArray, TypeArray},
+		Optional: []Type{TypeNumber},
+		Return:   TypeNumber,
+	},
+	"Rate": {
+		Params:   []Type{TypeNumber, TypeNumber, TypeNumber},
+		Optional: []Type{TypeNumber, TypeNumber, TypeNumber},
+		Return:   TypeNumber,
+	},
+	"Sumif":      ifSignature,
+	"Averageif":  ifSignature,
+	"Sumifs":     ifsSignature,
+	"Averageifs": ifsSignature,
+	"Maxifs":     ifsSignature,
+	"Minifs":     ifsSignature,
+	"Countif": {
+		Params: []Type{rangeType, criteriaType},
+		Return: TypeNumber,
+	},
+	"Countifs": {
+		Params:   []Type{rangeType, criteriaType},
+		Variadic: []Type{rangeType, criteriaType},
+		Return:   TypeNumber,
+	},
+	"Isblank":   isSignature,
+	"Isnumber":  isSignature,
+	"Istext":    isSignature,
+	"Isnontext": isSignature,
+	"Islogical": isSignature,
+	"Iserror":   isSignature,
+	"Iserr":     isSignature,
+	"Isna":      isSignature,
+	"Iseven": {
+		Params: []Type{TypeNumber},
+		Return: TypeBool,
+	},
+	"Isodd": {
+		Params: []Type{TypeNumber},
+		Return: TypeBool,
+	},
+	"TypeCode": {
+		Params: []Type{TypeAny},
+		Return: TypeNumber,
+	},
+	"Na": {
+		Return: TypeAny,
+	},
+	"Not": {
+		Params: []Type{TypeBool},
+		Return: TypeBool,
+	},
+	"Xor": {
+		Params:   []Type{TypeBool | TypeArray},
+		Variadic: []Type{TypeBool | TypeArray},
+		Return:   TypeBool,
+	},
+	"Switch": {
+		Params:   []Type{TypeAny, TypeAny, TypeAny},
+		Variadic: []Type{TypeAny},
+		Return:   TypeAny,
+	},
+	"Iferror": {
+		Params: []Type{TypeAny, TypeAny},
+		Return: TypeAny,
+	},
+	"Ifna": {
+		Params: []Type{TypeAny, TypeAny},
+		Return: TypeAny,
+	},
+	"Sin":     numberSignature,
+	"Cos":     numberSignature,
+	"Tan":     numberSignature,
+	"Asin":    numberSignature,
+	"Acos":    numberSignature,
+	"Atan":    numberSignature,
+	"Sinh":    numberSignature,
+	"Cosh":    numberSignature,
+	"Tanh":    numberSignature,
+	"Asinh":   numberSignature,
+	"Acosh":   numberSignature,
+	"Atanh":   numberSignature,
+	"Degrees": numberSignature,
+	"Radians": numberSignature,
+	"Fact":    numberSignature,
+	"Atan2":   twoNumbersSignature,
+	"Combin":  twoNumbersSignature,
+	"Permut":  twoNumbersSignature,
+	"Pi": {
+		Return: TypeNumber,
+	},
+	"Bin2dec": toDecimalSignature,
+	"Oct2dec": toDecimalSignature,
+	"Hex2dec": toDecimalSignature,
+	"Bin2oct": conversionSignature,
+	"Bin2hex": conversionSignature,
+	"Oct2bin": conversionSignature,
+	"Oct2hex": conversionSignature,
+	"Hex2bin": conversionSignature,
+	"Hex2oct": conversionSignature,
+	"Dec2bin": conversionSignature,
+	"Dec2oct": conversionSignature,
+	"Dec2hex": conversionSignature,
+	"Regexmatch": {
+		Params: []Type{textType, TypeString},
+		Return: TypeBool,
+	},
+	"Regexextract": {
+		Params: []Type{textType, TypeString},
+		Return: TypeString | TypeArray,
+	},
+	"Regexreplace": {
+		Params: []Type{textType, TypeString, textType},
+		Return: TypeString,
+	},
+	"Rand": {
+		Return: TypeNumber,
+	},
+	"Randbetween": twoNumbersSignature,
+	"Randarray": {
+		Optional: []Type{TypeNumber, TypeNumber, TypeNumber, TypeNumber, TypeBool},
+		Return:   TypeArray | TypeTable,
+	},
+	"MoneyOf": {
+		Params: []Type{TypeNumber, TypeString},
+		Return: TypeMoney,
+	},
+	"Amount": {
+		Params: []Type{TypeMoney},
+		Return: TypeNumber,
+	},
+	"Currency": {
+		Params: []Type{TypeMoney},
+		Return: TypeString,
+	},
+	"Exchange": {
+		Params: []Type{TypeMoney, TypeString},
+		Return: TypeMoney,
+	},
+	"Quantity": {
+		Params: []Type{TypeNumber, TypeString},
+		Return: TypeQuantity,
+	},
+	"Complex": {
+		Params:   []Type{TypeNumber, TypeNumber},
+		Optional: []Type{TypeString},
+		Return:   TypeComplex,
+	},
+	"Imreal":    complexSignature,
+	"Imaginary": complexSignature,
+	"Imabs":     complexSignature,
+	"Imsum":     complexesSignature,
+	"Improduct": complexesSignature,
+	"Convert": {
+		Params:   []Type{TypeNumber | TypeQuantity, TypeString},
+		Optional: []Type{TypeString},
+		Return:   TypeNumber | TypeQuantity,
+	},
+}
+
+// textType is a type of text arguments: numbers and booleans are converted to text.
+const textType = TypeString | TypeNumber | TypeBool
+
+var textSignature = Signature{
+	Params: []Type{textType},
+	Return: TypeString,
+}
+
+// numberSignature is a signature of functions of one number.
+var numberSignature = Signature{
+	Params: []Type{TypeNumber},
+	Return: TypeNumber,
+}
+
+// twoNumbersSignature is a signature of functions of two numbers.
+var twoNumbersSignature = Signature{
+	Params: []Type{TypeNumber, TypeNumber},
+	Return: TypeNumber,
+}
+
+// roundSignature is a signature of functions rounding a number or money
+// to optional number of digits.
+var roundSignature = Signature{
+	Params:   []Type{TypeNumber | TypeMoney},
+	Optional: []Type{TypeNumber},
+	Return:   TypeNumber | TypeMoney,
+}
+
+var numbersSignature = Signature{
+	Params:   []Type{TypeNumber | TypeArray},
+	Variadic: []Type{TypeNumber | TypeArray},
+	Return:   TypeNumber,
+}
+
+var arraysSignature = Signature{
+	Params: []Type{TypeNumber | TypeArray, TypeNumber | TypeArray},
+	Return: TypeNumber,
+}
+
+// annuitySignature is a signature of PMT, PV, FV and NPER: three required numbers,
+// the fourth one and payment type are optional.
+var annuitySignature = Signature{
+	Params:   []Type{TypeNumber, TypeNumber, TypeNumber},
+	Optional: []Type{TypeNumber, TypeNumber},
+	Return:   TypeNumber,
+}
+
+// rangeType is a type of ranges of conditional functions.
+const rangeType = TypeArray | TypeTable
+
+// criteriaType is a type of criteria of conditional functions.
+const criteriaType = TypeNumber | TypeString | TypeBool | TypeDate
+
+// ifSignature is a signature of SUMIF and AVERAGEIF: range, criteria and optional range of values.
+var ifSignature = Signature{
+	Params:   []Type{rangeType, criteriaType},
+	Optional: []Type{rangeType},
+	Return:   TypeNumber,
+}
+
+// ifsSignature is a signature of SUMIFS and similar functions: range of values
+// followed by pairs of range and criteria.
+var ifsSignature = Signature{
+	Params:   []Type{rangeType, rangeType, criteriaType},
+	Variadic: []Type{rangeType, criteriaType},
+	Return:   TypeNumber,
+}
+
+// isSignature is a signature of information functions testing a value.
+var isSignature = Signature{
+	Params: []Type{TypeAny},
+	Return: TypeBool,
+}
+
+// toDecimalSignature is a signature of functions converting numbers to decimal.
+var toDecimalSignature = Signature{
+	Params: []Type{textType},
+	Return: TypeNumber,
+}
+
+// conversionSignature is a signature of functions converting numbers to binary,
+// octal or hexadecimal text with optional number of places.
+var conversionSignature = Signature{
+	Params:   []Type{textType},
+	Optional: []Type{TypeNumber},
+	Return:   TypeString,
+}
+
+// complexType is a type of complex number arguments, complex numbers may be given as text.
+const complexType = TypeComplex | TypeNumber | TypeString
+
+// complexSignature is a signature of functions of a complex number returning a real number.
+var complexSignature = Signature{
+	Params: []Type{complexType},
+	Return: TypeNumber,
+}
+
+// complexesSignature is a signature of functions of complex numbers and their arrays.
+var complexesSignature = Signature{
+	Params:   []Type{complexType | TypeArray | TypeTable},
+	Variadic: []Type{complexType | TypeArray | TypeTable},
+	Return:   TypeComplex,
+}
@@ -0,0 +1,147 @@
+package functions
+
+// Standard returns a new registry with all built-in functions registered
+// under Excel canonical upper-case names and their common aliases.
+func Standard() *Registry {
+	return NewStandard(Config{})
+}
+
+// NewStandard is like Standard, functions depending on environment are configured by config.
+func NewStandard(config Config) *Registry {
+	registry := NewRegistry()
+	registry.MustRegister(coreDefinitions...)
+	registry.MustAlias(coreAliases)
+	registry.MustRegister(statisticalDefinitions...)
+	registry.MustAlias(statisticalAliases)
+	registry.MustRegister(textDefinitions...)
+	dates := &dates{config: config}
+	registry.MustRegister(dates.definitions()...)
+	registry.MustRegister(lookupDefinitions...)
+	registry.MustRegister(financialDefinitions...)
+	registry.MustRegister((&finance{config: config, dates: dates}).definitions()...)
+	registry.MustRegister(conditionalDefinitions...)
+	registry.MustRegister(mathDefinitions...)
+	registry.MustRegister(logicalDefinitions...)
+	registry.MustRegister(infoDefinitions...)
+	registry.MustRegister(trigDefinitions...)
+	registry.MustRegister(engineeringDefinitions()...)
+	registry.MustRegister(newRegexps(config.Regex).definitions()...)
+	registry.MustRegister(newRandom(config.Random).definitions()...)
+	registry.MustRegister(moneyDefinitions...)
+	registry.MustRegister((&exchange{rates: config.Rates}).definitions()...)
+	table := config.Units
+	if table == nil {
+		table = NewUnits()
+	}
+	registry.MustRegister((&units{table: table}).definitions()...)
+	registry.MustRegister(complexDefinitions...)
+	return registry
+}
+
+var coreDefinitions = []Definition{
+	{
+		Name:        "SUM",
+		Func:        Sum,
+		Signature:   Signatures["Sum"],
+		Params:      []string{"number"},
+		Description: "Adds all numbers and arrays of numbers.",
+		Examples:    []string{"SUM(1; 2; 3) = 6"},
+		Category:    CategoryMath,
+		Pure:        true,
+	},
+	{
+		Name:        "ROUND",
+		Func:        Round,
+		Signature:   Signatures["Round"],
+		Params:      []string{"number", "digits"},
+		Description: "Rounds a number to a specified number of digits.",
+		Examples:    []string{"ROUND(2,345; 2) = 2,35", "ROUND(2,5) = 3"},
+		Category:    CategoryMath,
+		Pure:        true,
+	},
+	{
+		Name:        "AND",
+		Func:        And,
+		Signature:   Signatures["And"],
+		Params:      []string{"logical"},
+		Description: "Returns TRUE if all of its arguments are TRUE.",
+		Examples:    []string{"AND(1 < 2; 2 < 3) = TRUE"},
+		Category:    CategoryLogical,
+		Pure:        true,
+	},
+	{
+		Name:        "OR",
+		Func:        Or,
+		Signature:   Signatures["Or"],
+		Params:      []string{"logical"},
+		Description: "Returns TRUE if any argument is TRUE.",
+		Examples:    []string{"OR(1 > 2; 2 < 3) = TRUE"},
+		Category:    CategoryLogical,
+		Pure:        true,
+	},
+	{
+		Name:        "IF",
+		Func:        If,
+		Signature:   Signatures["If"],
+		Params:      []string{"condition", "then", "else"},
+		Description: "Returns one value if condition is TRUE and another value if it's FALSE.",
+		Examples:    []string{`IF(1 < 2; "yes"; "no") = "yes"`},
+		Category:    CategoryLogical,
+		Pure:        true,
+	},
+	{
+		Name:        "IFS",
+		Func:        Ifs,
+		Signature:   Signatures["Ifs"],
+		Params:      []string{"condition", "value", "condition", "value"},
+		Description: "Returns value that corresponds to the first TRUE condition.",
+		Examples:    []string{`IFS(1 > 2; "a"; 1 < 2; "b") = "b"`},
+		Category:    CategoryLogical,
+		Pure:        true,
+	},
+	{
+		Name:        "AVERAGE",
+		Func:        Mean,
+		Signature:   Signatures["Mean"],
+		Params:      []string{"number"},
+		Description: "Returns the arithmetic mean of its arguments.",
+		Examples:    []string{"AVERAGE(1; 2; 3) = 2"},
+		Category:    CategoryStatistical,
+		Pure:        true,
+	},
+	{
+		Name:        "MIN",
+		Func:        Min,
+		Signature:   Signatures["Min"],
+		Params:      []string{"number", "number"},
+		Description: "Returns the smallest number.",
+		Examples:    []string{"MIN(3; 1; 2) = 1"},
+		Category:    CategoryStatistical,
+		Pure:        true,
+	},
+	{
+		Name:        "MAX",
+		Func:        Max,
+		Signature:   Signatures["Max"],
+		Params:      []string{"number", "number"},
+		Description: "Returns the largest number.",
+		Examples:    []string{"MAX(3; 1; 2) = 3"},
+		Category:    CategoryStatistical,
+		Pure:        true,
+	},
+	{
+		Name:        "COUNTA",
+		Func:        Len,
+		Signature:   Signatures["Len"],
+		Params:      []string{"value"},
+		Description: "Counts the number of arguments.",
+		Examples:    []string{`COUNTA(1; "a"; 2) = 3`},
+		Category:    CategoryStatistical,
+		Pure:        true,
+	},
+}
+
+var coreAliases = map[string]string{
+	"MEAN": "AVERAGE",
+	"AVG":  "AVERAGE",
+}
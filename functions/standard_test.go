@@ -0,0 +1,26 @@
+package functions
+
+import "testing"
+
+func TestStandard(t *testing.T) {
+	registry := Standard()
+	for _, name := range []string{"SUM", "IF", "AVERAGE", "MEAN", "COUNTA"} {
+		if _, ok := registry.Lookup(name); !ok {
+			t.Errorf("expected '%s' to be registered", name)
+		}
+	}
+	mean, _ := registry.Lookup("MEAN")
+	if mean.Name != "AVERAGE" {
+		t.Errorf("expected MEAN to be alias of AVERAGE, got %s", mean.Name)
+	}
+	for name, def := range registry.All() {
+		if def.Category == "" || def.Description == "" || len(def.Examples) == 0 {
+			t.Errorf("function '%s' has incomplete metadata", name)
+		}
+	}
+	for name, pure := range map[string]bool{"SUM": true, "DATE": true, "TODAY": false, "NOW": false, "RAND": false, "RANDARRAY": false} {
+		if def, _ := registry.Lookup(name); def.Pure != pure {
+			t.Errorf("function '%s': expected pure %v, got %v", name, pure, def.Pure)
+		}
+	}
+}
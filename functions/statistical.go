@@ -0,0 +1,576 @@
+package functions
+
+import (
+	"math"
+	"sort"
+)
+
+// sortedNumbers returns flattened args sorted in ascending order.
+func sortedNumbers(args ...any) ([]float64, error) {
+	numbers, err := flatten(args...)
+	if err != nil {
+		return nil, err
+	}
+	sort.Float64s(numbers)
+	return numbers, nil
+}
+
+func mean(numbers []float64) float64 {
+	var total float64
+	for _, val := range numbers {
+		total += val
+	}
+	return total / float64(len(numbers))
+}
+
+func Median(args ...any) (any, error) {
+	if err := checkArgs(args, 1, -1); err != nil {
+		return nil, err
+	}
+	numbers, err := sortedNumbers(args...)
+	if err != nil {
+		return nil, err
+	}
+	n := len(numbers)
+	if n == 0 {
+		return nil, errorf(ErrNum, "median of empty set")
+	}
+	if n%2 == 1 {
+		return numbers[n/2], nil
+	}
+	return (numbers[n/2-1] + numbers[n/2]) / 2, nil
+}
+
+// Mode returns the most frequently occurring number. If several numbers
+// occur equally often, the first of them is returned.
+func Mode(args ...any) (any, error) {
+	if err := checkArgs(args, 1, -1); err != nil {
+		return nil, err
+	}
+	numbers, err := flatten(args...)
+	if err != nil {
+		return nil, err
+	}
+	counts := make(map[float64]int, len(numbers))
+	for _, val := range numbers {
+		counts[val]++
+	}
+	var mode float64
+	var best int
+	for _, val := range numbers {
+		if c := counts[val]; c > best {
+			mode, best = val, c
+		}
+	}
+	if best < 2 {
+		return nil, errorf(ErrNA, "no repeated values")
+	}
+	return mode, nil
+}
+
+// variance returns sum of squared deviations divided by n-ddof.
+func variance(numbers []float64, ddof int) (float64, error) {
+	n := len(numbers)
+	if n-ddof <= 0 {
+		return 0, errorf(ErrDiv0, "expected more than %d numbers, got %d", ddof, n)
+	}
+	m := mean(numbers)
+	var total float64
+	for _, val := range numbers {
+		total += (val - m) * (val - m)
+	}
+	return total / float64(n-ddof), nil
+}
+
+func varianceOf(ddof int, args []any) (float64, error) {
+	if err := checkArgs(args, 1, -1); err != nil {
+		return 0, err
+	}
+	numbers, err := flatten(args...)
+	if err != nil {
+		return 0, err
+	}
+	return variance(numbers, ddof)
+}
+
+// VarS returns sample variance.
+func VarS(args ...any) (any, error) {
+	return varianceOf(1, args)
+}
+
+// VarP returns population variance.
+func VarP(args ...any) (any, error) {
+	return varianceOf(0, args)
+}
+
+// StdevS returns sample standard deviation.
+func StdevS(args ...any) (any, error) {
+	v, err := varianceOf(1, args)
+	if err != nil {
+		return nil, err
+	}
+	return math.Sqrt(v), nil
+}
+
+// StdevP returns population standard deviation.
+func StdevP(args ...any) (any, error) {
+	v, err := varianceOf(0, args)
+	if err != nil {
+		return nil, err
+	}
+	return math.Sqrt(v), nil
+}
+
+// interpolate returns value at 0-based fractional rank of sorted numbers.
+// The rank should be checked by the caller to be in range.
+func interpolate(numbers []float64, rank float64) float64 {
+	i := math.Floor(rank)
+	lower := numbers[int(i)]
+	if int(i)+1 >= len(numbers) {
+		return lower
+	}
+	return lower + (rank-i)*(numbers[int(i)+1]-lower)
+}
+
+func percentileInc(numbers []float64, k float64) (float64, error) {
+	if len(numbers) == 0 {
+		return 0, errorf(ErrNum, "percentile of empty set")
+	}
+	if !(k >= 0 && k <= 1) {
+		return 0, errorf(ErrNum, "percentile should be in [0; 1], got %f", k)
+	}
+	return interpolate(numbers, k*float64(len(numbers)-1)), nil
+}
+
+func percentileExc(numbers []float64, k float64) (float64, error) {
+	n := float64(len(numbers))
+	rank := k * (n + 1)
+	if !(k > 0 && k < 1 && rank >= 1 && rank <= n) {
+		return 0, errorf(ErrNum, "percentile %f is out of range for %d numbers", k, len(numbers))
+	}
+	return interpolate(numbers, rank-1), nil
+}
+
+// arrayAndNumber parses (array; number) args.
+func arrayAndNumber(args []any) ([]float64, float64, error) {
+	if err := checkArgs(args, 2, 2); err != nil {
+		return nil, 0, err
+	}
+	numbers, err := sortedNumbers(args[0])
+	if err != nil {
+		return nil, 0, err
+	}
+	k, err := number(args[1])
+	if err != nil {
+		return nil, 0, err
+	}
+	return numbers, k, nil
+}
+
+// PercentileInc returns k-th percentile, k is in [0; 1] inclusive.
+func PercentileInc(args ...any) (any, error) {
+	numbers, k, err := arrayAndNumber(args)
+	if err != nil {
+		return nil, err
+	}
+	return resultOf(percentileInc(numbers, k))
+}
+
+// PercentileExc returns k-th percentile, k is in (0; 1) exclusive.
+func PercentileExc(args ...any) (any, error) {
+	numbers, k, err := arrayAndNumber(args)
+	if err != nil {
+		return nil, err
+	}
+	return resultOf(percentileExc(numbers, k))
+}
+
+// QuartileInc returns quartile 0 to 4 based on inclusive percentile.
+func QuartileInc(args ...any) (any, error) {
+	numbers, q, err := arrayAndNumber(args)
+	if err != nil {
+		return nil, err
+	}
+	q = math.Trunc(q)
+	if !(q >= 0 && q <= 4) {
+		return nil, errorf(ErrNum, "quart should be in [0; 4], got %f", q)
+	}
+	return resultOf(percentileInc(numbers, q/4))
+}
+
+// QuartileExc returns quartile 1 to 3 based on exclusive percentile.
+func QuartileExc(args ...any) (any, error) {
+	numbers, q, err := arrayAndNumber(args)
+	if err != nil {
+		return nil, err
+	}
+	q = math.Trunc(q)
+	if !(q >= 1 && q <= 3) {
+		return nil, errorf(ErrNum, "quart should be in [1; 3], got %f", q)
+	}
+	return resultOf(percentileExc(numbers, q/4))
+}
+
+// resultOf boxes float64 result of a helper.
+func resultOf(val float64, err error) (any, error) {
+	if err != nil {
+		return nil, err
+	}
+	return val, nil
+}
+
+// Rank returns rank of number in array: descending if order is 0 or omitted, ascending otherwise.
+func Rank(args ...any) (any, error) {
+	if err := checkArgs(args, 2, 3); err != nil {
+		return nil, err
+	}
+	val, err := number(args[0])
+	if err != nil {
+		return nil, err
+	}
+	numbers, err := flatten(args[1])
+	if err != nil {
+		return nil, err
+	}
+	order, err := optionalNumber(args, 2, 0)
+	if err != nil {
+		return nil, err
+	}
+	rank := 1.
+	found := false
+	for _, el := range numbers {
+		switch {
+		case el == val:
+			found = true
+		case order == 0 && el > val, order != 0 && el < val:
+			rank++
+		}
+	}
+	if !found {
+		return nil, errorf(ErrNA, "%f not found", val)
+	}
+	return rank, nil
+}
+
+// Large returns k-th largest number.
+func Large(args ...any) (any, error) {
+	numbers, k, err := arrayAndNumber(args)
+	if err != nil {
+		return nil, err
+	}
+	k = math.Ceil(k)
+	if !(k >= 1 && k <= float64(len(numbers))) {
+		return nil, errorf(ErrNum, "k should be in [1; %d], got %f", len(numbers), k)
+	}
+	return numbers[len(numbers)-int(k)], nil
+}
+
+// Small returns k-th smallest number.
+func Small(args ...any) (any, error) {
+	numbers, k, err := arrayAndNumber(args)
+	if err != nil {
+		return nil, err
+	}
+	k = math.Ceil(k)
+	if !(k >= 1 && k <= float64(len(numbers))) {
+		return nil, errorf(ErrNum, "k should be in [1; %d], got %f", len(numbers), k)
+	}
+	return numbers[int(k)-1], nil
+}
+
+// pairs parses two arrays of equal non-zero length.
+func pairs(args []any) ([]float64, []float64, error) {
+	if err := checkArgs(args, 2, 2); err != nil {
+		return nil, nil, err
+	}
+	x, err := flatten(args[0])
+	if err != nil {
+		return nil, nil, err
+	}
+	y, err := flatten(args[1])
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(x) != len(y) {
+		return nil, nil, errorf(ErrNA, "arrays have different length: %d and %d", len(x), len(y))
+	}
+	if len(x) == 0 {
+		return nil, nil, errorf(ErrDiv0, "arrays are empty")
+	}
+	return x, y, nil
+}
+
+// comoments returns sums of (x-mx)*(y-my), (x-mx)^2 and (y-my)^2.
+func comoments(x, y []float64) (xy, xx, yy float64) {
+	mx, my := mean(x), mean(y)
+	for i := range x {
+		dx, dy := x[i]-mx, y[i]-my
+		xy += dx * dy
+		xx += dx * dx
+		yy += dy * dy
+	}
+	return xy, xx, yy
+}
+
+// Correl returns Pearson correlation coefficient of two arrays.
+func Correl(args ...any) (any, error) {
+	x, y, err := pairs(args)
+	if err != nil {
+		return nil, err
+	}
+	xy, xx, yy := comoments(x, y)
+	if xx == 0 || yy == 0 {
+		return nil, errorf(ErrDiv0, "standard deviation is zero")
+	}
+	return xy / math.Sqrt(xx*yy), nil
+}
+
+// CovarianceP returns population covariance of two arrays.
+func CovarianceP(args ...any) (any, error) {
+	x, y, err := pairs(args)
+	if err != nil {
+		return nil, err
+	}
+	xy, _, _ := comoments(x, y)
+	return xy / float64(len(x)), nil
+}
+
+// CovarianceS returns sample covariance of two arrays.
+func CovarianceS(args ...any) (any, error) {
+	x, y, err := pairs(args)
+	if err != nil {
+		return nil, err
+	}
+	if len(x) < 2 {
+		return nil, errorf(ErrDiv0, "expected more than 1 pair, got %d", len(x))
+	}
+	xy, _, _ := comoments(x, y)
+	return xy / float64(len(x)-1), nil
+}
+
+func slope(args []any) (float64, []float64, []float64, error) {
+	y, x, err := pairs(args)
+	if err != nil {
+		return 0, nil, nil, err
+	}
+	xy, xx, _ := comoments(x, y)
+	if xx == 0 {
+		return 0, nil, nil, errorf(ErrDiv0, "variance of known x is zero")
+	}
+	return xy / xx, x, y, nil
+}
+
+// Slope returns slope of linear regression line through (known_x; known_y) points.
+// Arguments are known_y and known_x arrays.
+func Slope(args ...any) (any, error) {
+	b, _, _, err := slope(args)
+	if err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// Intercept returns intercept of linear regression line through (known_x; known_y) points.
+// Arguments are known_y and known_x arrays.
+func Intercept(args ...any) (any, error) {
+	b, x, y, err := slope(args)
+	if err != nil {
+		return nil, err
+	}
+	return mean(y) - b*mean(x), nil
+}
+
+var statisticalDefinitions = []Definition{
+	{
+		Name:        "MEDIAN",
+		Func:        Median,
+		Signature:   Signatures["Median"],
+		Params:      []string{"number", "number"},
+		Description: "Returns the median of the given numbers.",
+		Examples:    []string{"MEDIAN(1; 5; 2; 4) = 3"},
+		Category:    CategoryStatistical,
+		Pure:        true,
+	},
+	{
+		Name:        "MODE.SNGL",
+		Func:        Mode,
+		Signature:   Signatures["Mode"],
+		Params:      []string{"number", "number"},
+		Description: "Returns the most frequently occurring number, #N/A if no number repeats.",
+		Examples:    []string{"MODE.SNGL(1; 2; 2; 3) = 2"},
+		Category:    CategoryStatistical,
+		Pure:        true,
+	},
+	{
+		Name:        "STDEV.S",
+		Func:        StdevS,
+		Signature:   Signatures["StdevS"],
+		Params:      []string{"number", "number"},
+		Description: "Estimates standard deviation based on a sample.",
+		Examples:    []string{"STDEV.S(2; 4; 4; 4; 5; 5; 7; 9) = 2,138"},
+		Category:    CategoryStatistical,
+		Pure:        true,
+	},
+	{
+		Name:        "STDEV.P",
+		Func:        StdevP,
+		Signature:   Signatures["StdevP"],
+		Params:      []string{"number", "number"},
+		Description: "Calculates standard deviation based on the entire population.",
+		Examples:    []string{"STDEV.P(2; 4; 4; 4; 5; 5; 7; 9) = 2"},
+		Category:    CategoryStatistical,
+		Pure:        true,
+	},
+	{
+		Name:        "VAR.S",
+		Func:        VarS,
+		Signature:   Signatures["VarS"],
+		Params:      []string{"number", "number"},
+		Description: "Estimates variance based on a sample.",
+		Examples:    []string{"VAR.S(1; 2; 3; 4) = 1,667"},
+		Category:    CategoryStatistical,
+		Pure:        true,
+	},
+	{
+		Name:        "VAR.P",
+		Func:        VarP,
+		Signature:   Signatures["VarP"],
+		Params:      []string{"number", "number"},
+		Description: "Calculates variance based on the entire population.",
+		Examples:    []string{"VAR.P(1; 2; 3; 4) = 1,25"},
+		Category:    CategoryStatistical,
+		Pure:        true,
+	},
+	{
+		Name:        "PERCENTILE.INC",
+		Func:        PercentileInc,
+		Signature:   Signatures["PercentileInc"],
+		Params:      []string{"array", "k"},
+		Description: "Returns the k-th percentile of values, k is in [0; 1] inclusive.",
+		Examples:    []string{"PERCENTILE.INC(A; 0,5)"},
+		Category:    CategoryStatistical,
+		Pure:        true,
+	},
+	{
+		Name:        "PERCENTILE.EXC",
+		Func:        PercentileExc,
+		Signature:   Signatures["PercentileExc"],
+		Params:      []string{"array", "k"},
+		Description: "Returns the k-th percentile of values, k is in (0; 1) exclusive.",
+		Examples:    []string{"PERCENTILE.EXC(A; 0,25)"},
+		Category:    CategoryStatistical,
+		Pure:        true,
+	},
+	{
+		Name:        "QUARTILE.INC",
+		Func:        QuartileInc,
+		Signature:   Signatures["QuartileInc"],
+		Params:      []string{"array", "quart"},
+		Description: "Returns the quartile 0 to 4 of values based on inclusive percentile.",
+		Examples:    []string{"QUARTILE.INC(A; 1)"},
+		Category:    CategoryStatistical,
+		Pure:        true,
+	},
+	{
+		Name:        "QUARTILE.EXC",
+		Func:        QuartileExc,
+		Signature:   Signatures["QuartileExc"],
+		Params:      []string{"array", "quart"},
+		Description: "Returns the quartile 1 to 3 of values based on exclusive percentile.",
+		Examples:    []string{"QUARTILE.EXC(A; 1)"},
+		Category:    CategoryStatistical,
+		Pure:        true,
+	},
+	{
+		Name:        "RANK.EQ",
+		Func:        Rank,
+		Signature:   Signatures["Rank"],
+		Params:      []string{"number", "ref", "order"},
+		Description: "Returns the rank of a number in a list, descending if order is 0 or omitted.",
+		Examples:    []string{"RANK.EQ(3; A)", "RANK.EQ(3; A; 1)"},
+		Category:    CategoryStatistical,
+		Pure:        true,
+	},
+	{
+		Name:        "LARGE",
+		Func:        Large,
+		Signature:   Signatures["Large"],
+		Params:      []string{"array", "k"},
+		Description: "Returns the k-th largest value.",
+		Examples:    []string{"LARGE(A; 2)"},
+		Category:    CategoryStatistical,
+		Pure:        true,
+	},
+	{
+		Name:        "SMALL",
+		Func:        Small,
+		Signature:   Signatures["Small"],
+		Params:      []string{"array", "k"},
+		Description: "Returns the k-th smallest value.",
+		Examples:    []string{"SMALL(A; 2)"},
+		Category:    CategoryStatistical,
+		Pure:        true,
+	},
+	{
+		Name:        "CORREL",
+		Func:        Correl,
+		Signature:   Signatures["Correl"],
+		Params:      []string{"array1", "array2"},
+		Description: "Returns the correlation coefficient of two arrays.",
+		Examples:    []string{"CORREL(A; B)"},
+		Category:    CategoryStatistical,
+		Pure:        true,
+	},
+	{
+		Name:        "COVARIANCE.P",
+		Func:        CovarianceP,
+		Signature:   Signatures["CovarianceP"],
+		Params:      []string{"array1", "array2"},
+		Description: "Returns population covariance of two arrays.",
+		Examples:    []string{"COVARIANCE.P(A; B)"},
+		Category:    CategoryStatistical,
+		Pure:        true,
+	},
+	{
+		Name:        "COVARIANCE.S",
+		Func:        CovarianceS,
+		Signature:   Signatures["CovarianceS"],
+		Params:      []string{"array1", "array2"},
+		Description: "Returns sample covariance of two arrays.",
+		Examples:    []string{"COVARIANCE.S(A; B)"},
+		Category:    CategoryStatistical,
+		Pure:        true,
+	},
+	{
+		Name:        "SLOPE",
+		Func:        Slope,
+		Signature:   Signatures["Slope"],
+		Params:      []string{"known_y", "known_x"},
+		Description: "Returns the slope of the linear regression line.",
+		Examples:    []string{"SLOPE(Y; X)"},
+		Category:    CategoryStatistical,
+		Pure:        true,
+	},
+	{
+		Name:        "INTERCEPT",
+		Func:        Intercept,
+		Signature:   Signatures["Intercept"],
+		Params:      []string{"known_y", "known_x"},
+		Description: "Returns the intercept of the linear regression line.",
+		Examples:    []string{"INTERCEPT(Y; X)"},
+		Category:    CategoryStatistical,
+		Pure:        true,
+	},
+}
+
+var statisticalAliases = map[string]string{
+	"MODE":       "MODE.SNGL",
+	"STDEV":      "STDEV.S",
+	"STDEVP":     "STDEV.P",
+	"VAR":        "VAR.S",
+	"VARP":       "VAR.P",
+	"PERCENTILE": "PERCENTILE.INC",
+	"QUARTILE":   "QUARTILE.INC",
+	"RANK":       "RANK.EQ",
+	"COVAR":      "COVARIANCE.P",
+}
@@ -0,0 +1,86 @@
+package functions
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestStatistical(t *testing.T) {
+	data := []float64{2, 4, 4, 4, 5, 5, 7, 9}
+	x := []float64{1, 2, 3, 4, 5}
+	y := []any{2., 4., 5., 4., 5.}
+	cases := []struct {
+		name     string
+		fn       func(args ...any) (any, error)
+		args     []any
+		expected float64
+	}{
+		{"Median", Median, []any{1., 5., 2., 4.}, 3},
+		{"Median", Median, []any{data}, 4.5},
+		{"Mode", Mode, []any{1., 2., 2., 1.}, 1},
+		{"Mode", Mode, []any{data}, 4},
+		{"StdevP", StdevP, []any{data}, 2},
+		{"StdevS", StdevS, []any{data}, 2.1380899},
+		{"VarP", VarP, []any{1., 2., 3., 4.}, 1.25},
+		{"VarS", VarS, []any{[]any{1., 2.}, 3., 4.}, 1.6666667},
+		{"PercentileInc", PercentileInc, []any{x, 0.3}, 2.2},
+		{"PercentileExc", PercentileExc, []any{x, 0.25}, 1.5},
+		{"QuartileInc", QuartileInc, []any{data, 1.}, 4},
+		{"QuartileInc", QuartileInc, []any{data, 4.}, 9},
+		{"QuartileExc", QuartileExc, []any{data, 3.}, 6.5},
+		{"Rank", Rank, []any{4., data}, 5},
+		{"Rank", Rank, []any{4., data, 1.}, 2},
+		{"Large", Large, []any{data, 2.}, 7},
+		{"Small", Small, []any{data, 2.}, 4},
+		{"Correl", Correl, []any{x, y}, 0.7745967},
+		{"CovarianceP", CovarianceP, []any{x, y}, 1.2},
+		{"CovarianceS", CovarianceS, []any{x, y}, 1.5},
+		{"Slope", Slope, []any{y, x}, 0.6},
+		{"Intercept", Intercept, []any{y, x}, 2.2},
+		{"Min", Min, []any{3., data}, 2},
+		{"Max", Max, []any{[]any{3., data}}, 9},
+	}
+	for _, c := range cases {
+		res, err := c.fn(c.args...)
+		if err != nil {
+			t.Errorf("%s%v: expected nil error, got '%v'", c.name, c.args, err)
+			continue
+		}
+		if math.Abs(res.(float64)-c.expected) > 1e-6 {
+			t.Errorf("%s%v: expected %f, got %f", c.name, c.args, c.expected, res)
+		}
+	}
+}
+
+func TestStatistical_Errors(t *testing.T) {
+	cases := []struct {
+		name string
+		fn   func(args ...any) (any, error)
+		args []any
+		code ErrorCode
+	}{
+		{"Mode", Mode, []any{1., 2., 3.}, ErrNA},
+		{"VarS", VarS, []any{1.}, ErrDiv0},
+		{"PercentileInc", PercentileInc, []any{[]float64{1}, 2.}, ErrNum},
+		{"PercentileExc", PercentileExc, []any{[]float64{1, 2}, 0.1}, ErrNum},
+		{"Rank", Rank, []any{10., []float64{1, 2}}, ErrNA},
+		{"Large", Large, []any{[]float64{1, 2}, 3.}, ErrNum},
+		{"Large", Large, []any{[]float64{1, 2}, 1e20}, ErrNum},
+		{"Large", Large, []any{[]float64{1, 2}, math.NaN()}, ErrNum},
+		{"Small", Small, []any{[]float64{1, 2}, 1e20}, ErrNum},
+		{"Small", Small, []any{[]float64{1, 2}, math.Inf(-1)}, ErrNum},
+		{"PercentileInc", PercentileInc, []any{[]float64{1, 2}, math.NaN()}, ErrNum},
+		{"PercentileExc", PercentileExc, []any{[]float64{1, 2, 3}, math.NaN()}, ErrNum},
+		{"QuartileInc", QuartileInc, []any{[]float64{1, 2}, math.NaN()}, ErrNum},
+		{"QuartileExc", QuartileExc, []any{[]float64{1, 2, 3}, math.NaN()}, ErrNum},
+		{"Correl", Correl, []any{[]float64{1, 2}, []float64{1}}, ErrNA},
+		{"Correl", Correl, []any{[]float64{1, 1}, []float64{1, 2}}, ErrDiv0},
+	}
+	for _, c := range cases {
+		_, err := c.fn(c.args...)
+		if !errors.Is(err, c.code) {
+			t.Errorf("%s%v: expected %s error, got '%v'", c.name, c.args, c.code, err)
+		}
+	}
+}
@@ -0,0 +1,695 @@
+package functions
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// text returns arg as string, numbers and booleans are converted like in Excel.
+func text(arg any) (string, error) {
+	switch val := arg.(type) {
+	case string:
+		return val, nil
+	case float64:
+		return strconv.FormatFloat(val, 'f', -1, 64), nil
+	case Decimal:
+		return val.trim(0).String(), nil
+	case bool:
+		if val {
+			return "TRUE", nil
+		}
+		return "FALSE", nil
+	case blank:
+		return "", nil
+	default:
+		return "", fmt.Errorf("expected string, got %T", arg)
+	}
+}
+
+// count returns arg as a non-negative integer number of characters.
+func count(arg any) (int, error) {
+	val, err := number(arg)
+	if err != nil {
+		return 0, err
+	}
+	if math.IsNaN(val) || math.IsInf(val, 0) {
+		return 0, errorf(ErrValue, "expected finite number, got %f", val)
+	}
+	if val < 0 {
+		return 0, errorf(ErrValue, "expected non-negative number, got %f", val)
+	}
+	return int(math.Min(math.Trunc(val), maxCount)), nil
+}
+
+// position returns arg as 1-based position of a character.
+func position(arg any) (int, error) {
+	val, err := number(arg)
+	if err != nil {
+		return 0, err
+	}
+	if math.IsNaN(val) || math.IsInf(val, 0) {
+		return 0, errorf(ErrValue, "expected finite number, got %f", val)
+	}
+	if val < 1 {
+		return 0, errorf(ErrValue, "expected position >= 1, got %f", val)
+	}
+	return int(math.Min(math.Trunc(val), maxCount)), nil
+}
+
+// maxCount limits counts and positions of characters: larger values are
+// past the end of any text, and sums of them don't overflow int.
+const maxCount = math.MaxInt32
+
+// textArgs converts every arg to string.
+func textArgs(args []any) ([]string, error) {
+	result := make([]string, len(args))
+	for i, arg := range args {
+		s, err := text(arg)
+		if err != nil {
+			return nil, err
+		}
+		result[i] = s
+	}
+	return result, nil
+}
+
+// texts collects strings from scalars and arrays.
+func texts(args ...any) ([]string, error) {
+	result := make([]string, 0, len(args))
+	for _, arg := range args {
+		switch val := arg.(type) {
+		case []any:
+			sub, err := texts(val...)
+			if err != nil {
+				return nil, err
+			}
+			result = append(result, sub...)
+		case []float64:
+			for _, el := range val {
+				s, _ := text(el)
+				result = append(result, s)
+			}
+		default:
+			s, err := text(arg)
+			if err != nil {
+				return nil, err
+			}
+			result = append(result, s)
+		}
+	}
+	return result, nil
+}
+
+// Left returns the first n characters of text, n is 1 if omitted.
+func Left(args ...any) (any, error) {
+	if err := checkArgs(args, 1, 2); err != nil {
+		return nil, err
+	}
+	s, err := text(args[0])
+	if err != nil {
+		return nil, err
+	}
+	n := 1
+	if len(args) == 2 {
+		if n, err = count(args[1]); err != nil {
+			return nil, err
+		}
+	}
+	runes := []rune(s)
+	if n > len(runes) {
+		n = len(runes)
+	}
+	return string(runes[:n]), nil
+}
+
+// Right returns the last n characters of text, n is 1 if omitted.
+func Right(args ...any) (any, error) {
+	if err := checkArgs(args, 1, 2); err != nil {
+		return nil, err
+	}
+	s, err := text(args[0])
+	if err != nil {
+		return nil, err
+	}
+	n := 1
+	if len(args) == 2 {
+		if n, err = count(args[1]); err != nil {
+			return nil, err
+		}
+	}
+	runes := []rune(s)
+	if n > len(runes) {
+		n = len(runes)
+	}
+	return string(runes[len(runes)-n:]), nil
+}
+
+// Mid returns n characters of text starting at 1-based position.
+func Mid(args ...any) (any, error) {
+	if err := checkArgs(args, 3, 3); err != nil {
+		return nil, err
+	}
+	s, err := text(args[0])
+	if err != nil {
+		return nil, err
+	}
+	start, err := position(args[1])
+	if err != nil {
+		return nil, err
+	}
+	n, err := count(args[2])
+	if err != nil {
+		return nil, err
+	}
+	runes := []rune(s)
+	if start > len(runes) {
+		return "", nil
+	}
+	end := start - 1 + n
+	if end > len(runes) {
+		end = len(runes)
+	}
+	return string(runes[start-1 : end]), nil
+}
+
+// TextLen returns number of characters in text.
+func TextLen(args ...any) (any, error) {
+	if err := checkArgs(args, 1, 1); err != nil {
+		return nil, err
+	}
+	s, err := text(args[0])
+	if err != nil {
+		return nil, err
+	}
+	return float64(utf8.RuneCountInString(s)), nil
+}
+
+// findArgs parses (find_text; within_text; [start]) args.
+// It returns the text starting at start position and the position.
+func findArgs(args []any) (string, string, int, error) {
+	if err := checkArgs(args, 2, 3); err != nil {
+		return "", "", 0, err
+	}
+	needle, err := text(args[0])
+	if err != nil {
+		return "", "", 0, err
+	}
+	haystack, err := text(args[1])
+	if err != nil {
+		return "", "", 0, err
+	}
+	start := 1
+	if len(args) == 3 {
+		if start, err = position(args[2]); err != nil {
+			return "", "", 0, err
+		}
+	}
+	runes := []rune(haystack)
+	if start > len(runes)+1 {
+		return "", "", 0, errorf(ErrValue, "start %d is beyond text length %d", start, len(runes))
+	}
+	return needle, string(runes[start-1:]), start, nil
+}
+
+// Find returns 1-based position of find_text in within_text, case-sensitive.
+func Find(args ...any) (any, error) {
+	needle, haystack, start, err := findArgs(args)
+	if err != nil {
+		return nil, err
+	}
+	i := strings.Index(haystack, needle)
+	if i < 0 {
+		return nil, errorf(ErrValue, "'%s' not found", needle)
+	}
+	return float64(start + utf8.RuneCountInString(haystack[:i])), nil
+}
+
+// Search returns 1-based position of find_text in within_text, case-insensitive.
+// find_text may contain wildcards: ? matches any character, * matches any
+// sequence of characters and ~ escapes the next character.
+func Search(args ...any) (any, error) {
+	needle, haystack, start, err := findArgs(args)
+	if err != nil {
+		return nil, err
+	}
+	re, err := wildcardRegexp(needle, false)
+	if err != nil {
+		return nil, err
+	}
+	loc := re.FindStringIndex(haystack)
+	if loc == nil {
+		return nil, errorf(ErrValue, "'%s' not found", needle)
+	}
+	return float64(start + utf8.RuneCountInString(haystack[:loc[0]])), nil
+}
+
+// wildcardRegexp compiles case-insensitive Excel wildcard pattern.
+// If whole is true, pattern must match the whole text.
+func wildcardRegexp(pattern string, whole bool) (*regexp.Regexp, error) {
+	var b strings.Builder
+	b.WriteString("(?is)")
+	if whole {
+		b.WriteString("^")
+	}
+	escaped := false
+	for _, r := range pattern {
+		switch {
+		case escaped:
+			b.WriteString(regexp.QuoteMeta(string(r)))
+			escaped = false
+		case r == '~':
+			escaped = true
+		case r == '*':
+			b.WriteString(".*")
+		case r == '?':
+			b.WriteString(".")
+		default:
+			b.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+	if escaped {
+		b.WriteString("~")
+	}
+	if whole {
+		b.WriteString("$")
+	}
+	return regexp.Compile(b.String())
+}
+
+// Substitute replaces old_text with new_text. If instance is given,
+// only that occurrence is replaced.
+func Substitute(args ...any) (any, error) {
+	if err := checkArgs(args, 3, 4); err != nil {
+		return nil, err
+	}
+	values, err := textArgs(args[:3])
+	if err != nil {
+		return nil, err
+	}
+	s, old, replacement := values[0], values[1], values[2]
+	if old == "" {
+		return s, nil
+	}
+	if len(args) == 3 {
+		return strings.ReplaceAll(s, old, replacement), nil
+	}
+	instance, err := position(args[3])
+	if err != nil {
+		return nil, err
+	}
+	offset := 0
+	for i := 1; ; i++ {
+		j := strings.Index(s[offset:], old)
+		if j < 0 {
+			return s, nil
+		}
+		offset += j
+		if i == instance {
+			return s[:offset] + replacement + s[offset+len(old):], nil
+		}
+		offset += len(old)
+	}
+}
+
+// Replace replaces n characters of text starting at 1-based position with new_text.
+func Replace(args ...any) (any, error) {
+	if err := checkArgs(args, 4, 4); err != nil {
+		return nil, err
+	}
+	s, err := text(args[0])
+	if err != nil {
+		return nil, err
+	}
+	start, err := position(args[1])
+	if err != nil {
+		return nil, err
+	}
+	n, err := count(args[2])
+	if err != nil {
+		return nil, err
+	}
+	replacement, err := text(args[3])
+	if err != nil {
+		return nil, err
+	}
+	runes := []rune(s)
+	from := start - 1
+	if from > len(runes) {
+		from = len(runes)
+	}
+	to := from + n
+	if to > len(runes) {
+		to = len(runes)
+	}
+	return string(runes[:from]) + replacement + string(runes[to:]), nil
+}
+
+// Trim removes leading and trailing spaces and collapses inner spaces to one.
+func Trim(args ...any) (any, error) {
+	return mapText(args, func(s string) string {
+		return strings.Join(strings.FieldsFunc(s, func(r rune) bool {
+			return r == ' '
+		}), " ")
+	})
+}
+
+func Upper(args ...any) (any, error) {
+	return mapText(args, strings.ToUpper)
+}
+
+func Lower(args ...any) (any, error) {
+	return mapText(args, strings.ToLower)
+}
+
+// Proper capitalizes the first letter of every word.
+func Proper(args ...any) (any, error) {
+	return mapText(args, func(s string) string {
+		runes := []rune(s)
+		prevLetter := false
+		for i, r := range runes {
+			if prevLetter {
+				runes[i] = unicode.ToLower(r)
+			} else {
+				runes[i] = unicode.ToUpper(r)
+			}
+			prevLetter = unicode.IsLetter(r)
+		}
+		return string(runes)
+	})
+}
+
+// Clean removes non-printable characters.
+func Clean(args ...any) (any, error) {
+	return mapText(args, func(s string) string {
+		return strings.Map(func(r rune) rune {
+			if r < 32 || r == 127 {
+				return -1
+			}
+			return r
+		}, s)
+	})
+}
+
+func mapText(args []any, fn func(string) string) (any, error) {
+	if err := checkArgs(args, 1, 1); err != nil {
+		return nil, err
+	}
+	s, err := text(args[0])
+	if err != nil {
+		return nil, err
+	}
+	return fn(s), nil
+}
+
+// Rept repeats text n times.
+func Rept(args ...any) (any, error) {
+	if err := checkArgs(args, 2, 2); err != nil {
+		return nil, err
+	}
+	s, err := text(args[0])
+	if err != nil {
+		return nil, err
+	}
+	n, err := count(args[1])
+	if err != nil {
+		return nil, err
+	}
+	if len(s) != 0 && n > maxTextLength/len(s) {
+		return nil, errorf(ErrValue, "result is longer than %d bytes", maxTextLength)
+	}
+	return strings.Repeat(s, n), nil
+}
+
+// maxTextLength limits length of text produced by Rept.
+const maxTextLength = 32767
+
+// Exact reports whether two texts are identical, case-sensitive.
+func Exact(args ...any) (any, error) {
+	if err := checkArgs(args, 2, 2); err != nil {
+		return nil, err
+	}
+	values, err := textArgs(args)
+	if err != nil {
+		return nil, err
+	}
+	return values[0] == values[1], nil
+}
+
+// TextJoin joins texts and arrays of texts with delimiter,
+// empty texts are skipped if ignore_empty is true.
+func TextJoin(args ...any) (any, error) {
+	if err := checkArgs(args, 3, -1); err != nil {
+		return nil, err
+	}
+	delimiter, err := text(args[0])
+	if err != nil {
+		return nil, err
+	}
+	ignoreEmpty, err := boolean(args[1])
+	if err != nil {
+		return nil, err
+	}
+	values, err := texts(args[2:]...)
+	if err != nil {
+		return nil, err
+	}
+	if ignoreEmpty {
+		nonEmpty := values[:0]
+		for _, val := range values {
+			if val != "" {
+				nonEmpty = append(nonEmpty, val)
+			}
+		}
+		values = nonEmpty
+	}
+	return strings.Join(values, delimiter), nil
+}
+
+// TextSplit splits text by column delimiter into an array. If row delimiter
+// is given and not empty, the result is an array of rows. Empty values
+// are skipped if ignore_empty is true.
+func TextSplit(args ...any) (any, error) {
+	if err := checkArgs(args, 2, 4); err != nil {
+		return nil, err
+	}
+	values, err := textArgs(args[:2])
+	if err != nil {
+		return nil, err
+	}
+	s, colDelimiter := values[0], values[1]
+	var rowDelimiter string
+	if len(args) >= 3 {
+		if rowDelimiter, err = text(args[2]); err != nil {
+			return nil, err
+		}
+	}
+	var ignoreEmpty bool
+	if len(args) == 4 {
+		if ignoreEmpty, err = boolean(args[3]); err != nil {
+			return nil, err
+		}
+	}
+	split := func(s string) []any {
+		parts := []string{s}
+		if colDelimiter != "" {
+			parts = strings.Split(s, colDelimiter)
+		}
+		result := make([]any, 0, len(parts))
+		for _, part := range parts {
+			if ignoreEmpty && part == "" {
+				continue
+			}
+			result = append(result, part)
+		}
+		return result
+	}
+	if rowDelimiter == "" {
+		return split(s), nil
+	}
+	rows := make([]any, 0)
+	for _, row := range strings.Split(s, rowDelimiter) {
+		if ignoreEmpty && row == "" {
+			continue
+		}
+		rows = append(rows, split(row))
+	}
+	return rows, nil
+}
+
+var textDefinitions = []Definition{
+	{
+		Name:        "LEFT",
+		Func:        Left,
+		Signature:   Signatures["Left"],
+		Params:      []string{"text", "count"},
+		Description: "Returns the first characters of text.",
+		Examples:    []string{`LEFT("hello"; 2) = "he"`},
+		Category:    CategoryText,
+		Pure:        true,
+	},
+	{
+		Name:        "RIGHT",
+		Func:        Right,
+		Signature:   Signatures["Right"],
+		Params:      []string{"text", "count"},
+		Description: "Returns the last characters of text.",
+		Examples:    []string{`RIGHT("hello"; 2) = "lo"`},
+		Category:    CategoryText,
+		Pure:        true,
+	},
+	{
+		Name:        "MID",
+		Func:        Mid,
+		Signature:   Signatures["Mid"],
+		Params:      []string{"text", "start", "count"},
+		Description: "Returns characters from the middle of text.",
+		Examples:    []string{`MID("hello"; 2; 3) = "ell"`},
+		Category:    CategoryText,
+		Pure:        true,
+	},
+	{
+		Name:        "LEN",
+		Func:        TextLen,
+		Signature:   Signatures["TextLen"],
+		Params:      []string{"text"},
+		Description: "Returns the number of characters in text.",
+		Examples:    []string{`LEN("привет") = 6`},
+		Category:    CategoryText,
+		Pure:        true,
+	},
+	{
+		Name:        "FIND",
+		Func:        Find,
+		Signature:   Signatures["Find"],
+		Params:      []string{"find_text", "within_text", "start"},
+		Description: "Returns position of one text within another, case-sensitive.",
+		Examples:    []string{`FIND("l"; "hello") = 3`},
+		Category:    CategoryText,
+		Pure:        true,
+	},
+	{
+		Name:        "SEARCH",
+		Func:        Search,
+		Signature:   Signatures["Search"],
+		Params:      []string{"find_text", "within_text", "start"},
+		Description: "Returns position of one text within another, case-insensitive, with ? and * wildcards.",
+		Examples:    []string{`SEARCH("L?O"; "hello") = 3`},
+		Category:    CategoryText,
+		Pure:        true,
+	},
+	{
+		Name:        "SUBSTITUTE",
+		Func:        Substitute,
+		Signature:   Signatures["Substitute"],
+		Params:      []string{"text", "old_text", "new_text", "instance"},
+		Description: "Replaces occurrences of old text with new text.",
+		Examples:    []string{`SUBSTITUTE("a-b-c"; "-"; "+"; 2) = "a-b+c"`},
+		Category:    CategoryText,
+		Pure:        true,
+	},
+	{
+		Name:        "REPLACE",
+		Func:        Replace,
+		Signature:   Signatures["Replace"],
+		Params:      []string{"text", "start", "count", "new_text"},
+		Description: "Replaces characters of text at the given position.",
+		Examples:    []string{`REPLACE("hello"; 1; 1; "J") = "Jello"`},
+		Category:    CategoryText,
+		Pure:        true,
+	},
+	{
+		Name:        "TRIM",
+		Func:        Trim,
+		Signature:   Signatures["Trim"],
+		Params:      []string{"text"},
+		Description: "Removes extra spaces from text.",
+		Examples:    []string{`TRIM("  a   b ") = "a b"`},
+		Category:    CategoryText,
+		Pure:        true,
+	},
+	{
+		Name:        "UPPER",
+		Func:        Upper,
+		Signature:   Signatures["Upper"],
+		Params:      []string{"text"},
+		Description: "Converts text to upper case.",
+		Examples:    []string{`UPPER("abc") = "ABC"`},
+		Category:    CategoryText,
+		Pure:        true,
+	},
+	{
+		Name:        "LOWER",
+		Func:        Lower,
+		Signature:   Signatures["Lower"],
+		Params:      []string{"text"},
+		Description: "Converts text to lower case.",
+		Examples:    []string{`LOWER("ABC") = "abc"`},
+		Category:    CategoryText,
+		Pure:        true,
+	},
+	{
+		Name:        "PROPER",
+		Func:        Proper,
+		Signature:   Signatures["Proper"],
+		Params:      []string{"text"},
+		Description: "Capitalizes the first letter of every word.",
+		Examples:    []string{`PROPER("hello world") = "Hello World"`},
+		Category:    CategoryText,
+		Pure:        true,
+	},
+	{
+		Name:        "CLEAN",
+		Func:        Clean,
+		Signature:   Signatures["Clean"],
+		Params:      []string{"text"},
+		Description: "Removes non-printable characters from text.",
+		Examples:    []string{`CLEAN(text)`},
+		Category:    CategoryText,
+		Pure:        true,
+	},
+	{
+		Name:        "REPT",
+		Func:        Rept,
+		Signature:   Signatures["Rept"],
+		Params:      []string{"text", "count"},
+		Description: "Repeats text the given number of times.",
+		Examples:    []string{`REPT("ab"; 3) = "ababab"`},
+		Category:    CategoryText,
+		Pure:        true,
+	},
+	{
+		Name:        "EXACT",
+		Func:        Exact,
+		Signature:   Signatures["Exact"],
+		Params:      []string{"text1", "text2"},
+		Description: "Checks whether two texts are identical, case-sensitive.",
+		Examples:    []string{`EXACT("a"; "A") = FALSE`},
+		Category:    CategoryText,
+		Pure:        true,
+	},
+	{
+		Name:        "TEXTJOIN",
+		Func:        TextJoin,
+		Signature:   Signatures["TextJoin"],
+		Params:      []string{"delimiter", "ignore_empty", "text", "text"},
+		Description: "Joins texts and arrays with a delimiter.",
+		Examples:    []string{`TEXTJOIN(", "; 1=1; "a"; ""; "b") = "a, b"`},
+		Category:    CategoryText,
+		Pure:        true,
+	},
+	{
+		Name:        "TEXTSPLIT",
+		Func:        TextSplit,
+		Signature:   Signatures["TextSplit"],
+		Params:      []string{"text", "col_delimiter", "row_delimiter", "ignore_empty"},
+		Description: "Splits text into an array by delimiters.",
+		Examples:    []string{`TEXTSPLIT("a,b"; ",")`},
+		Category:    CategoryText,
+		Pure:        true,
+	},
+}
@@ -0,0 +1,107 @@
+package functions
+
+import (
+	"errors"
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestText(t *testing.T) {
+	cases := []struct {
+		name     string
+		fn       func(args ...any) (any, error)
+		args     []any
+		expected any
+	}{
+		{"Left", Left, []any{"привет"}, "п"},
+		{"Left", Left, []any{"привет", 3.}, "при"},
+		{"Left", Left, []any{"ab", 10.}, "ab"},
+		{"Left", Left, []any{"abc", 1e20}, "abc"},
+		{"Right", Right, []any{"abc", 1e20}, "abc"},
+		{"Mid", Mid, []any{"abc", 2., 1e20}, "bc"},
+		{"Mid", Mid, []any{"abc", 1e20, 1e20}, ""},
+		{"Replace", Replace, []any{"abc", 2., 1e20, "x"}, "ax"},
+		{"Replace", Replace, []any{"abc", 1e20, 1., "x"}, "abcx"},
+		{"Rept", Rept, []any{"", 1e20}, ""},
+		{"Right", Right, []any{"привет", 2.}, "ет"},
+		{"Mid", Mid, []any{"привет", 2., 3.}, "рив"},
+		{"Mid", Mid, []any{"abc", 5., 3.}, ""},
+		{"TextLen", TextLen, []any{"привет"}, 6.},
+		{"TextLen", TextLen, []any{12.5}, 4.},
+		{"TextLen", TextLen, []any{MustDecimal("12.50")}, 4.},
+		{"Find", Find, []any{"е", "привет"}, 5.},
+		{"Find", Find, []any{"l", "hello", 4.}, 4.},
+		{"Search", Search, []any{"L?O", "hello"}, 3.},
+		{"Search", Search, []any{"*l", "hello"}, 1.},
+		{"Search", Search, []any{"~?", "what?"}, 5.},
+		{"Substitute", Substitute, []any{"a-b-c", "-", "+"}, "a+b+c"},
+		{"Substitute", Substitute, []any{"a-b-c", "-", "+", 2.}, "a-b+c"},
+		{"Substitute", Substitute, []any{"a-b-c", "-", "+", 3.}, "a-b-c"},
+		{"Replace", Replace, []any{"hello", 1., 1., "J"}, "Jello"},
+		{"Replace", Replace, []any{"abc", 10., 1., "d"}, "abcd"},
+		{"Trim", Trim, []any{"  a   b "}, "a b"},
+		{"Upper", Upper, []any{"abc"}, "ABC"},
+		{"Lower", Lower, []any{"ABC"}, "abc"},
+		{"Proper", Proper, []any{"hello WORLD o'neil"}, "Hello World O'Neil"},
+		{"Clean", Clean, []any{"a\tb\x01"}, "ab"},
+		{"Rept", Rept, []any{"ab", 3.}, "ababab"},
+		{"Exact", Exact, []any{"a", "A"}, false},
+		{"Exact", Exact, []any{"1", 1.}, true},
+		{"TextJoin", TextJoin, []any{", ", true, "a", "", []any{"b", 1.}}, "a, b, 1"},
+		{"TextJoin", TextJoin, []any{"-", false, "a", ""}, "a-"},
+		{"TextSplit", TextSplit, []any{"a,b,,c", ","}, []any{"a", "b", "", "c"}},
+		{"TextSplit", TextSplit, []any{"a,b;c", ",", ";"}, []any{[]any{"a", "b"}, []any{"c"}}},
+		{"TextSplit", TextSplit, []any{"a,,b", ",", "", true}, []any{"a", "b"}},
+	}
+	for _, c := range cases {
+		res, err := c.fn(c.args...)
+		if err != nil {
+			t.Errorf("%s%v: expected nil error, got '%v'", c.name, c.args, err)
+			continue
+		}
+		if !reflect.DeepEqual(res, c.expected) {
+			t.Errorf("%s%v: expected %v, got %v", c.name, c.args, c.expected, res)
+		}
+	}
+}
+
+func TestText_Errors(t *testing.T) {
+	if _, err := Find("x", "hello"); !errors.Is(err, ErrValue) {
+		t.Errorf("expected #VALUE! error, got '%v'", err)
+	}
+	if _, err := Mid("abc", 0., 1.); !errors.Is(err, ErrValue) {
+		t.Errorf("expected #VALUE! error, got '%v'", err)
+	}
+	if _, err := Left("abc", -1.); !errors.Is(err, ErrValue) {
+		t.Errorf("expected #VALUE! error, got '%v'", err)
+	}
+	nan, inf := math.NaN(), math.Inf(1)
+	invalid := []struct {
+		name string
+		fn   func(args ...any) (any, error)
+		args []any
+	}{
+		{"Left", Left, []any{"abc", nan}},
+		{"Left", Left, []any{"abc", inf}},
+		{"Right", Right, []any{"abc", nan}},
+		{"Mid", Mid, []any{"abc", nan, 1.}},
+		{"Mid", Mid, []any{"abc", 1., inf}},
+		{"Replace", Replace, []any{"abc", inf, 1., "x"}},
+		{"Find", Find, []any{"a", "abc", nan}},
+		{"Search", Search, []any{"a", "abc", inf}},
+		{"Rept", Rept, []any{"ab", 1e19}},
+		{"Rept", Rept, []any{"ab", nan}},
+	}
+	for _, c := range invalid {
+		if _, err := c.fn(c.args...); !errors.Is(err, ErrValue) {
+			t.Errorf("%s%v: expected #VALUE! error, got '%v'", c.name, c.args, err)
+		}
+	}
+	if _, err := Find("a", "abc", 1e20); !errors.Is(err, ErrValue) {
+		t.Errorf("expected #VALUE! error, got '%v'", err)
+	}
+	if _, err := Upper(); err == nil {
+		t.Errorf("expected args error")
+	}
+}
@@ -0,0 +1,231 @@
+package functions
+
+import "math"
+
+// domain wraps a function defined for numbers from min to max inclusive.
+func domain(fn func(float64) float64, min, max float64) func(float64) (float64, error) {
+	return func(x float64) (float64, error) {
+		if x < min || x > max {
+			return 0, errorf(ErrNum, "argument %v is out of range [%v; %v]", x, min, max)
+		}
+		return fn(x), nil
+	}
+}
+
+var (
+	// Sin returns sine of an angle in radians.
+	Sin = numberFunc(anyNumber(math.Sin))
+	// Cos returns cosine of an angle in radians.
+	Cos = numberFunc(anyNumber(math.Cos))
+	// Tan returns tangent of an angle in radians.
+	Tan = numberFunc(anyNumber(math.Tan))
+	// Asin returns arcsine of a number in radians.
+	Asin = numberFunc(domain(math.Asin, -1, 1))
+	// Acos returns arccosine of a number in radians.
+	Acos = numberFunc(domain(math.Acos, -1, 1))
+	// Atan returns arctangent of a number in radians.
+	Atan = numberFunc(anyNumber(math.Atan))
+	// Sinh returns hyperbolic sine of a number.
+	Sinh = numberFunc(anyNumber(math.Sinh))
+	// Cosh returns hyperbolic cosine of a number.
+	Cosh = numberFunc(anyNumber(math.Cosh))
+	// Tanh returns hyperbolic tangent of a number.
+	Tanh = numberFunc(anyNumber(math.Tanh))
+	// Asinh returns inverse hyperbolic sine of a number.
+	Asinh = numberFunc(anyNumber(math.Asinh))
+	// Acosh returns inverse hyperbolic cosine of a number not less than 1.
+	Acosh = numberFunc(domain(math.Acosh, 1, math.Inf(1)))
+	// Atanh returns inverse hyperbolic tangent of a number between -1 and 1 exclusive.
+	Atanh = numberFunc(domain(math.Atanh, math.Nextafter(-1, 0), math.Nextafter(1, 0)))
+	// Degrees converts radians to degrees.
+	Degrees = numberFunc(anyNumber(func(x float64) float64 {
+		return x * 180 / math.Pi
+	}))
+	// Radians converts degrees to radians.
+	Radians = numberFunc(anyNumber(func(x float64) float64 {
+		return x * math.Pi / 180
+	}))
+)
+
+// Atan2 returns arctangent of point coordinates x and y in radians.
+func Atan2(args ...any) (any, error) {
+	x, y, err := twoNumbers(args)
+	if err != nil {
+		return nil, err
+	}
+	if x == 0 && y == 0 {
+		return nil, errorf(ErrDiv0, "both coordinates are zero")
+	}
+	return math.Atan2(y, x), nil
+}
+
+// Pi returns number π.
+func Pi(args ...any) (any, error) {
+	if err := checkArgs(args, 0, 0); err != nil {
+		return nil, err
+	}
+	return math.Pi, nil
+}
+
+var trigDefinitions = []Definition{
+	{
+		Name:        "SIN",
+		Func:        Sin,
+		Signature:   Signatures["Sin"],
+		Params:      []string{"angle"},
+		Description: "Returns the sine of an angle in radians.",
+		Examples:    []string{"SIN(PI() / 2) = 1"},
+		Category:    CategoryMath,
+		Pure:        true,
+	},
+	{
+		Name:        "COS",
+		Func:        Cos,
+		Signature:   Signatures["Cos"],
+		Params:      []string{"angle"},
+		Description: "Returns the cosine of an angle in radians.",
+		Examples:    []string{"COS(0) = 1"},
+		Category:    CategoryMath,
+		Pure:        true,
+	},
+	{
+		Name:        "TAN",
+		Func:        Tan,
+		Signature:   Signatures["Tan"],
+		Params:      []string{"angle"},
+		Description: "Returns the tangent of an angle in radians.",
+		Examples:    []string{"TAN(RADIANS(45))"},
+		Category:    CategoryMath,
+		Pure:        true,
+	},
+	{
+		Name:        "ASIN",
+		Func:        Asin,
+		Signature:   Signatures["Asin"],
+		Params:      []string{"number"},
+		Description: "Returns the arcsine of a number in radians.",
+		Examples:    []string{"DEGREES(ASIN(0,5)) = 30"},
+		Category:    CategoryMath,
+		Pure:        true,
+	},
+	{
+		Name:        "ACOS",
+		Func:        Acos,
+		Signature:   Signatures["Acos"],
+		Params:      []string{"number"},
+		Description: "Returns the arccosine of a number in radians.",
+		Examples:    []string{"ACOS(1) = 0"},
+		Category:    CategoryMath,
+		Pure:        true,
+	},
+	{
+		Name:        "ATAN",
+		Func:        Atan,
+		Signature:   Signatures["Atan"],
+		Params:      []string{"number"},
+		Description: "Returns the arctangent of a number in radians.",
+		Examples:    []string{"ATAN(1) = PI() / 4"},
+		Category:    CategoryMath,
+		Pure:        true,
+	},
+	{
+		Name:        "ATAN2",
+		Func:        Atan2,
+		Signature:   Signatures["Atan2"],
+		Params:      []string{"x", "y"},
+		Description: "Returns the arctangent of point coordinates in radians.",
+		Examples:    []string{"ATAN2(-1; -1)"},
+		Category:    CategoryMath,
+		Pure:        true,
+	},
+	{
+		Name:        "SINH",
+		Func:        Sinh,
+		Signature:   Signatures["Sinh"],
+		Params:      []string{"number"},
+		Description: "Returns the hyperbolic sine of a number.",
+		Examples:    []string{"SINH(0) = 0"},
+		Category:    CategoryMath,
+		Pure:        true,
+	},
+	{
+		Name:        "COSH",
+		Func:        Cosh,
+		Signature:   Signatures["Cosh"],
+		Params:      []string{"number"},
+		Description: "Returns the hyperbolic cosine of a number.",
+		Examples:    []string{"COSH(0) = 1"},
+		Category:    CategoryMath,
+		Pure:        true,
+	},
+	{
+		Name:        "TANH",
+		Func:        Tanh,
+		Signature:   Signatures["Tanh"],
+		Params:      []string{"number"},
+		Description: "Returns the hyperbolic tangent of a number.",
+		Examples:    []string{"TANH(0) = 0"},
+		Category:    CategoryMath,
+		Pure:        true,
+	},
+	{
+		Name:        "ASINH",
+		Func:        Asinh,
+		Signature:   Signatures["Asinh"],
+		Params:      []string{"number"},
+		Description: "Returns the inverse hyperbolic sine of a number.",
+		Examples:    []string{"ASINH(0) = 0"},
+		Category:    CategoryMath,
+		Pure:        true,
+	},
+	{
+		Name:        "ACOSH",
+		Func:        Acosh,
+		Signature:   Signatures["Acosh"],
+		Params:      []string{"number"},
+		Description: "Returns the inverse hyperbolic cosine of a number.",
+		Examples:    []string{"ACOSH(1) = 0"},
+		Category:    CategoryMath,
+		Pure:        true,
+	},
+	{
+		Name:        "ATANH",
+		Func:        Atanh,
+		Signature:   Signatures["Atanh"],
+		Params:      []string{"number"},
+		Description: "Returns the inverse hyperbolic tangent of a number.",
+		Examples:    []string{"ATANH(0) = 0"},
+		Category:    CategoryMath,
+		Pure:        true,
+	},
+	{
+		Name:        "PI",
+		Func:        Pi,
+		Signature:   Signatures["Pi"],
+		Params:      []string{},
+		Description: "Returns the number π.",
+		Examples:    []string{"PI() * R^2"},
+		Category:    CategoryMath,
+		Pure:        true,
+	},
+	{
+		Name:        "DEGREES",
+		Func:        Degrees,
+		Signature:   Signatures["Degrees"],
+		Params:      []string{"angle"},
+		Description: "Converts radians to degrees.",
+		Examples:    []string{"DEGREES(PI()) = 180"},
+		Category:    CategoryMath,
+		Pure:        true,
+	},
+	{
+		Name:        "RADIANS",
+		Func:        Radians,
+		Signature:   Signatures["Radians"],
+		Params:      []string{"angle"},
+		Description: "Converts degrees to radians.",
+		Examples:    []string{"RADIANS(180) = PI()"},
+		Category:    CategoryMath,
+		Pure:        true,
+	},
+}
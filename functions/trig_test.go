@@ -0,0 +1,70 @@
+package functions
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestTrig(t *testing.T) {
+	cases := []struct {
+		name     string
+		fn       func(args ...any) (any, error)
+		args     []any
+		expected float64
+	}{
+		{"Sin", Sin, []any{math.Pi / 2}, 1},
+		{"Cos", Cos, []any{math.Pi}, -1},
+		{"Tan", Tan, []any{math.Pi / 4}, 1},
+		{"Asin", Asin, []any{0.5}, math.Pi / 6},
+		{"Acos", Acos, []any{-1.}, math.Pi},
+		{"Atan", Atan, []any{1.}, math.Pi / 4},
+		{"Atan2", Atan2, []any{-1., -1.}, -3 * math.Pi / 4},
+		{"Atan2", Atan2, []any{0., 1.}, math.Pi / 2},
+		{"Sinh", Sinh, []any{1.}, 1.1752011936},
+		{"Cosh", Cosh, []any{1.}, 1.5430806348},
+		{"Tanh", Tanh, []any{0.5}, 0.4621171573},
+		{"Asinh", Asinh, []any{-2.5}, -1.6472311464},
+		{"Acosh", Acosh, []any{10.}, 2.9932228461},
+		{"Atanh", Atanh, []any{-0.1}, -0.1003353477},
+		{"Pi", Pi, nil, 3.1415926536},
+		{"Degrees", Degrees, []any{math.Pi}, 180},
+		{"Radians", Radians, []any{270.}, 4.7123889804},
+	}
+	for _, c := range cases {
+		res, err := c.fn(c.args...)
+		if err != nil {
+			t.Fatalf("%s%v: expected nil error, got %s", c.name, c.args, err)
+		}
+		if math.Abs(res.(float64)-c.expected) > 1e-9 {
+			t.Errorf("%s%v: expected %v, got %v", c.name, c.args, c.expected, res)
+		}
+	}
+}
+
+func TestTrig_Errors(t *testing.T) {
+	cases := []struct {
+		name string
+		fn   func(args ...any) (any, error)
+		args []any
+		code ErrorCode
+	}{
+		{"Asin", Asin, []any{2.}, ErrNum},
+		{"Acos", Acos, []any{-1.5}, ErrNum},
+		{"Acosh", Acosh, []any{0.5}, ErrNum},
+		{"Atanh", Atanh, []any{1.}, ErrNum},
+		{"Atan2", Atan2, []any{0., 0.}, ErrDiv0},
+	}
+	for _, c := range cases {
+		_, err := c.fn(c.args...)
+		if !errors.Is(err, c.code) {
+			t.Errorf("%s%v: expected %s, got %v", c.name, c.args, c.code, err)
+		}
+	}
+	if _, err := Sin("a"); err == nil || err.Error() != "expected float64, got string" {
+		t.Errorf("expected argument type error, got %v", err)
+	}
+	if _, err := Pi(1.); err == nil || err.Error() != "expected 0 args, got 1" {
+		t.Errorf("expected arity error, got %v", err)
+	}
+}
@@ -0,0 +1,441 @@
+package functions
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Dimension is a vector of exponents of base quantities:
+// length, mass, time, electric current, temperature, amount of substance,
+// luminous intensity and information.
+type Dimension [8]int8
+
+const (
+	dimLength = iota
+	dimMass
+	dimTime
+	dimCurrent
+	dimTemperature
+	dimAmount
+	dimLuminosity
+	dimInformation
+)
+
+// baseDim returns dimension of the i-th base quantity raised to power n.
+func baseDim(i int, n int8) Dimension {
+	var d Dimension
+	d[i] = n
+	return d
+}
+
+func (d Dimension) mul(e Dimension, sign int8) Dimension {
+	for i := range d {
+		d[i] += sign * e[i]
+	}
+	return d
+}
+
+func (d Dimension) pow(n int8) Dimension {
+	for i := range d {
+		d[i] *= n
+	}
+	return d
+}
+
+// IsZero reports whether the dimension is dimensionless.
+func (d Dimension) IsZero() bool {
+	return d == Dimension{}
+}
+
+// A Unit is a unit of measure. Value v in the unit is v*Factor+Offset
+// in base SI units of the dimension.
+type Unit struct {
+	Symbol string
+	Factor float64
+	Offset float64 // non-zero only for temperature scales like °C and °F
+	Dim    Dimension
+}
+
+// Compatible reports whether values in units u and v may be converted to each other.
+func (u Unit) Compatible(v Unit) bool {
+	return u.Dim == v.Dim
+}
+
+// compound wraps symbol of compound unit into parentheses.
+func (u Unit) compound() string {
+	if strings.ContainsAny(u.Symbol, "*/^") {
+		return "(" + u.Symbol + ")"
+	}
+	return u.Symbol
+}
+
+// Mul returns product of units.
+func (u Unit) Mul(v Unit) (Unit, error) {
+	return u.combine(v, "*", 1)
+}
+
+// Div returns quotient of units.
+func (u Unit) Div(v Unit) (Unit, error) {
+	return u.combine(v, "/", -1)
+}
+
+func (u Unit) combine(v Unit, op string, sign int8) (Unit, error) {
+	if u.Offset != 0 || v.Offset != 0 {
+		return Unit{}, errorf(ErrValue, "can't combine %s and %s: unit with offset", u.Symbol, v.Symbol)
+	}
+	factor := u.Factor * v.Factor
+	if sign < 0 {
+		factor = u.Factor / v.Factor
+	}
+	symbol := u.Symbol
+	if symbol == "" {
+		symbol = "1"
+	}
+	return Unit{
+		Symbol: symbol + op + v.compound(),
+		Factor: factor,
+		Dim:    u.Dim.mul(v.Dim, sign),
+	}, nil
+}
+
+// Pow returns unit raised to integer power n.
+func (u Unit) Pow(n int) (Unit, error) {
+	if u.Offset != 0 {
+		return Unit{}, errorf(ErrValue, "can't raise %s to a power: unit with offset", u.Symbol)
+	}
+	if n < -127 || n > 127 {
+		return Unit{}, errorf(ErrNum, "power %d of unit %s is out of range", n, u.Symbol)
+	}
+	factor := 1.
+	for i := 0; i < abs(n); i++ {
+		factor *= u.Factor
+	}
+	if n < 0 {
+		factor = 1 / factor
+	}
+	return Unit{
+		Symbol: u.compound() + "^" + strconv.Itoa(n),
+		Factor: factor,
+		Dim:    u.Dim.pow(int8(n)),
+	}, nil
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+// prefixKind is a kind of prefixes a unit accepts.
+type prefixKind uint8
+
+const (
+	noPrefixes prefixKind = iota
+	decimalPrefixes
+	allPrefixes // decimal and binary prefixes of information units
+)
+
+type prefix struct {
+	symbol string
+	factor float64
+}
+
+// Prefixes are sorted so that two-letter ones are matched first.
+var (
+	metricPrefixes = []prefix{
+		{"da", 1e1}, {"Y", 1e24}, {"Z", 1e21}, {"E", 1e18}, {"P", 1e15},
+		{"T", 1e12}, {"G", 1e9}, {"M", 1e6}, {"k", 1e3}, {"h", 1e2}, {"e", 1e1},
+		{"d", 1e-1}, {"c", 1e-2}, {"m", 1e-3}, {"u", 1e-6}, {"n", 1e-9},
+		{"p", 1e-12}, {"f", 1e-15}, {"a", 1e-18}, {"z", 1e-21}, {"y", 1e-24},
+	}
+	binaryPrefixes = []prefix{
+		{"ki", 1 << 10}, {"Mi", 1 << 20}, {"Gi", 1 << 30}, {"Ti", 1 << 40},
+		{"Pi", 1 << 50}, {"Ei", 1 << 60}, {"Zi", 1 << 70}, {"Yi", 1 << 80},
+	}
+)
+
+type unitEntry struct {
+	unit     Unit
+	prefixes prefixKind
+}
+
+// Units is a table of units of measure. Units may be combined in expressions
+// like "kg*m/s^2", metric units accept prefixes like "km" and length units
+// accept area and volume suffixes like "m2" and "ft3". Units is safe for
+// concurrent use.
+type Units struct {
+	mu      sync.RWMutex
+	entries map[string]unitEntry
+}
+
+// NewUnits returns table of SI and imperial units supported by Excel CONVERT.
+func NewUnits() *Units {
+	u := &Units{entries: make(map[string]unitEntry, len(standardUnits))}
+	for _, el := range standardUnits {
+		dim := el.derived
+		if el.dim >= 0 {
+			dim = baseDim(el.dim, 1)
+		}
+		for _, symbol := range strings.Split(el.symbols, " ") {
+			u.entries[symbol] = unitEntry{
+				unit:     Unit{Symbol: symbol, Factor: el.factor, Offset: el.offset, Dim: dim},
+				prefixes: el.prefixes,
+			}
+		}
+	}
+	return u
+}
+
+// Define adds unit symbol equal to factor units of base expression,
+// for example Define("furlong", 201.168, "m").
+func (u *Units) Define(symbol string, factor float64, base string) error {
+	if symbol == "" || strings.ContainsAny(symbol, "*/^ ") {
+		return fmt.Errorf("invalid unit symbol '%s'", symbol)
+	}
+	unit, err := u.Parse(base)
+	if err != nil {
+		return err
+	}
+	if unit.Offset != 0 {
+		return fmt.Errorf("can't define unit '%s' based on unit with offset", symbol)
+	}
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	if _, ok := u.entries[symbol]; ok {
+		return fmt.Errorf("unit '%s' is already defined", symbol)
+	}
+	u.entries[symbol] = unitEntry{unit: Unit{Symbol: symbol, Factor: factor * unit.Factor, Dim: unit.Dim}}
+	return nil
+}
+
+// Parse returns unit by symbol or expression of units joined by "*" and "/"
+// and raised to integer powers with "^". Unknown units are #N/A errors.
+func (u *Units) Parse(expr string) (Unit, error) {
+	if unit, ok := u.lookup(expr); ok {
+		return unit, nil
+	}
+	if !strings.ContainsAny(expr, "*/^") {
+		return Unit{}, errorf(ErrNA, "unknown unit '%s'", expr)
+	}
+	var res Unit
+	op := byte('*')
+	for i, start := 0, 0; i <= len(expr); i++ {
+		if i < len(expr) && expr[i] != '*' && expr[i] != '/' {
+			continue
+		}
+		unit, err := u.parseTerm(expr[start:i])
+		if err != nil {
+			return Unit{}, err
+		}
+		switch {
+		case start == 0:
+			res = unit
+		case op == '*':
+			res, err = res.Mul(unit)
+		default:
+			res, err = res.Div(unit)
+		}
+		if err != nil {
+			return Unit{}, err
+		}
+		if i < len(expr) {
+			op, start = expr[i], i+1
+		}
+	}
+	res.Symbol = expr
+	return res, nil
+}
+
+// parseTerm parses unit optionally raised to power: "s^2".
+func (u *Units) parseTerm(term string) (Unit, error) {
+	symbol, power := term, 1
+	if i := strings.IndexByte(term, '^'); i >= 0 {
+		n, err := strconv.Atoi(term[i+1:])
+		if err != nil {
+			return Unit{}, errorf(ErrNA, "invalid power in unit '%s'", term)
+		}
+		symbol, power = term[:i], n
+	}
+	unit, ok := u.lookup(symbol)
+	if !ok {
+		return Unit{}, errorf(ErrNA, "unknown unit '%s'", symbol)
+	}
+	if power == 1 {
+		return unit, nil
+	}
+	return unit.Pow(power)
+}
+
+// lookup finds unit by symbol, possibly with prefix or area and volume suffix.
+func (u *Units) lookup(symbol string) (Unit, bool) {
+	u.mu.RLock()
+	defer u.mu.RUnlock()
+	if unit, ok := u.prefixed(symbol); ok {
+		return unit, true
+	}
+	if n := len(symbol); n > 1 && (symbol[n-1] == '2' || symbol[n-1] == '3') {
+		unit, ok := u.prefixed(symbol[:n-1])
+		if ok && unit.Dim == baseDim(dimLength, 1) {
+			unit, err := unit.Pow(int(symbol[n-1] - '0'))
+			if err == nil {
+				unit.Symbol = symbol
+				return unit, true
+			}
+		}
+	}
+	return Unit{}, false
+}
+
+// prefixed finds unit by symbol or by prefix and symbol.
+func (u *Units) prefixed(symbol string) (Unit, bool) {
+	if entry, ok := u.entries[symbol]; ok {
+		return entry.unit, true
+	}
+	find := func(prefixes []prefix, kind prefixKind) (Unit, bool) {
+		for _, p := range prefixes {
+			if !strings.HasPrefix(symbol, p.symbol) {
+				continue
+			}
+			entry, ok := u.entries[symbol[len(p.symbol):]]
+			if !ok || entry.prefixes < kind {
+				continue
+			}
+			unit := entry.unit
+			unit.Symbol = symbol
+			unit.Factor *= p.factor
+			return unit, true
+		}
+		return Unit{}, false
+	}
+	if unit, ok := find(binaryPrefixes, allPrefixes); ok {
+		return unit, true
+	}
+	return find(metricPrefixes, decimalPrefixes)
+}
+
+var (
+	velocity = baseDim(dimLength, 1).mul(baseDim(dimTime, 1), -1)
+	area     = baseDim(dimLength, 2)
+	volume   = baseDim(dimLength, 3)
+	force    = baseDim(dimMass, 1).mul(baseDim(dimLength, 1), 1).mul(baseDim(dimTime, 2), -1)
+	energy   = force.mul(baseDim(dimLength, 1), 1)
+	power    = energy.mul(baseDim(dimTime, 1), -1)
+	pressure = force.mul(area, -1)
+	magnetic = baseDim(dimMass, 1).mul(baseDim(dimTime, 2), -1).mul(baseDim(dimCurrent, 1), -1)
+)
+
+// standardUnits are units of Excel CONVERT and SI base units. Units with
+// several symbols list them separated by spaces. dim is index of base
+// quantity or -1 for derived units described by derived.
+var standardUnits = []struct {
+	symbols  string
+	factor   float64
+	offset   float64
+	dim      int
+	derived  Dimension
+	prefixes prefixKind
+}{
+	// mass, in kilograms
+	{symbols: "g", factor: 1e-3, dim: dimMass, prefixes: decimalPrefixes},
+	{symbols: "sg", factor: 14.593902937206364, dim: dimMass},
+	{symbols: "lbm", factor: 0.45359237, dim: dimMass},
+	{symbols: "u", factor: 1.66053906660e-27, dim: dimMass, prefixes: decimalPrefixes},
+	{symbols: "ozm", factor: 0.028349523125, dim: dimMass},
+	{symbols: "grain", factor: 6.479891e-5, dim: dimMass},
+	{symbols: "cwt shweight", factor: 45.359237, dim: dimMass},
+	{symbols: "uk_cwt lcwt hweight", factor: 50.80234544, dim: dimMass},
+	{symbols: "stone", factor: 6.35029318, dim: dimMass},
+	{symbols: "ton", factor: 907.18474, dim: dimMass},
+	{symbols: "uk_ton LTON brton", factor: 1016.0469088, dim: dimMass},
+	// length, in meters
+	{symbols: "m", factor: 1, dim: dimLength, prefixes: decimalPrefixes},
+	{symbols: "mi", factor: 1609.344, dim: dimLength},
+	{symbols: "Nmi", factor: 1852, dim: dimLength},
+	{symbols: "in", factor: 0.0254, dim: dimLength},
+	{symbols: "ft", factor: 0.3048, dim: dimLength},
+	{symbols: "yd", factor: 0.9144, dim: dimLength},
+	{symbols: "ang", factor: 1e-10, dim: dimLength, prefixes: decimalPrefixes},
+	{symbols: "ell", factor: 1.143, dim: dimLength},
+	{symbols: "ly", factor: 9.4607304725808e15, dim: dimLength},
+	{symbols: "parsec pc", factor: 3.0856775812815532e16, dim: dimLength},
+	{symbols: "Picapt Pica", factor: 0.0254 / 72, dim: dimLength},
+	{symbols: "pica", factor: 0.0254 / 6, dim: dimLength},
+	{symbols: "survey_mi", factor: 1609.3472186944373, dim: dimLength},
+	// time, in seconds
+	{symbols: "yr", factor: 31557600, dim: dimTime},
+	{symbols: "day d", factor: 86400, dim: dimTime},
+	{symbols: "hr", factor: 3600, dim: dimTime},
+	{symbols: "mn min", factor: 60, dim: dimTime},
+	{symbols: "sec s", factor: 1, dim: dimTime, prefixes: decimalPrefixes},
+	// temperature, in kelvins
+	{symbols: "C cel", factor: 1, offset: 273.15, dim: dimTemperature},
+	{symbols: "F fah", factor: 5. / 9, offset: 273.15 - 32*5./9, dim: dimTemperature},
+	{symbols: "K kel", factor: 1, dim: dimTemperature, prefixes: decimalPrefixes},
+	{symbols: "Rank", factor: 5. / 9, dim: dimTemperature},
+	{symbols: "Reau", factor: 1.25, offset: 273.15, dim: dimTemperature},
+	// other SI base units
+	{symbols: "A", factor: 1, dim: dimCurrent, prefixes: decimalPrefixes},
+	{symbols: "mol", factor: 1, dim: dimAmount, prefixes: decimalPrefixes},
+	{symbols: "cd", factor: 1, dim: dimLuminosity, prefixes: decimalPrefixes},
+	// information, in bits
+	{symbols: "bit", factor: 1, dim: dimInformation, prefixes: allPrefixes},
+	{symbols: "byte", factor: 8, dim: dimInformation, prefixes: allPrefixes},
+	// speed, in meters per second
+	{symbols: "m/s m/sec", factor: 1, dim: -1, derived: velocity, prefixes: decimalPrefixes},
+	{symbols: "m/h m/hr", factor: 1. / 3600, dim: -1, derived: velocity, prefixes: decimalPrefixes},
+	{symbols: "mph", factor: 0.44704, dim: -1, derived: velocity},
+	{symbols: "kn", factor: 1852. / 3600, dim: -1, derived: velocity},
+	{symbols: "admkn", factor: 1853.184 / 3600, dim: -1, derived: velocity},
+	// area, in square meters
+	{symbols: "ha", factor: 1e4, dim: -1, derived: area},
+	{symbols: "ar", factor: 100, dim: -1, derived: area, prefixes: decimalPrefixes},
+	{symbols: "uk_acre", factor: 4046.8564224, dim: -1, derived: area},
+	{symbols: "us_acre", factor: 4046.872609874252, dim: -1, derived: area},
+	{symbols: "Morgen", factor: 2500, dim: -1, derived: area},
+	// volume, in cubic meters
+	{symbols: "l L lt", factor: 1e-3, dim: -1, derived: volume, prefixes: decimalPrefixes},
+	{symbols: "tsp", factor: 4.92892159375e-6, dim: -1, derived: volume},
+	{symbols: "tspm", factor: 5e-6, dim: -1, derived: volume},
+	{symbols: "tbs", factor: 1.478676478125e-5, dim: -1, derived: volume},
+	{symbols: "oz", factor: 2.95735295625e-5, dim: -1, derived: volume},
+	{symbols: "cup", factor: 2.365882365e-4, dim: -1, derived: volume},
+	{symbols: "pt us_pt", factor: 4.73176473e-4, dim: -1, derived: volume},
+	{symbols: "uk_pt", factor: 5.6826125e-4, dim: -1, derived: volume},
+	{symbols: "qt", factor: 9.46352946e-4, dim: -1, derived: volume},
+	{symbols: "uk_qt", factor: 1.1365225e-3, dim: -1, derived: volume},
+	{symbols: "gal", factor: 3.785411784e-3, dim: -1, derived: volume},
+	{symbols: "uk_gal", factor: 4.54609e-3, dim: -1, derived: volume},
+	{symbols: "barrel", factor: 0.158987294928, dim: -1, derived: volume},
+	{symbols: "bushel", factor: 0.03523907016688, dim: -1, derived: volume},
+	// force, in newtons
+	{symbols: "N", factor: 1, dim: -1, derived: force, prefixes: decimalPrefixes},
+	{symbols: "dyn dy", factor: 1e-5, dim: -1, derived: force, prefixes: decimalPrefixes},
+	{symbols: "lbf", factor: 4.4482216152605, dim: -1, derived: force},
+	{symbols: "pond", factor: 9.80665e-3, dim: -1, derived: force, prefixes: decimalPrefixes},
+	// energy, in joules
+	{symbols: "J", factor: 1, dim: -1, derived: energy, prefixes: decimalPrefixes},
+	{symbols: "e", factor: 1e-7, dim: -1, derived: energy, prefixes: decimalPrefixes},
+	{symbols: "c", factor: 4.184, dim: -1, derived: energy, prefixes: decimalPrefixes},
+	{symbols: "cal", factor: 4.1868, dim: -1, derived: energy, prefixes: decimalPrefixes},
+	{symbols: "eV ev", factor: 1.602176634e-19, dim: -1, derived: energy, prefixes: decimalPrefixes},
+	{symbols: "HPh hh", factor: 2684519.537696173, dim: -1, derived: energy},
+	{symbols: "Wh wh", factor: 3600, dim: -1, derived: energy, prefixes: decimalPrefixes},
+	{symbols: "flb", factor: 1.3558179483314004, dim: -1, derived: energy},
+	{symbols: "BTU btu", factor: 1055.05585262, dim: -1, derived: energy},
+	// power, in watts
+	{symbols: "HP h", factor: 745.6998715822702, dim: -1, derived: power},
+	{symbols: "PS", factor: 735.49875, dim: -1, derived: power},
+	{symbols: "W w", factor: 1, dim: -1, derived: power, prefixes: decimalPrefixes},
+	// pressure, in pascals
+	{symbols: "Pa p", factor: 1, dim: -1, derived: pressure, prefixes: decimalPrefixes},
+	{symbols: "atm at", factor: 101325, dim: -1, derived: pressure, prefixes: decimalPrefixes},
+	{symbols: "mmHg", factor: 133.322387415, dim: -1, derived: pressure, prefixes: decimalPrefixes},
+	{symbols: "psi", factor: 6894.757293168361, dim: -1, derived: pressure},
+	{symbols: "Torr", factor: 101325. / 760, dim: -1, derived: pressure},
+	// magnetism, in teslas
+	{symbols: "T", factor: 1, dim: -1, derived: magnetic, prefixes: decimalPrefixes},
+	{symbols: "ga", factor: 1e-4, dim: -1, derived: magnetic, prefixes: decimalPrefixes},
+}
@@ -0,0 +1,131 @@
+package functions
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestConvert(t *testing.T) {
+	u := &units{table: NewUnits()}
+	cases := []struct {
+		value    float64
+		from, to string
+		expected float64
+	}{
+		{1, "lbm", "kg", 0.45359237},
+		{68, "F", "C", 20},
+		{100, "C", "K", 373.15},
+		{0, "K", "F", -459.67},
+		{1, "mi", "km", 1.609344},
+		{100, "ft2", "m2", 9.290304},
+		{1, "m3", "l", 1000},
+		{1, "gal", "l", 3.785411784},
+		{1, "hr", "mn", 60},
+		{1, "yr", "day", 365.25},
+		{36, "km/h", "m/s", 10},
+		{1, "kbyte", "bit", 8000},
+		{1, "kibyte", "byte", 1024},
+		{1, "atm", "Torr", 760},
+		{1, "kWh", "J", 3.6e6},
+		{1, "kg*m/s^2", "N", 1},
+		{1, "N*m", "J", 1},
+		{1, "mm", "um", 1000},
+		{1, "Nmi", "m", 1852},
+	}
+	for _, c := range cases {
+		res, err := u.Convert(c.value, c.from, c.to)
+		if err != nil {
+			t.Fatalf("CONVERT(%v; %s; %s): expected nil error, got %s", c.value, c.from, c.to, err)
+		}
+		if math.Abs(res.(float64)-c.expected) > 1e-9*math.Max(1, math.Abs(c.expected)) {
+			t.Errorf("CONVERT(%v; %s; %s): expected %v, got %v", c.value, c.from, c.to, c.expected, res)
+		}
+	}
+	// temperatures are exact, not only close
+	temperatures := []struct {
+		value    float64
+		from, to string
+		expected float64
+	}{
+		{10, "C", "F", 50},
+		{50, "F", "C", 10},
+		{-40, "C", "F", -40},
+		{98.6, "F", "C", 37},
+		{37, "C", "F", 98.6},
+		{10, "Reau", "F", 54.5},
+	}
+	for _, c := range temperatures {
+		if res, err := u.Convert(c.value, c.from, c.to); err != nil || res != c.expected {
+			t.Errorf("CONVERT(%v; %s; %s): expected %v, got %v (%v)", c.value, c.from, c.to, c.expected, res, err)
+		}
+	}
+	errs := []struct{ from, to string }{
+		{"ft", "sec"},
+		{"furlong", "m"},
+		{"m", "kft"},
+		{"C", "C*m"},
+		{"m^x", "m"},
+	}
+	for _, c := range errs {
+		if _, err := u.Convert(1., c.from, c.to); !errors.Is(err, ErrNA) && !errors.Is(err, ErrValue) {
+			t.Errorf("CONVERT(1; %s; %s): expected error, got %v", c.from, c.to, err)
+		}
+	}
+}
+
+func TestUnits_Define(t *testing.T) {
+	table := NewUnits()
+	if err := table.Define("furlong", 201.168, "m"); err != nil {
+		t.Fatalf("expected nil error, got %s", err)
+	}
+	if err := table.Define("fps", 1, "ft/s"); err != nil {
+		t.Fatalf("expected nil error, got %s", err)
+	}
+	res, err := (&units{table: table}).Convert(8., "furlong", "mi")
+	if err != nil || math.Abs(res.(float64)-1) > 1e-12 {
+		t.Errorf("expected 1 mile, got %v (%v)", res, err)
+	}
+	for _, c := range []struct{ symbol, base string }{{"m", "ft"}, {"x", "unknown"}, {"a/b", "m"}, {"xC", "C"}} {
+		if err := table.Define(c.symbol, 1, c.base); err == nil {
+			t.Errorf("%s = %s: expected error", c.symbol, c.base)
+		}
+	}
+}
+
+func TestQuantity(t *testing.T) {
+	table := NewUnits()
+	q := func(value float64, symbol string) Quantity {
+		unit, err := table.Parse(symbol)
+		if err != nil {
+			t.Fatalf("unit %s: expected nil error, got %s", symbol, err)
+		}
+		return Quantity{Value: value, Unit: unit}
+	}
+	sum, err := q(1, "km").Add(q(500, "m"))
+	if err != nil || sum.String() != "1.5 km" {
+		t.Errorf("expected 1.5 km, got %v (%v)", sum, err)
+	}
+	if _, err := q(1, "m").Add(q(1, "s")); !errors.Is(err, ErrValue) {
+		t.Errorf("expected #VALUE! adding m and s, got %v", err)
+	}
+	speed, err := q(100, "km").Div(q(2, "hr"))
+	if err != nil || speed.(Quantity).String() != "50 km/hr" {
+		t.Errorf("expected 50 km/hr, got %v (%v)", speed, err)
+	}
+	ratio, err := q(1, "km").Div(q(250, "m"))
+	if err != nil || ratio != 4. {
+		t.Errorf("expected dimensionless 4, got %v (%v)", ratio, err)
+	}
+	square, err := q(3, "m").Pow(2)
+	if err != nil || square.(Quantity).String() != "9 m^2" {
+		t.Errorf("expected 9 m^2, got %v (%v)", square, err)
+	}
+	if cmp, err := q(1, "mi").Cmp(q(1, "km")); err != nil || cmp != 1 {
+		t.Errorf("expected mile to be longer than kilometer, got %v (%v)", cmp, err)
+	}
+	warmer, err := q(20, "C").Add(q(9, "F"))
+	if err != nil || warmer.String() != "25 C" {
+		t.Errorf("expected 25 C, got %v (%v)", warmer, err)
+	}
+}
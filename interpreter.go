@@ -2,9 +2,12 @@ package go_interpreter
 
 import (
 	"fmt"
+	"github.com/kovalenkong/go-interpreter/functions"
 	"math"
+	"reflect"
 	"strconv"
 	"strings"
+	"time"
 )
 
 type Func func(args ...any) (any, error)
@@ -12,6 +15,16 @@ type Interpreter struct {
 	variables map[string]any
 	functions map[string]Func
 	node      Node
+
+	signatures map[string]functions.Signature
+	registry   *functions.Registry
+	folding    NameFolding
+	decimal    *functions.DecimalContext
+	identChars string
+	env        *Env
+
+	// registryNames finds registry functions under name folding
+	registryNames foldIndex
 }
 
 func NewInterpreter(variables map[string]any, functions map[string]Func) *Interpreter {
@@ -22,6 +35,9 @@ func NewInterpreter(variables map[string]any, functions map[string]Func) *Interp
 }
 
 func (e *Interpreter) SetVar(name string, value any) {
+	if e.variables == nil {
+		e.variables = map[string]any{}
+	}
 	e.variables[name] = value
 }
 
@@ -30,18 +46,21 @@ func (e *Interpreter) ClearVars() {
 }
 
 func (e *Interpreter) SetFunction(name string, function Func) {
+	if e.functions == nil {
+		e.functions = map[string]Func{}
+	}
 	e.functions[name] = function
 }
 
+// SetRegistry makes functions of the registry available to formulas.
+// Functions set by SetFunction take precedence over the registry.
+func (e *Interpreter) SetRegistry(registry *functions.Registry) {
+	e.registry = registry
+}
+
 // Execute method run node and returns result of Interpreter input function.
 func (e *Interpreter) Execute(formula string) (any, error) {
-	lexer := NewLexer()
-	tokens, err := lexer.Lex(strings.NewReader(formula))
-	if err != nil {
-		return nil, err
-	}
-	parser := NewParser()
-	node, err := parser.Parse(tokens)
+	node, err := e.parse(formula)
 	if err != nil {
 		return nil, err
 	}
@@ -50,6 +69,17 @@ func (e *Interpreter) Execute(formula string) (any, error) {
 	return e.execute(e.node)
 }
 
+func (e *Interpreter) parse(formula string) (Node, error) {
+	lexer := NewLexer()
+	lexer.SetIdentChars(e.identChars)
+	tokens, err := lexer.Lex(strings.NewReader(formula))
+	if err != nil {
+		return nil, err
+	}
+	parser := NewParser()
+	return parser.Parse(tokens)
+}
+
 func (e *Interpreter) execute(node Node) (any, error) {
 	switch n := node.(type) {
 	case *BinaryExpr:
@@ -64,6 +94,10 @@ func (e *Interpreter) execute(node Node) (any, error) {
 		return e.evalUnary(n)
 	case *Comparison:
 		return e.evalComparison(n)
+	case *Member:
+		return e.evalMember(n)
+	case *Index:
+		return e.evalIndex(n)
 	default:
 		return nil, fmt.Errorf("unknown node type: %T", node)
 	}
@@ -78,6 +112,33 @@ func (e *Interpreter) evalBinaryExpr(node *BinaryExpr) (any, error) {
 	if err != nil {
 		return nil, err
 	}
+	return e.binaryOp(node.Op, left, right)
+}
+
+// binaryOp applies arithmetic operator op to already evaluated operands.
+func (e *Interpreter) binaryOp(op TokenType, left, right any) (any, error) {
+	left, right = unblank(left, right), unblank(right, left)
+	if lt, ok := left.(time.Time); ok {
+		return dateOp(op, lt, right)
+	}
+	if rt, ok := right.(time.Time); ok && op == ADD {
+		return dateOp(op, rt, left)
+	}
+	if isMoney(left) || isMoney(right) {
+		return e.moneyOp(op, left, right)
+	}
+	if isQuantity(left) || isQuantity(right) {
+		return e.quantityOp(op, left, right)
+	}
+	if l, r, ok := complexOperands(left, right); ok {
+		return complexOp(op, l, r)
+	}
+	if l, r, ok, err := e.decimals(left, right); ok || err != nil {
+		if err != nil {
+			return nil, err
+		}
+		return e.decimalOp(op, l, r)
+	}
 	l, ok := left.(float64)
 	if !ok {
 		return nil, fmt.Errorf("expected float64, got %T", left)
@@ -86,7 +147,7 @@ func (e *Interpreter) evalBinaryExpr(node *BinaryExpr) (any, error) {
 	if !ok {
 		return nil, fmt.Errorf("expected float64, got %T", right)
 	}
-	switch node.Op {
+	switch op {
 	case ADD:
 		return l + r, nil
 	case SUB:
@@ -101,13 +162,16 @@ func (e *Interpreter) evalBinaryExpr(node *BinaryExpr) (any, error) {
 	case EXP:
 		return math.Pow(l, r), nil
 	default:
-		return nil, fmt.Errorf("unknown binary operation: %d", node.Op)
+		return nil, fmt.Errorf("unknown binary operation: %d", op)
 	}
 }
 
 func (e *Interpreter) evalLiteral(node *Literal) (any, error) {
 	switch node.Kind {
 	case NUMBER:
+		if e.decimal != nil {
+			return functions.ParseDecimal(strings.Replace(node.Value, ",", ".", -1))
+		}
 		return strconv.ParseFloat(strings.Replace(node.Value, ",", ".", -1), 10)
 	case STRING:
 		return node.Value, nil
@@ -117,25 +181,145 @@ func (e *Interpreter) evalLiteral(node *Literal) (any, error) {
 }
 
 func (e *Interpreter) evalIdent(node *Ident) (any, error) {
-	name := node.Name
-	value, ok := e.variables[name]
+	return e.lookupVar(node.Name)
+}
+
+func (e *Interpreter) evalMember(node *Member) (any, error) {
+	object, err := e.execute(node.Object)
+	if err != nil {
+		return nil, err
+	}
+	return e.member(object, node.Name)
+}
+
+func (e *Interpreter) evalIndex(node *Index) (any, error) {
+	object, err := e.execute(node.Object)
+	if err != nil {
+		return nil, err
+	}
+	index, err := e.execute(node.Index)
+	if err != nil {
+		return nil, err
+	}
+	return e.index(object, index)
+}
+
+func (e *Interpreter) lookupVar(name string) (any, error) {
+	return e.resolveVar(e.variables, name)
+}
+
+// resolveVarKey finds variable by key resolved at compile time,
+// the name is resolved again if vars have no such key.
+func (e *Interpreter) resolveVarKey(vars map[string]any, key, name string) (any, error) {
+	if value, ok := vars[key]; ok {
+		return value, nil
+	}
+	return e.resolveVar(vars, name)
+}
+
+// resolveVar finds variable in vars according to the name folding policy.
+func (e *Interpreter) resolveVar(vars map[string]any, name string) (any, error) {
+	value, ok, err := resolveName(e.folding, vars, name)
+	if err != nil {
+		return nil, err
+	}
+	if !ok && e.env != nil {
+		if value, ok, err = e.env.lookupVar(e.folding, name); err != nil {
+			return nil, err
+		}
+	}
 	if !ok {
 		return nil, fmt.Errorf("variable '%s' not found", name)
 	}
 	return value, nil
 }
 
+func (e *Interpreter) lookupFunction(name string) (Func, error) {
+	function, _, err := e.resolveFunction(name)
+	return function, err
+}
+
+// resolveFunction returns function by name and its definition
+// if the function comes from the registry.
+func (e *Interpreter) resolveFunction(name string) (Func, *functions.Definition, error) {
+	_, function, def, err := e.findFunction(name)
+	return function, def, err
+}
+
+// findFunction resolves function as resolveFunction does and also returns
+// the name under which the function is set or registered.
+func (e *Interpreter) findFunction(name string) (string, Func, *functions.Definition, error) {
+	key, ok, err := resolveKey(e.folding, e.functions, name)
+	if err != nil {
+		return "", nil, nil, err
+	}
+	if ok {
+		return key, e.functions[key], nil, nil
+	}
+	if e.env != nil {
+		if key, ok, err = resolveKey(e.folding, e.env.meta.methods, name); err != nil {
+			return "", nil, nil, err
+		}
+		if ok {
+			function, _, _ := e.env.lookupFunction(FoldExact, key)
+			return key, function, nil, nil
+		}
+	}
+	if e.registry != nil {
+		if def, ok := e.registry.Lookup(name); ok {
+			return name, def.Func, def, nil
+		}
+		if e.folding != FoldExact {
+			key, ok, err := e.registryNames.resolve(e.registry, e.folding, name)
+			if err != nil {
+				return "", nil, nil, err
+			}
+			if ok {
+				def, _ := e.registry.Lookup(key)
+				return key, def.Func, def, nil
+			}
+		}
+	}
+	return "", nil, nil, fmt.Errorf("function '%s' not found", name)
+}
+
+// exactFunction returns function set or registered under exactly the name.
+func (e *Interpreter) exactFunction(name string) (Func, *functions.Definition, bool) {
+	if function, ok := e.functions[name]; ok {
+		return function, nil, true
+	}
+	if e.env != nil {
+		if function, ok, _ := e.env.lookupFunction(FoldExact, name); ok {
+			return function, nil, true
+		}
+	}
+	if e.registry != nil {
+		if def, ok := e.registry.Lookup(name); ok {
+			return def.Func, def, true
+		}
+	}
+	return nil, nil, false
+}
+
+// catchesErrors reports whether failed arguments of the function
+// should be passed to it as error values.
+func catchesErrors(def *functions.Definition) bool {
+	return def != nil && def.CatchErrors
+}
+
 func (e *Interpreter) evalFunction(node *Function) (any, error) {
-	funcName := node.Name
-	function, ok := e.functions[funcName]
-	if !ok {
-		return nil, fmt.Errorf("function '%s' not found", funcName)
+	function, def, err := e.resolveFunction(node.Name)
+	if err != nil {
+		return nil, err
 	}
 	args := make([]any, len(node.Args))
 	for i, arg := range node.Args {
 		argument, err := e.execute(arg)
 		if err != nil {
-			return nil, err
+			if !catchesErrors(def) {
+				return nil, err
+			}
+			argument = err
 		}
 		args[i] = argument
 	}
@@ -147,17 +331,71 @@ func (e *Interpreter) evalUnary(node *UnaryExpr) (any, error) {
 	if err != nil {
 		return nil, err
 	}
-	val, ok := res.(float64)
+	return e.unaryOp(node.Op, res)
+}
+
+// dateOp applies arithmetic operator to a date: a number of days may be
+// added or subtracted and subtracting dates gives a number of days.
+func dateOp(op TokenType, date time.Time, operand any) (any, error) {
+	if d, ok := operand.(functions.Decimal); ok {
+		operand = d.Float64()
+	}
+	switch val := operand.(type) {
+	case float64:
+		if op != ADD && op != SUB {
+			break
+		}
+		if op == SUB {
+			val = -val
+		}
+		res, err := functions.AddDays(date, val)
+		if err != nil {
+			return nil, err
+		}
+		return res, nil
+	case time.Time:
+		if op == SUB {
+			return functions.DaysBetween(val, date), nil
+		}
+	}
+	return nil, fmt.Errorf("unsupported operation %d for date and %T", op, operand)
+}
+
+// unaryOp applies unary operator op to an already evaluated operand.
+func (e *Interpreter) unaryOp(op TokenType, res any) (any, error) {
+	switch val := res.(type) {
+	case functions.Decimal:
+		if op == SUB {
+			return val.Neg(), nil
+		}
+		return val, nil
+	case functions.Money:
+		if op == SUB {
+			return val.Neg(), nil
+		}
+		return val, nil
+	case functions.Quantity:
+		if op == SUB {
+			return val.Scale(-1), nil
+		}
+		return val, nil
+	case complex128:
+		if op == SUB {
+			return -val, nil
+		}
+		return val, nil
+	}
+	val, ok := unblank(res, nil).(float64)
 	if !ok {
 		return nil, fmt.Errorf("expected float64, got %T", res)
 	}
-	switch node.Op {
+	switch op {
 	case ADD:
 		return val, nil
 	case SUB:
 		return -val, nil
 	default:
-		return nil, fmt.Errorf("unknown unary operator: %d", node.Op)
+		return nil, fmt.Errorf("unknown unary operator: %d", op)
 	}
 }
 
@@ -170,8 +408,44 @@ func (e *Interpreter) evalComparison(node *Comparison) (any, error) {
 	if err != nil {
 		return nil, err
 	}
+	return e.compareValues(node.Op, left, right)
+}
 
-	if node.Op == EQ {
+// compareValues applies comparison operator op to already evaluated operands.
+func (e *Interpreter) compareValues(op TokenType, left, right any) (any, error) {
+	left, right = unblank(left, right), unblank(right, left)
+	if l, ok := left.(time.Time); ok {
+		r, ok := right.(time.Time)
+		if !ok {
+			if op == EQ {
+				return false, nil
+			}
+			return nil, fmt.Errorf("can't compare date and %T", right)
+		}
+		return compareTimes(l, r, op)
+	}
+	if isMoney(left) || isMoney(right) {
+		return compareMoney(op, left, right)
+	}
+	if isQuantity(left) || isQuantity(right) {
+		return compareQuantities(op, left, right)
+	}
+	if l, r, ok := complexOperands(left, right); ok {
+		if op != EQ {
+			return nil, fmt.Errorf("complex numbers are not ordered")
+		}
+		return l == r, nil
+	}
+	if l, r, ok, err := e.decimals(left, right); ok || err != nil {
+		if err != nil {
+			return nil, err
+		}
+		return compare(l.Cmp(r), 0, op)
+	}
+	if !equatable(left) || !equatable(right) {
+		return nil, fmt.Errorf("%w can't compare %T and %T", functions.ErrValue, left, right)
+	}
+	if op == EQ {
 		return left == right, nil
 	}
 	switch l := left.(type) {
@@ -180,20 +454,50 @@ func (e *Interpreter) evalComparison(node *Comparison) (any, error) {
 		if !ok {
 			return nil, fmt.Errorf("can't compare float64 and %T", right)
 		}
-		return compare(l, r, node.Op)
+		return compare(l, r, op)
 	case string:
 		r, ok := right.(string)
 		if !ok {
 			return nil, fmt.Errorf("can't compare string and %T", right)
 		}
-		return compare(l, r, node.Op)
+		return compare(l, r, op)
 	default:
 		return nil, fmt.Errorf("unknown comparable type %T", left)
 	}
 }
 
-func compare[T float64 | string](left, right T, op TokenType) (bool, error) {
+// equatable reports whether value can be compared with ==, arrays can't.
+func equatable(value any) bool {
+	return value == nil || reflect.TypeOf(value).Comparable()
+}
+
+// unblank replaces functions.Blank with zero value of the other operand type:
+// an empty string, FALSE, zero amount in the same currency, zero quantity
+// in the same unit or 0 otherwise.
+func unblank(value, other any) any {
+	if value != functions.Blank {
+		return value
+	}
+	switch o := other.(type) {
+	case string:
+		return ""
+	case bool:
+		return false
+	case functions.Money:
+		return functions.Money{Currency: o.Currency}
+	case functions.Quantity:
+		return functions.Quantity{Unit: o.Unit}
+	case complex128:
+		return complex128(0)
+	default:
+		return 0.
+	}
+}
+
+func compare[T int | float64 | string](left, right T, op TokenType) (bool, error) {
 	switch op {
+	case EQ:
+		return left == right, nil
 	case LT:
 		return left < right, nil
 	case GT:
@@ -205,3 +509,19 @@ func compare[T float64 | string](left, right T, op TokenType) (bool, error) {
 	}
 	return false, fmt.Errorf("unexpected comparison token: %d", op)
 }
+
+func compareTimes(left, right time.Time, op TokenType) (bool, error) {
+	switch op {
+	case EQ:
+		return left.Equal(right), nil
+	case LT:
+		return left.Before(right), nil
+	case GT:
+		return left.After(right), nil
+	case LTE:
+		return !left.After(right), nil
+	case GTE:
+		return !left.Before(right), nil
+	}
+	return false, fmt.Errorf("unexpected comparison token: %d", op)
+}
@@ -1,9 +1,13 @@
 package go_interpreter
 
 import (
+	"errors"
 	"fmt"
 	"github.com/kovalenkong/go-interpreter/functions"
+	"math"
+	"math/cmplx"
 	"testing"
+	"time"
 )
 
 func BenchmarkInterpreter_ExecuteFormula(b *testing.B) {
@@ -69,50 +73,9 @@ func BenchmarkInterpreter_ExecuteSimpleAdd(b *testing.B) {
 }
 
 func BenchmarkInterpreter_ExecuteBig(b *testing.B) {
-	interpreter := NewInterpreter(
-		map[string]any{
-			"X": 10.0,
-			"Y": 100.0,
-		},
-		map[string]Func{
-			"SUM": func(args ...any) (any, error) {
-				var total float64
-				for _, el := range args {
-					val, ok := el.(float64)
-					if !ok {
-						return nil, fmt.Errorf("expected float64, got %T", el)
-					}
-					total += val
-				}
-				return total, nil
-			},
-			"And": func(args ...any) (any, error) {
-				for _, el := range args {
-					cond, ok := el.(bool)
-					if !ok {
-						return nil, fmt.Errorf("expected bool, got %T", el)
-					}
-					if !cond {
-						return false, nil
-					}
-				}
-				return true, nil
-			},
-			"IF": func(args ...any) (any, error) {
-				if length := len(args); length != 3 {
-					return nil, fmt.Errorf("expected 3 args, got %d", length)
-				}
-				cond, ok := args[0].(bool)
-				if !ok {
-					return nil, fmt.Errorf("expected bool, got %T", args[0])
-				}
-				if cond {
-					return args[1], nil
-				}
-				return args[2], nil
-			},
-		},
-	)
+	interpreter := NewDefaultInterpreter()
+	interpreter.SetVar("X", 10.0)
+	interpreter.SetVar("Y", 100.0)
 	var expected float64 = 123
 	for i := 0; i < b.N; i++ {
 		res, err := interpreter.Execute(`IF(AND(SUM(1;2;3)=6;X^2=Y);123;0)`)
@@ -128,3 +91,475 @@ func BenchmarkInterpreter_ExecuteBig(b *testing.B) {
 		}
 	}
 }
+
+func TestNewDefaultInterpreter(t *testing.T) {
+	interpreter := NewDefaultInterpreter()
+	res, err := interpreter.Execute(`IF(AND(SUM(1;2;3)=6;MEAN(1;3)=2);ROUND(2,345;2);0)`)
+	if err != nil {
+		t.Fatalf("expected nil error, got %s", err)
+	}
+	if res != 2.35 {
+		t.Errorf("expected 2.35, got %v", res)
+	}
+
+	interpreter = NewDefaultInterpreter(ExcludeCategories(functions.CategoryLogical))
+	if _, err := interpreter.Execute(`IF(1=1;1;0)`); err == nil {
+		t.Errorf("expected IF to be excluded")
+	}
+	interpreter = NewDefaultInterpreter(IncludeCategories(functions.CategoryLogical))
+	if _, err := interpreter.Execute(`SUM(1)`); err == nil {
+		t.Errorf("expected SUM to be excluded")
+	}
+	if _, err := interpreter.Execute(`AND(1=1)`); err != nil {
+		t.Errorf("expected nil error, got %s", err)
+	}
+}
+
+func TestInterpreter_DottedFunctions(t *testing.T) {
+	interpreter := NewDefaultInterpreter()
+	interpreter.SetVar("X", []float64{1, 2, 3, 4})
+	cases := map[string]float64{
+		`STDEV.P(2; 4; 4; 4; 5; 5; 7; 9)`:    2,
+		`PERCENTILE.INC(X; 0,5) * 2`:         5,
+		`QUARTILE.INC(X; 1) + STDEV.S(1; 1)`: 1.75,
+	}
+	for formula, expected := range cases {
+		res, err := interpreter.Execute(formula)
+		if err != nil {
+			t.Fatalf("formula '%s': expected nil error, got %s", formula, err)
+		}
+		if res != expected {
+			t.Errorf("formula '%s': expected %v, got %v", formula, expected, res)
+		}
+	}
+	if _, err := interpreter.Execute(`STDEV.X(1; 2)`); err == nil {
+		t.Errorf("expected unknown function error")
+	}
+}
+
+func TestInterpreter_CompareArrays(t *testing.T) {
+	interpreter := NewDefaultInterpreter()
+	for _, formula := range []string{
+		`TEXTSPLIT("a,b"; ",") = TEXTSPLIT("a,b"; ",")`,
+		`TEXTSPLIT("a,b"; ",") = "a"`,
+		`1 = TEXTSPLIT("a"; ",")`,
+	} {
+		if _, err := interpreter.Execute(formula); !errors.Is(err, functions.ErrValue) {
+			t.Errorf("formula '%s': expected #VALUE!, got %v", formula, err)
+		}
+	}
+}
+
+func TestInterpreter_Lookup(t *testing.T) {
+	interpreter := NewDefaultInterpreter()
+	rates, err := functions.NewTable([][]any{
+		{0., "none", 0.},
+		{100., "bronze", 0.05},
+		{500., "silver", 0.1},
+	})
+	if err != nil {
+		t.Fatalf("expected nil error, got %s", err)
+	}
+	interpreter.SetVar("Rates", rates)
+	interpreter.SetVar("Total", 120.)
+	res, err := interpreter.Execute(`Total * (1 - VLOOKUP(Total; Rates; 3))`)
+	if err != nil {
+		t.Fatalf("expected nil error, got %s", err)
+	}
+	if res != 114. {
+		t.Errorf("expected 114, got %v", res)
+	}
+	_, err = interpreter.Execute(`VLOOKUP(50; Rates; 2; 1=0)`)
+	if !errors.Is(err, functions.ErrNA) {
+		t.Errorf("expected #N/A, got %v", err)
+	}
+	if err := interpreter.Validate(`INDEX(Rates; 2; 2)`); err != nil {
+		t.Errorf("expected nil error, got %s", err)
+	}
+	if err := interpreter.Validate(`VLOOKUP(1; Total; 2)`); err == nil {
+		t.Errorf("expected type error")
+	}
+	for _, formula := range []string{`INDEX(Rates; 0; 1) = INDEX(Rates; 0; 1)`, `Rates = Rates`, `INDEX(Rates; 2) > 1`} {
+		if _, err := interpreter.Execute(formula); !errors.Is(err, functions.ErrValue) {
+			t.Errorf("formula '%s': expected #VALUE!, got %v", formula, err)
+		}
+	}
+}
+
+func TestInterpreter_Financial(t *testing.T) {
+	flows := []float64{-70000, 12000, 15000, 18000, 21000, 26000}
+	interpreter := NewDefaultInterpreter()
+	interpreter.SetVar("Flows", flows)
+	res, err := interpreter.Execute(`ROUND(IRR(Flows); 4)`)
+	if err != nil {
+		t.Fatalf("expected nil error, got %s", err)
+	}
+	if res != 0.0866 {
+		t.Errorf("expected 0.0866, got %v", res)
+	}
+	interpreter = NewDefaultInterpreter(WithSolver(0, 1))
+	interpreter.SetVar("Flows", flows)
+	if _, err := interpreter.Execute(`IRR(Flows)`); !errors.Is(err, functions.ErrNum) {
+		t.Errorf("expected #NUM!, got %v", err)
+	}
+}
+
+func TestInterpreter_Logical(t *testing.T) {
+	interpreter := NewDefaultInterpreter()
+	interpreter.SetVar("Discount", functions.Blank)
+	interpreter.SetVar("Tier", "Gold")
+	interpreter.SetVar("Price", 100.)
+	cases := map[string]any{
+		`IFERROR(Price / 0; 0)`:                       0.,
+		`IFERROR(Missing; "none")`:                    "none",
+		`IFNA(IFS(Price > 200; "high"); "low")`:       "low",
+		`ISERROR(Price / 0)`:                          true,
+		`ISBLANK(Discount)`:                           true,
+		`ISBLANK(Price)`:                              false,
+		`Price * (1 - Discount)`:                      100.,
+		`Discount = 0`:                                true,
+		`Discount = ""`:                               true,
+		`IF(ISBLANK(Discount); 0,1; Discount)`:        0.1,
+		`SWITCH(Tier; "silver"; 0,1; "gold"; 0,2; 0)`: 0.2,
+		`XOR(Price > 10; NOT(ISNUMBER(Tier)))`:        false,
+		`TYPE(Tier) + TYPE(Price) + TYPE(Price / 0)`:  19.,
+	}
+	for formula, expected := range cases {
+		res, err := interpreter.Execute(formula)
+		if err != nil {
+			t.Fatalf("formula '%s': expected nil error, got %s", formula, err)
+		}
+		if res != expected {
+			t.Errorf("formula '%s': expected %v, got %v", formula, expected, res)
+		}
+		program, err := interpreter.Compile(formula)
+		if err != nil {
+			t.Fatalf("formula '%s': expected nil error, got %s", formula, err)
+		}
+		if res, err := program.Run(); err != nil || res != expected {
+			t.Errorf("compiled formula '%s': expected %v, got %v (%v)", formula, expected, res, err)
+		}
+	}
+	if _, err := interpreter.Execute(`IFS(Price > 200; "high")`); !errors.Is(err, functions.ErrNA) {
+		t.Errorf("expected #N/A, got %v", err)
+	}
+}
+
+func TestInterpreter_Regex(t *testing.T) {
+	interpreter := NewDefaultInterpreter(WithRegex(functions.Regex{MaxInput: 10}))
+	interpreter.SetVar("Code", "ABC-1234")
+	res, err := interpreter.Execute(`IF(REGEXMATCH(Code; "^[A-Z]{3}-\d{4}$"); REGEXEXTRACT(Code; "\d+"); "")`)
+	if err != nil {
+		t.Fatalf("expected nil error, got %s", err)
+	}
+	if res != "1234" {
+		t.Errorf("expected 1234, got %v", res)
+	}
+	interpreter.SetVar("Code", "ABC-1234567")
+	if _, err := interpreter.Execute(`REGEXMATCH(Code; "\d")`); !errors.Is(err, functions.ErrValue) {
+		t.Errorf("expected #VALUE! for long text, got %v", err)
+	}
+}
+
+func TestInterpreter_Random(t *testing.T) {
+	run := func() any {
+		interpreter := NewDefaultInterpreter(WithSeed(7))
+		res, err := interpreter.Execute(`SUM(RANDARRAY(10; 2; 1; 6; 1=1)) + RANDBETWEEN(1; 100)`)
+		if err != nil {
+			t.Fatalf("expected nil error, got %s", err)
+		}
+		return res
+	}
+	if a, b := run(), run(); a != b {
+		t.Errorf("expected equal results with the same seed, got %v and %v", a, b)
+	}
+}
+
+func TestInterpreter_Decimal(t *testing.T) {
+	interpreter := NewDefaultInterpreter(WithDecimal(4, functions.RoundHalfUp))
+	interpreter.SetVar("Price", 19.99)
+	interpreter.SetVar("Rate", functions.MustDecimal("0.175"))
+	cases := map[string]string{
+		`0,1 + 0,2`:                 "0.3",
+		`Price * 3`:                 "59.97",
+		`2 / 3`:                     "0.6667",
+		`ROUND(Price * Rate; 2)`:    "3.50",
+		`SUM(0,1; 0,2; Price) - 20`: "0.29",
+		`-Rate ^ 2`:                 "0.030625",
+		`1,1 ^ -1`:                  "0.9091",
+		`AVERAGE(0,1; 0,2)`:         "0.15",
+		`AVERAGE(1; 2; 2)`:          "1.6666666666666667",
+		`MAX(0,1; 0,3; Rate)`:       "0.3",
+		`MIN(Price; 0,1 + 0,2)`:     "0.3",
+		`MOD(0,3; 0,1)`:             "0.0",
+		`MOD(-0,5; 0,2)`:            "0.1",
+		`10 ^ 1000 / 10 ^ 999`:      "10.0000",
+	}
+	for formula, expected := range cases {
+		res, err := interpreter.Execute(formula)
+		if err != nil {
+			t.Fatalf("formula '%s': expected nil error, got %s", formula, err)
+		}
+		if d, ok := res.(functions.Decimal); !ok || d.String() != expected {
+			t.Errorf("formula '%s': expected %s, got %v", formula, expected, res)
+		}
+		program, err := interpreter.Compile(formula)
+		if err != nil {
+			t.Fatalf("formula '%s': expected nil error, got %s", formula, err)
+		}
+		if res, err := program.Run(); err != nil || res.(functions.Decimal).String() != expected {
+			t.Errorf("compiled formula '%s': expected %s, got %v (%v)", formula, expected, res, err)
+		}
+	}
+	for _, formula := range []string{`0,1 + 0,2 = 0,3`, `Rate > 0,17`, `Price = 19,99`} {
+		if res, err := interpreter.Execute(formula); err != nil || res != true {
+			t.Errorf("formula '%s': expected true, got %v (%v)", formula, res, err)
+		}
+	}
+	if _, err := interpreter.Execute(`1 / (Price - 19,99)`); err == nil {
+		t.Errorf("expected zero division error")
+	}
+	for _, formula := range []string{`((10 ^ 1000) ^ 1000) ^ 1000`, `ROUND(1 / 3; 10 ^ 20)`} {
+		if _, err := interpreter.Execute(formula); !errors.Is(err, functions.ErrNum) {
+			t.Errorf("formula '%s': expected #NUM!, got %v", formula, err)
+		}
+	}
+
+	program, err := interpreter.Compile(`Qty * 0,1`)
+	if err != nil {
+		t.Fatalf("expected nil error, got %s", err)
+	}
+	results, errs := EvalBatch(program, map[string][]float64{"Qty": {1, 2, 3}}, 3)
+	if errs[2] != nil || results[2].(functions.Decimal).String() != "0.3" {
+		t.Errorf("expected 0.3, got %v (%v)", results[2], errs[2])
+	}
+}
+
+func TestInterpreter_DecimalFunctions(t *testing.T) {
+	interpreter := NewDefaultInterpreter(WithDecimal(10, functions.RoundHalfUp))
+	interpreter.SetVar("Amounts", []any{functions.MustDecimal("0.1"), functions.MustDecimal("0.2"), 0.2, "0.2"})
+	cases := map[string]string{
+		`LEN(123)`:                         "3",
+		`UPPER(1,50)`:                      "1.5",
+		`REGEXMATCH(1; "1")`:               "true",
+		`ISNUMBER(1)`:                      "true",
+		`COUNTIF(Amounts; 0,2)`:            "3",
+		`SUMIF(Amounts; ">0,1")`:           "0.4",
+		`AVERAGEIF(Amounts; "<=0,2")`:      "0.1666666666666667",
+		`MAXIFS(Amounts; Amounts; "<0,2")`: "0.1",
+		`DAY(DATE(2020; 1; 1) + 1)`:        "2",
+		`YEAR(45000)`:                      "2023",
+	}
+	for formula, expected := range cases {
+		res, err := interpreter.Execute(formula)
+		if err != nil {
+			t.Fatalf("formula '%s': expected nil error, got %s", formula, err)
+		}
+		if fmt.Sprint(res) != expected {
+			t.Errorf("formula '%s': expected %s, got %v", formula, expected, res)
+		}
+	}
+}
+
+func TestInterpreter_Money(t *testing.T) {
+	rates := functions.RateTable{"EUR": {"USD": functions.MustDecimal("1.08")}}
+	interpreter := NewDefaultInterpreter(WithRates(rates))
+	interpreter.SetVar("Price", functions.Money{Amount: functions.MustDecimal("19.99"), Currency: "USD"})
+	interpreter.SetVar("Fee", functions.Blank)
+	cases := map[string]string{
+		`Price * 3`:                                 "59.97 USD",
+		`2 * Price - MONEY(0,97; "USD")`:            "39.01 USD",
+		`-Price + Fee`:                              "-19.99 USD",
+		`ROUND(Price / 3; 2)`:                       "6.66 USD",
+		`SUM(Price; MONEY(0,01; "usd"))`:            "20.00 USD",
+		`Price + EXCHANGE(MONEY(10; "EUR"); "USD")`: "30.79 USD",
+	}
+	for formula, expected := range cases {
+		res, err := interpreter.Execute(formula)
+		if err != nil {
+			t.Fatalf("formula '%s': expected nil error, got %s", formula, err)
+		}
+		if m, ok := res.(functions.Money); !ok || m.String() != expected {
+			t.Errorf("formula '%s': expected %s, got %v", formula, expected, res)
+		}
+	}
+	bools := map[string]bool{
+		`Price > MONEY(10; "USD")`:    true,
+		`Price = MONEY(19,99; "EUR")`: false,
+		`Price = 19,99`:               false,
+	}
+	for formula, expected := range bools {
+		if res, err := interpreter.Execute(formula); err != nil || res != expected {
+			t.Errorf("formula '%s': expected %v, got %v (%v)", formula, expected, res, err)
+		}
+	}
+	for _, formula := range []string{
+		`Price + MONEY(1; "EUR")`,
+		`SUM(Price; MONEY(1; "EUR"))`,
+		`Price + 1`,
+		`1 / Price`,
+		`Price > MONEY(1; "EUR")`,
+		`MONEY(1; "EURO")`,
+		`Price - MONEY(1; "EUR")`,
+		`Price / 0`,
+	} {
+		if res, err := interpreter.Execute(formula); err == nil || res != nil {
+			t.Errorf("formula '%s': expected nil result and error, got %v (%v)", formula, res, err)
+		}
+	}
+	if res, err := interpreter.Execute(`Price / MONEY(10; "USD")`); err != nil || res.(functions.Decimal).Cmp(functions.MustDecimal("1.999")) != 0 {
+		t.Errorf("expected ratio 1.999, got %v (%v)", res, err)
+	}
+}
+
+func TestInterpreter_Quantity(t *testing.T) {
+	units := functions.NewUnits()
+	if err := units.Define("furlong", 201.168, "m"); err != nil {
+		t.Fatalf("expected nil error, got %s", err)
+	}
+	interpreter := NewDefaultInterpreter(WithUnits(units))
+	km, err := units.Parse("km")
+	if err != nil {
+		t.Fatalf("expected nil error, got %s", err)
+	}
+	hr, err := units.Parse("hr")
+	if err != nil {
+		t.Fatalf("expected nil error, got %s", err)
+	}
+	interpreter.SetVar("Distance", functions.Quantity{Value: 42.195, Unit: km})
+	interpreter.SetVar("Time", functions.Quantity{Value: 3, Unit: hr})
+	cases := map[string]string{
+		`Distance + QUANTITY(805; "m")`:         "43 km",
+		`CONVERT(Distance / Time; "km/h")`:      "14.065 km/h",
+		`QUANTITY(2; "m") * QUANTITY(3; "m")`:   "6 m*m",
+		`-QUANTITY(1; "m") ^ 2`:                 "1 m^2",
+		`CONVERT(QUANTITY(1; "mi"); "furlong")`: "8 furlong",
+		`2 * QUANTITY(10; "kg") / 4`:            "5 kg",
+	}
+	for formula, expected := range cases {
+		res, err := interpreter.Execute(formula)
+		if err != nil {
+			t.Fatalf("formula '%s': expected nil error, got %s", formula, err)
+		}
+		if q, ok := res.(functions.Quantity); !ok || q.String() != expected {
+			t.Errorf("formula '%s': expected %s, got %v", formula, expected, res)
+		}
+	}
+	values := map[string]any{
+		`CONVERT(10; "km"; "mi")`:                           6.21371192237334,
+		`Distance / QUANTITY(1; "km")`:                      42.195,
+		`Distance > QUANTITY(26; "mi")`:                     true,
+		`QUANTITY(1; "m") = QUANTITY(1; "s")`:               false,
+		`ROUND(CONVERT(Time; "mn") / QUANTITY(1; "mn"); 0)`: 180.,
+	}
+	for formula, expected := range values {
+		if res, err := interpreter.Execute(formula); err != nil || res != expected {
+			t.Errorf("formula '%s': expected %v, got %v (%v)", formula, expected, res, err)
+		}
+	}
+	for _, formula := range []string{
+		`Distance + Time`,
+		`Distance + 1`,
+		`Distance > Time`,
+		`Distance ^ 0,5`,
+		`QUANTITY(1; "C") * QUANTITY(1; "m")`,
+		`CONVERT(2,5; "ft"; "sec")`,
+		`Distance - Time`,
+		`CONVERT(Distance; "sec")`,
+	} {
+		if res, err := interpreter.Execute(formula); err == nil || res != nil {
+			t.Errorf("formula '%s': expected nil result and error, got %v (%v)", formula, res, err)
+		}
+	}
+}
+
+func TestInterpreter_Complex(t *testing.T) {
+	interpreter := NewDefaultInterpreter()
+	interpreter.SetVar("Z", complex(3, 4))
+	interpreter.SetVar("R", 2.)
+	cases := map[string]any{
+		`Z + R`:                        complex(5, 4),
+		`R - Z`:                        complex(-1, -4),
+		`Z * COMPLEX(0; 1)`:            complex(-4, 3),
+		`Z / R`:                        complex(1.5, 2),
+		`-Z`:                           complex(-3, -4),
+		`IMABS(Z)`:                     5.,
+		`IMREAL(IMPRODUCT(Z; "1-i"))`:  7.,
+		`IMSUM(Z; "2j"; R)`:            complex(5, 6),
+		`Z = COMPLEX(3; 4)`:            true,
+		`COMPLEX(2; 0) = R`:            true,
+		`IMAGINARY(COMPLEX(0; 1) ^ 2)`: 0.,
+		`IMREAL(COMPLEX(-1; 0) ^ 0,5)`: 0.,
+	}
+	for formula, expected := range cases {
+		res, err := interpreter.Execute(formula)
+		if err != nil {
+			t.Fatalf("formula '%s': expected nil error, got %s", formula, err)
+		}
+		if c, ok := res.(complex128); ok {
+			if e, ok := expected.(complex128); !ok || cmplx.Abs(c-e) > 1e-12 {
+				t.Errorf("formula '%s': expected %v, got %v", formula, expected, res)
+			}
+		} else if f, ok := res.(float64); ok {
+			if e, ok := expected.(float64); !ok || math.Abs(f-e) > 1e-12 {
+				t.Errorf("formula '%s': expected %v, got %v", formula, expected, res)
+			}
+		} else if res != expected {
+			t.Errorf("formula '%s': expected %v, got %v", formula, expected, res)
+		}
+	}
+	for _, formula := range []string{`Z > R`, `Z / 0`, `Z + "a"`} {
+		if _, err := interpreter.Execute(formula); err == nil {
+			t.Errorf("formula '%s': expected error", formula)
+		}
+	}
+}
+
+func TestInterpreter_Dates(t *testing.T) {
+	now := time.Date(2024, 3, 15, 13, 30, 0, 0, time.UTC)
+	interpreter := NewDefaultInterpreter(WithClock(func() time.Time { return now }))
+	interpreter.SetVar("Due", time.Date(2024, 3, 20, 0, 0, 0, 0, time.UTC))
+	cases := map[string]any{
+		`Due - TODAY()`:                    5.,
+		`TODAY() + 1,5`:                    time.Date(2024, 3, 16, 12, 0, 0, 0, time.UTC),
+		`1 + TODAY()`:                      time.Date(2024, 3, 16, 0, 0, 0, 0, time.UTC),
+		`Due - 20`:                         time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC),
+		`Due > NOW()`:                      true,
+		`Due = DATE(2024; 3; 20)`:          true,
+		`DATEDIF(TODAY(); Due; "D")`:       5.,
+		`YEAR(EDATE(Due; 12))`:             2025.,
+		`NETWORKDAYS(TODAY(); Due)`:        4.,
+		`EOMONTH(Due; 0) - DATE(2024;3;1)`: 30.,
+	}
+	for formula, expected := range cases {
+		res, err := interpreter.Execute(formula)
+		if err != nil {
+			t.Fatalf("formula '%s': expected nil error, got %s", formula, err)
+		}
+		if tm, ok := expected.(time.Time); ok {
+			if r, ok := res.(time.Time); !ok || !r.Equal(tm) {
+				t.Errorf("formula '%s': expected %v, got %v", formula, expected, res)
+			}
+			continue
+		}
+		if res != expected {
+			t.Errorf("formula '%s': expected %v, got %v", formula, expected, res)
+		}
+	}
+	if err := interpreter.Validate(`Due - TODAY() + 1`); err != nil {
+		t.Errorf("expected nil error, got %s", err)
+	}
+	if err := interpreter.Validate(`Due * 2`); err == nil {
+		t.Errorf("expected type error")
+	}
+
+	serial := NewDefaultInterpreter(WithSerialDates())
+	if res, err := serial.Execute(`DATE(2024; 2; 29) + 1`); err != nil || res != 45352. {
+		t.Errorf("expected 45352, got %v (%v)", res, err)
+	}
+	for _, formula := range []string{`Due + 10 ^ 300`, `DATE(2020; 1; 10 ^ 300)`, `YEAR(10 ^ 300)`} {
+		if res, err := interpreter.Execute(formula); !errors.Is(err, functions.ErrNum) || res != nil {
+			t.Errorf("formula '%s': expected #NUM! error, got %v (%v)", formula, res, err)
+		}
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"bufio"
 	"fmt"
 	"io"
+	"strings"
 	"unicode"
 )
 
@@ -14,14 +15,22 @@ type Token struct {
 }
 
 type Lexer struct {
-	tokenPos uint
-	reader   *bufio.Reader
+	tokenPos   uint
+	reader     *bufio.Reader
+	identChars string
 }
 
 func NewLexer() *Lexer {
 	return new(Lexer)
 }
 
+// SetIdentChars sets characters allowed in identifiers besides letters,
+// digits and underscore. The characters may appear after the first
+// character of an identifier.
+func (l *Lexer) SetIdentChars(chars string) {
+	l.identChars = chars
+}
+
 func (l *Lexer) Lex(reader io.Reader) ([]Token, error) {
 	l.reader = bufio.NewReader(reader)
 	l.tokenPos = 0
@@ -81,6 +90,33 @@ func (l *Lexer) Lex(reader io.Reader) ([]Token, error) {
 				Type: DELIMITER,
 				pos:  l.tokenPos,
 			}
+		case r == '.':
+			token = Token{
+				Type: DOT,
+				pos:  l.tokenPos,
+			}
+		case r == '[' && postfix(tokens):
+			token = Token{
+				Type: LBRACKET,
+				pos:  l.tokenPos,
+			}
+		case r == '[' || r == '`':
+			// quoted identifier like [Unit Price] or `Unit Price`
+			position := l.tokenPos
+			value, err := l.readQuoted(r)
+			if err != nil {
+				return nil, err
+			}
+			token = Token{
+				Type:  IDENT,
+				Value: value,
+				pos:   position,
+			}
+		case r == ']':
+			token = Token{
+				Type: RBRACKET,
+				pos:  l.tokenPos,
+			}
 		case r == '"':
 			position := l.tokenPos
 			value, err := l.readString()
@@ -143,7 +179,7 @@ func (l *Lexer) Lex(reader io.Reader) ([]Token, error) {
 				Value: value,
 				pos:   position,
 			}
-		case unicode.IsLetter(r):
+		case unicode.IsLetter(r) || r == '_':
 			position := l.tokenPos
 			value, err := l.readIdent()
 			if err != nil {
@@ -227,7 +263,7 @@ func (l *Lexer) readIdent() (string, error) {
 			return "", err
 		}
 		switch {
-		case unicode.IsLetter(r), unicode.IsDigit(r):
+		case unicode.IsLetter(r), unicode.IsDigit(r), r == '_', strings.ContainsRune(l.identChars, r):
 			lit += string(r)
 		default:
 			err = l.reader.UnreadRune()
@@ -237,6 +273,59 @@ func (l *Lexer) readIdent() (string, error) {
 	}
 }
 
+// postfix reports whether a bracket following tokens starts an index
+// rather than a quoted identifier.
+func postfix(tokens []Token) bool {
+	if len(tokens) == 0 {
+		return false
+	}
+	switch tokens[len(tokens)-1].Type {
+	case IDENT, RPAREN, RBRACKET:
+		return true
+	}
+	return false
+}
+
+// readQuoted reads identifier quoted by brackets or backticks. A backtick
+// is doubled to be a part of the name; names quoted by brackets can't
+// contain the closing bracket.
+func (l *Lexer) readQuoted(open rune) (string, error) {
+	closing := open
+	if open == '[' {
+		closing = ']'
+	}
+	var lit string
+	for {
+		r, _, err := l.reader.ReadRune()
+		if err != nil {
+			if err == io.EOF {
+				return "", fmt.Errorf("missing %s at the end of identifier %s%s", string(closing), string(open), lit)
+			}
+			return "", err
+		}
+		l.tokenPos++
+		if r == closing {
+			next, _, err := l.reader.ReadRune()
+			if err == nil && next == '`' && closing == '`' {
+				l.tokenPos++
+				lit += string(r)
+				continue
+			}
+			if err == nil {
+				err = l.reader.UnreadRune()
+			}
+			if err != nil && err != io.EOF {
+				return "", err
+			}
+			if lit == "" {
+				return "", fmt.Errorf("empty quoted identifier")
+			}
+			return lit, nil
+		}
+		lit += string(r)
+	}
+}
+
 func (l *Lexer) readComparison() (string, error) {
 	var lit string
 	if err := l.reader.UnreadRune(); err != nil {
@@ -0,0 +1,96 @@
+package go_interpreter
+
+import (
+	"fmt"
+	"github.com/kovalenkong/go-interpreter/functions"
+)
+
+func isMoney(value any) bool {
+	_, ok := value.(functions.Money)
+	return ok
+}
+
+// result returns value of an operation as any, or nil if the operation failed.
+func result[T any](value T, err error) (any, error) {
+	if err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+// moneyOp applies arithmetic operator when at least one operand is money.
+// Amounts in the same currency may be added, subtracted and divided,
+// money may be multiplied or divided by a number.
+func (e *Interpreter) moneyOp(op TokenType, left, right any) (any, error) {
+	l, lmoney := left.(functions.Money)
+	r, rmoney := right.(functions.Money)
+	switch {
+	case lmoney && rmoney:
+		switch op {
+		case ADD:
+			return result(l.Add(r))
+		case SUB:
+			return result(l.Sub(r))
+		case DIV:
+			if l.Currency != r.Currency {
+				return nil, fmt.Errorf("can't divide %s by %s amount", l.Currency, r.Currency)
+			}
+			return result(l.Amount.Quo(r.Amount, e.decimalContext()))
+		}
+	case lmoney:
+		k, ok, err := toDecimal(right)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			switch op {
+			case MUL:
+				return l.Mul(k), nil
+			case DIV:
+				return result(l.Quo(k, e.decimalContext()))
+			}
+		}
+	case rmoney:
+		k, ok, err := toDecimal(left)
+		if err != nil {
+			return nil, err
+		}
+		if ok && op == MUL {
+			return r.Mul(k), nil
+		}
+	}
+	return nil, fmt.Errorf("unsupported operation %s for %s and %s", opName(op), typeName(left), typeName(right))
+}
+
+// compareMoney compares amounts in the same currency. Money is not equal to other values.
+func compareMoney(op TokenType, left, right any) (any, error) {
+	l, lmoney := left.(functions.Money)
+	r, rmoney := right.(functions.Money)
+	if !lmoney || !rmoney {
+		if op == EQ {
+			return false, nil
+		}
+		return nil, fmt.Errorf("can't compare %s and %s", typeName(left), typeName(right))
+	}
+	if op == EQ && l.Currency != r.Currency {
+		return false, nil
+	}
+	cmp, err := l.Cmp(r)
+	if err != nil {
+		return nil, err
+	}
+	return compare(cmp, 0, op)
+}
+
+// typeName names type of value in error messages: money by its currency
+// and quantities by their units.
+func typeName(value any) string {
+	switch val := value.(type) {
+	case functions.Money:
+		return val.Currency + " amount"
+	case functions.Quantity:
+		return "quantity in " + val.Unit.Symbol
+	default:
+		return fmt.Sprintf("%T", value)
+	}
+}
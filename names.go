@@ -0,0 +1,182 @@
+package go_interpreter
+
+import (
+	"fmt"
+	"github.com/kovalenkong/go-interpreter/functions"
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// NameFolding is a policy of matching function and variable names.
+type NameFolding uint8
+
+const (
+	// FoldExact matches names exactly.
+	FoldExact NameFolding = iota
+	// FoldASCII matches names ignoring case of ASCII letters.
+	FoldASCII
+	// FoldUnicode matches names under Unicode simple case folding.
+	FoldUnicode
+)
+
+// Fold returns canonical form of name: names match if their folded forms are equal.
+func (f NameFolding) Fold(name string) string {
+	switch f {
+	case FoldASCII:
+		return strings.Map(func(r rune) rune {
+			if 'A' <= r && r <= 'Z' {
+				return r + 'a' - 'A'
+			}
+			return r
+		}, name)
+	case FoldUnicode:
+		return strings.Map(foldRune, name)
+	default:
+		return name
+	}
+}
+
+// foldRune returns the smallest rune of r's case folding orbit.
+func foldRune(r rune) rune {
+	min := r
+	for f := unicode.SimpleFold(r); f != r; f = unicode.SimpleFold(f) {
+		if f < min {
+			min = f
+		}
+	}
+	return min
+}
+
+// resolveName finds value of name in m. An exact match is preferred,
+// otherwise names are compared by folding policy f and more than one
+// match is reported as an error.
+func resolveName[V any](f NameFolding, m map[string]V, name string) (V, bool, error) {
+	key, ok, err := resolveKey(f, m, name)
+	if !ok || err != nil {
+		var zero V
+		return zero, false, err
+	}
+	return m[key], true, nil
+}
+
+// resolveKey finds key of m matching name as resolveName does.
+func resolveKey[V any](f NameFolding, m map[string]V, name string) (string, bool, error) {
+	if _, ok := m[name]; ok {
+		return name, true, nil
+	}
+	if f == FoldExact {
+		return "", false, nil
+	}
+	folded := f.Fold(name)
+	matches := make([]string, 0, 1)
+	for key := range m {
+		if f.Fold(key) == folded {
+			matches = append(matches, key)
+		}
+	}
+	return oneMatch(name, matches)
+}
+
+// oneMatch returns the only name matching name or reports ambiguity.
+func oneMatch(name string, matches []string) (string, bool, error) {
+	switch len(matches) {
+	case 0:
+		return "", false, nil
+	case 1:
+		return matches[0], true, nil
+	default:
+		matches = append([]string(nil), matches...)
+		sort.Strings(matches)
+		return "", false, fmt.Errorf("ambiguous name '%s': matches '%s'", name, strings.Join(matches, "', '"))
+	}
+}
+
+// foldIndex groups names of functions in a registry by their folded form,
+// so functions are found without folding every registered name. The index
+// is rebuilt when the registry, its size or the folding policy changes.
+type foldIndex struct {
+	mu       sync.Mutex
+	registry *functions.Registry
+	folding  NameFolding
+	size     int
+	names    map[string][]string
+}
+
+// resolve finds name of the function in registry matching name under folding.
+func (x *foldIndex) resolve(registry *functions.Registry, folding NameFolding, name string) (string, bool, error) {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	if x.names == nil || x.registry != registry || x.folding != folding || x.size != registry.Len() {
+		x.registry, x.folding, x.size = registry, folding, registry.Len()
+		x.names = make(map[string][]string, x.size)
+		for key := range registry.All() {
+			folded := folding.Fold(key)
+			x.names[folded] = append(x.names[folded], key)
+		}
+	}
+	return oneMatch(name, x.names[folding.Fold(name)])
+}
+
+// SetNameFolding sets policy of matching function and variable names.
+// Use CheckNames to find names that became ambiguous under the policy.
+func (e *Interpreter) SetNameFolding(folding NameFolding) {
+	e.folding = folding
+}
+
+// SetIdentChars sets characters allowed in names of formulas besides
+// letters, digits and underscore, see Lexer.SetIdentChars.
+func (e *Interpreter) SetIdentChars(chars string) {
+	e.identChars = chars
+}
+
+// CheckNames reports variables and functions which names can't be
+// told apart under the name folding policy.
+func (e *Interpreter) CheckNames() error {
+	var conflicts []string
+	variableNames := make(map[string]struct{}, len(e.variables))
+	for name := range e.variables {
+		variableNames[name] = struct{}{}
+	}
+	functionNames := make(map[string]struct{}, len(e.functions))
+	for name := range e.functions {
+		functionNames[name] = struct{}{}
+	}
+	if e.env != nil {
+		for name := range e.env.meta.fields {
+			variableNames[name] = struct{}{}
+		}
+		for name := range e.env.meta.methods {
+			functionNames[name] = struct{}{}
+		}
+	}
+	conflicts = append(conflicts, ambiguousNames(e.folding, "variables", variableNames)...)
+	if e.registry != nil {
+		for name := range e.registry.All() {
+			functionNames[name] = struct{}{}
+		}
+	}
+	conflicts = append(conflicts, ambiguousNames(e.folding, "functions", functionNames)...)
+	if len(conflicts) != 0 {
+		return fmt.Errorf("ambiguous names: %s", strings.Join(conflicts, "; "))
+	}
+	return nil
+}
+
+func ambiguousNames[V any](f NameFolding, kind string, m map[string]V) []string {
+	groups := map[string][]string{}
+	for name := range m {
+		folded := f.Fold(name)
+		groups[folded] = append(groups[folded], name)
+	}
+	conflicts := make([]string, 0)
+	for _, names := range groups {
+		if len(names) > 1 {
+			sort.Strings(names)
+			conflicts = append(conflicts, fmt.Sprintf("%s '%s'", kind, strings.Join(names, "', '")))
+		}
+	}
+	sort.Strings(conflicts)
+	return conflicts
+}
@@ -0,0 +1,137 @@
+package go_interpreter
+
+import (
+	"github.com/kovalenkong/go-interpreter/functions"
+	"testing"
+)
+
+func TestNameFolding_Fold(t *testing.T) {
+	cases := []struct {
+		folding  NameFolding
+		left     string
+		right    string
+		expected bool
+	}{
+		{FoldExact, "Sum", "Sum", true},
+		{FoldExact, "Sum", "SUM", false},
+		{FoldASCII, "Sum", "SUM", true},
+		{FoldASCII, "Цена", "ЦЕНА", false},
+		{FoldUnicode, "Цена", "ЦЕНА", true},
+		{FoldUnicode, "Kelvin", "Kelvin", true},
+		{FoldUnicode, "Sum", "Sun", false},
+	}
+	for _, c := range cases {
+		if res := c.folding.Fold(c.left) == c.folding.Fold(c.right); res != c.expected {
+			t.Errorf("folding %d of '%s' and '%s': expected %v, got %v", c.folding, c.left, c.right, c.expected, res)
+		}
+	}
+}
+
+func TestInterpreter_NameFolding(t *testing.T) {
+	interpreter := NewInterpreter(
+		map[string]any{"Price": 10.0, "Цена": 2.0},
+		map[string]Func{"And": functions.And, "Sum": functions.Sum},
+	)
+	if _, err := interpreter.Execute(`AND(price > 1)`); err == nil {
+		t.Errorf("expected error with exact names")
+	}
+	interpreter.SetNameFolding(FoldASCII)
+	res, err := interpreter.Execute(`AND(price > 1; sum(PRICE) = 10)`)
+	if err != nil {
+		t.Fatalf("expected nil error, got %s", err)
+	}
+	if res != true {
+		t.Errorf("expected true, got %v", res)
+	}
+	if _, err := interpreter.Execute(`ЦЕНА`); err == nil {
+		t.Errorf("expected error with ASCII folding")
+	}
+	interpreter.SetNameFolding(FoldUnicode)
+	if res, err := interpreter.Execute(`ЦЕНА * 2`); err != nil || res != 4. {
+		t.Errorf("expected 4, got %v (%v)", res, err)
+	}
+	if err := interpreter.Validate(`SUM(цена; price)`); err != nil {
+		t.Errorf("expected nil error, got %s", err)
+	}
+	program, err := interpreter.Compile(`PRICE + 1`)
+	if err != nil {
+		t.Fatalf("expected nil error, got %s", err)
+	}
+	if !program.Numeric() {
+		t.Errorf("expected numeric program")
+	}
+}
+
+func TestInterpreter_CheckNames(t *testing.T) {
+	interpreter := NewDefaultInterpreter(WithNameFolding(FoldASCII))
+	if err := interpreter.CheckNames(); err != nil {
+		t.Fatalf("expected nil error, got %s", err)
+	}
+	if res, err := interpreter.Execute(`sum(1;2)`); err != nil || res != 3. {
+		t.Errorf("expected 3, got %v (%v)", res, err)
+	}
+	interpreter.SetVar("x", 1.0)
+	interpreter.SetVar("X", 2.0)
+	interpreter.SetFunction("Sum", functions.Sum)
+	if err := interpreter.CheckNames(); err == nil {
+		t.Errorf("expected ambiguous names error")
+	}
+	if res, err := interpreter.Execute(`X`); err != nil || res != 2. {
+		t.Errorf("expected exact match 2, got %v (%v)", res, err)
+	}
+	interpreter.SetVar("XX", 1.0)
+	interpreter.SetVar("xX", 1.0)
+	if _, err := interpreter.Execute(`xx`); err == nil {
+		t.Errorf("expected ambiguous variable error")
+	}
+}
+
+func TestInterpreter_NameFoldingRegistry(t *testing.T) {
+	registry := functions.NewRegistry()
+	registry.MustRegister(functions.Definition{Name: "SUM", Func: functions.Sum})
+	interpreter := NewInterpreter(map[string]any{"Price": 10.0}, nil)
+	interpreter.SetRegistry(registry)
+	interpreter.SetNameFolding(FoldASCII)
+	if res, err := interpreter.Execute(`sum(price; 1)`); err != nil || res != 11. {
+		t.Errorf("expected 11, got %v (%v)", res, err)
+	}
+	registry.MustRegister(functions.Definition{Name: "Double", Func: func(args ...any) (any, error) {
+		return args[0].(float64) * 2, nil
+	}})
+	if res, err := interpreter.Execute(`DOUBLE(price)`); err != nil || res != 20. {
+		t.Errorf("expected function registered after the first lookup, got %v (%v)", res, err)
+	}
+	registry.MustRegister(functions.Definition{Name: "Sum", Func: functions.Sum})
+	if _, err := interpreter.Execute(`sum(1)`); err == nil {
+		t.Errorf("expected ambiguous function error")
+	}
+
+	program, err := interpreter.Compile(`PRICE * 2 + price`)
+	if err != nil {
+		t.Fatalf("expected nil error, got %s", err)
+	}
+	var res float64
+	allocs := testing.AllocsPerRun(100, func() {
+		res, err = program.RunFloat()
+	})
+	if err != nil || res != 30 {
+		t.Errorf("expected 30, got %v (%v)", res, err)
+	}
+	if allocs != 0 {
+		t.Errorf("expected 0 allocations, got %f", allocs)
+	}
+	program, err = interpreter.Compile(`double(PRICE)`)
+	if err != nil {
+		t.Fatalf("expected nil error, got %s", err)
+	}
+	allocs = testing.AllocsPerRun(100, func() {
+		res, err = program.RunFloat()
+	})
+	if err != nil || res != 20 {
+		t.Errorf("expected 20, got %v (%v)", res, err)
+	}
+	// arguments of the call, the boxed argument and the result
+	if allocs > 3 {
+		t.Errorf("expected at most 3 allocations, got %f", allocs)
+	}
+}
@@ -0,0 +1,145 @@
+package go_interpreter
+
+import (
+	"github.com/kovalenkong/go-interpreter/functions"
+	"math/rand"
+	"time"
+)
+
+// An Option configures interpreter created by NewDefaultInterpreter.
+type Option func(*options)
+
+type options struct {
+	include []string
+	exclude []string
+	folding NameFolding
+	chars   string
+	config  functions.Config
+	decimal *functions.DecimalContext
+}
+
+// IncludeCategories limits standard functions to the given categories.
+func IncludeCategories(categories ...string) Option {
+	return func(o *options) {
+		o.include = append(o.include, categories...)
+	}
+}
+
+// ExcludeCategories removes standard functions of the given categories.
+func ExcludeCategories(categories ...string) Option {
+	return func(o *options) {
+		o.exclude = append(o.exclude, categories...)
+	}
+}
+
+// WithNameFolding sets policy of matching function and variable names.
+func WithNameFolding(folding NameFolding) Option {
+	return func(o *options) {
+		o.folding = folding
+	}
+}
+
+// WithIdentChars sets characters allowed in names besides letters, digits and underscore.
+func WithIdentChars(chars string) Option {
+	return func(o *options) {
+		o.chars = chars
+	}
+}
+
+// WithClock sets source of current time for TODAY and NOW functions.
+func WithClock(clock func() time.Time) Option {
+	return func(o *options) {
+		o.config.Clock = clock
+	}
+}
+
+// WithLocation sets location of dates created by date functions.
+func WithLocation(loc *time.Location) Option {
+	return func(o *options) {
+		o.config.Location = loc
+	}
+}
+
+// WithSerialDates makes date functions return Excel serial numbers instead of time.Time.
+func WithSerialDates() Option {
+	return func(o *options) {
+		o.config.SerialDates = true
+	}
+}
+
+// WithSolver sets tolerance and iteration limit of IRR, XIRR and RATE solvers.
+func WithSolver(tolerance float64, maxIterations int) Option {
+	return func(o *options) {
+		o.config.Solver = functions.Solver{Tolerance: tolerance, MaxIterations: maxIterations}
+	}
+}
+
+// WithRegex sets cache size and limits of pattern and text length of regular expression functions.
+func WithRegex(regex functions.Regex) Option {
+	return func(o *options) {
+		o.config.Regex = regex
+	}
+}
+
+// WithRandom sets source of random functions.
+func WithRandom(source rand.Source) Option {
+	return func(o *options) {
+		o.config.Random = source
+	}
+}
+
+// WithSeed seeds source of random functions for reproducible results.
+func WithSeed(seed int64) Option {
+	return WithRandom(rand.NewSource(seed))
+}
+
+// WithRates sets exchange rates used by EXCHANGE to convert money.
+func WithRates(rates functions.ExchangeRates) Option {
+	return func(o *options) {
+		o.config.Rates = rates
+	}
+}
+
+// WithUnits sets table of units used by QUANTITY and CONVERT.
+func WithUnits(units *functions.Units) Option {
+	return func(o *options) {
+		o.config.Units = units
+	}
+}
+
+// WithDecimal turns on decimal mode with scale and rounding mode of division.
+func WithDecimal(scale int32, rounding functions.RoundingMode) Option {
+	return func(o *options) {
+		o.decimal = &functions.DecimalContext{Scale: scale, Rounding: rounding}
+	}
+}
+
+// NewDefaultInterpreter returns interpreter without variables
+// and with standard library of functions.
+func NewDefaultInterpreter(opts ...Option) *Interpreter {
+	o := &options{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	registry := functions.NewStandard(o.config)
+	if len(o.include) != 0 || len(o.exclude) != 0 {
+		registry = registry.Filter(func(def *functions.Definition) bool {
+			return (len(o.include) == 0 || contains(o.include, def.Category)) && !contains(o.exclude, def.Category)
+		})
+	}
+	interpreter := NewInterpreter(map[string]any{}, map[string]Func{})
+	interpreter.SetRegistry(registry)
+	interpreter.SetNameFolding(o.folding)
+	interpreter.SetIdentChars(o.chars)
+	interpreter.SetDecimalMode(o.decimal)
+	return interpreter
+}
+
+func contains(values []string, value string) bool {
+	for _, el := range values {
+		if el == value {
+			return true
+		}
+	}
+	return false
+}
@@ -45,12 +45,14 @@ loop:
 	for {
 		switch t := p.curToken().Type; t {
 		case EQ, LT, GT, LTE, GTE:
+			pos := p.curToken().pos
 			p.next()
 			right, err := p.parseAddSub()
 			if err != nil {
 				return nil, err
 			}
 			result = &Comparison{
+				pos:   pos,
 				Left:  result,
 				Right: right,
 				Op:    t,
@@ -71,12 +73,14 @@ loop:
 	for {
 		switch t := p.curToken().Type; t {
 		case ADD, SUB:
+			pos := p.curToken().pos
 			p.next()
 			right, err := p.parseMultiplication()
 			if err != nil {
 				return nil, err
 			}
 			result = &BinaryExpr{
+				pos:   pos,
 				Left:  result,
 				Right: right,
 				Op:    t,
@@ -94,12 +98,14 @@ func (p *Parser) parseMultiplication() (Node, error) {
 		return nil, err
 	}
 	for p.curToken().Type == MUL {
+		pos := p.curToken().pos
 		p.next()
 		right, err := p.parseDivision()
 		if err != nil {
 			return nil, err
 		}
 		res = &BinaryExpr{
+			pos:   pos,
 			Left:  res,
 			Right: right,
 			Op:    MUL,
@@ -114,12 +120,14 @@ func (p *Parser) parseDivision() (Node, error) {
 		return nil, err
 	}
 	for p.curToken().Type == DIV {
+		pos := p.curToken().pos
 		p.next()
 		right, err := p.parseExponentiation()
 		if err != nil {
 			return nil, err
 		}
 		res = &BinaryExpr{
+			pos:   pos,
 			Left:  res,
 			Right: right,
 			Op:    DIV,
@@ -134,12 +142,14 @@ func (p *Parser) parseExponentiation() (Node, error) {
 		return nil, err
 	}
 	for p.curToken().Type == EXP {
+		pos := p.curToken().pos
 		p.next()
 		right, err := p.parseHighestPriority()
 		if err != nil {
 			return nil, err
 		}
 		res = &BinaryExpr{
+			pos:   pos,
 			Left:  res,
 			Right: right,
 			Op:    EXP,
@@ -161,52 +171,34 @@ func (p *Parser) parseHighestPriority() (Node, error) {
 			return nil, fmt.Errorf("missing ')', got %d", t.Type)
 		}
 		p.next()
-		return res, nil
+		return p.parsePostfix(res)
 	case NUMBER, STRING: // literal
 		p.next()
 		return &Literal{
+			pos:   token.pos,
 			Kind:  token.Type,
 			Value: token.Value,
 		}, nil
 	case IDENT:
-		// if the next token is a bracket, then parse the function
-		if p.nextToken().Type == LPAREN {
-			p.next()
-			// токен = LPAREN
-			args := make([]Node, 0)
-		argsLoop:
-			for {
-				p.next()
-				if p.curToken().Type == RPAREN {
-					// если аргументов больше нет
-					break
-				}
-				res, err := p.parseComparison()
-				if err != nil {
-					return nil, err
-				}
-				args = append(args, res)
-				switch t := p.curToken(); t.Type {
-				case RPAREN:
-					break argsLoop
-				case DELIMITER:
-					continue
-				default:
-					return nil, fmt.Errorf("expected ; or ) at the end of the function, got %d", t.Type)
-				}
+		// if the next token is a bracket, then parse the function,
+		// a dotted name followed by a bracket is a function like STDEV.S
+		if name, n := p.dottedName(); p.tokens[p.pos+n].Type == LPAREN {
+			p.pos += n
+			args, err := p.parseArgs()
+			if err != nil {
+				return nil, err
 			}
-			p.next()
-			return &Function{
-				Name: token.Value,
+			return p.parsePostfix(&Function{
+				pos:  token.pos,
+				Name: name,
 				Args: args,
-			}, nil
-
-		} else {
-			p.next()
-			return &Ident{
-				Name: token.Value,
-			}, nil
+			})
 		}
+		p.next()
+		return p.parsePostfix(&Ident{
+			pos:  token.pos,
+			Name: token.Value,
+		})
 	case ADD, SUB: // unary +-
 		p.next()
 		res, err := p.parseHighestPriority()
@@ -214,9 +206,88 @@ func (p *Parser) parseHighestPriority() (Node, error) {
 			return nil, err
 		}
 		return &UnaryExpr{
+			pos:  token.pos,
 			Left: res,
 			Op:   token.Type,
 		}, nil
 	}
 	return nil, fmt.Errorf("unexpected token: %d", token.Type)
 }
+
+// dottedName returns name of identifiers joined by dots starting at the
+// current token and the number of tokens it takes.
+func (p *Parser) dottedName() (string, uint) {
+	name := p.curToken().Value
+	n := uint(1)
+	for p.tokens[p.pos+n].Type == DOT && p.tokens[p.pos+n+1].Type == IDENT {
+		name += "." + p.tokens[p.pos+n+1].Value
+		n += 2
+	}
+	return name, n
+}
+
+// parseArgs parses arguments of a function starting at the opening bracket.
+func (p *Parser) parseArgs() ([]Node, error) {
+	// токен = LPAREN
+	args := make([]Node, 0)
+argsLoop:
+	for {
+		p.next()
+		if p.curToken().Type == RPAREN {
+			// если аргументов больше нет
+			break
+		}
+		res, err := p.parseComparison()
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, res)
+		switch t := p.curToken(); t.Type {
+		case RPAREN:
+			break argsLoop
+		case DELIMITER:
+			continue
+		default:
+			return nil, fmt.Errorf("expected ; or ) at the end of the function, got %d", t.Type)
+		}
+	}
+	p.next()
+	return args, nil
+}
+
+// parsePostfix parses member and index access following node: a.b[0].
+func (p *Parser) parsePostfix(node Node) (Node, error) {
+	for {
+		switch token := p.curToken(); token.Type {
+		case DOT:
+			p.next()
+			name := p.curToken()
+			if name.Type != IDENT {
+				return nil, fmt.Errorf("expected field name after '.' at position %d, got %d", token.pos, name.Type)
+			}
+			p.next()
+			node = &Member{
+				pos:    token.pos,
+				Object: node,
+				Name:   name.Value,
+			}
+		case LBRACKET:
+			p.next()
+			index, err := p.parseComparison()
+			if err != nil {
+				return nil, err
+			}
+			if t := p.curToken(); t.Type != RBRACKET {
+				return nil, fmt.Errorf("missing ']', got %d", t.Type)
+			}
+			p.next()
+			node = &Index{
+				pos:    token.pos,
+				Object: node,
+				Index:  index,
+			}
+		default:
+			return node, nil
+		}
+	}
+}
@@ -107,3 +107,65 @@ func TestParser_ParseBool(t *testing.T) {
 		}
 	}
 }
+
+func TestParser_ParseAccess(t *testing.T) {
+	cases := map[string]string{
+		`order.customer.tier`:     "((order.customer).tier)",
+		`items[0].price`:          "((items[0]).price)",
+		`row["Unit Price"] * 2`:   "((row[Unit Price]) * 2)",
+		`CEILING.MATH(x.y; 1)`:    "CEILING.MATH((x.y); 1)",
+		`Sum(a.b)[1]`:             "(Sum((a.b))[1])",
+		`(a)[b.c]`:                "(a[(b.c)])",
+		`PERCENTILE.INC(a; 0,5)`:  "PERCENTILE.INC(a; 0,5)",
+		`a.b + c.d`:               "((a.b) + (c.d))",
+		`-x[1]`:                   "-((x[1]))",
+		`table[i + 1]["Unit"].xs`: "(((table[(i + 1)])[Unit]).xs)",
+	}
+	for formula, expected := range cases {
+		tokens, err := NewLexer().Lex(strings.NewReader(formula))
+		if err != nil {
+			t.Fatalf("formula '%s': expected nil error, got %s", formula, err)
+		}
+		node, err := NewParser().Parse(tokens)
+		if err != nil {
+			t.Fatalf("formula '%s': expected nil error, got %s", formula, err)
+		}
+		if res := dump(node); res != expected {
+			t.Errorf("formula '%s': expected %s, got %s", formula, expected, res)
+		}
+	}
+	for _, formula := range []string{`a.`, `a.1`, `a[1`, `a[]`, `1.5`, `a..b`} {
+		tokens, err := NewLexer().Lex(strings.NewReader(formula))
+		if err != nil {
+			continue
+		}
+		if _, err := NewParser().Parse(tokens); err == nil {
+			t.Errorf("formula '%s': expected error", formula)
+		}
+	}
+}
+
+// dump returns text form of the node with explicit grouping.
+func dump(node Node) string {
+	switch n := node.(type) {
+	case *Literal:
+		return n.Value
+	case *Ident:
+		return n.Name
+	case *Member:
+		return "(" + dump(n.Object) + "." + n.Name + ")"
+	case *Index:
+		return "(" + dump(n.Object) + "[" + dump(n.Index) + "])"
+	case *Function:
+		args := make([]string, len(n.Args))
+		for i, arg := range n.Args {
+			args[i] = dump(arg)
+		}
+		return n.Name + "(" + strings.Join(args, "; ") + ")"
+	case *BinaryExpr:
+		return "(" + dump(n.Left) + " " + opName(n.Op) + " " + dump(n.Right) + ")"
+	case *UnaryExpr:
+		return opName(n.Op) + "(" + dump(n.Left) + ")"
+	}
+	return "?"
+}
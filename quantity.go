@@ -0,0 +1,93 @@
+package go_interpreter
+
+import (
+	"fmt"
+	"github.com/kovalenkong/go-interpreter/functions"
+	"math"
+)
+
+func isQuantity(value any) bool {
+	_, ok := value.(functions.Quantity)
+	return ok
+}
+
+// scalar returns plain number operand as float64.
+func scalar(value any) (float64, bool) {
+	switch val := value.(type) {
+	case float64:
+		return val, true
+	case functions.Decimal:
+		return val.Float64(), true
+	default:
+		return 0, false
+	}
+}
+
+// quantityOp applies arithmetic operator when at least one operand is a quantity.
+// Quantities of the same dimension may be added and subtracted, units of
+// products, quotients and integer powers are derived from units of operands.
+func (e *Interpreter) quantityOp(op TokenType, left, right any) (any, error) {
+	l, lquantity := left.(functions.Quantity)
+	r, rquantity := right.(functions.Quantity)
+	switch {
+	case lquantity && rquantity:
+		switch op {
+		case ADD:
+			return result(l.Add(r))
+		case SUB:
+			return result(l.Sub(r))
+		case MUL:
+			return l.Mul(r)
+		case DIV:
+			return l.Div(r)
+		}
+	case lquantity:
+		if k, ok := scalar(right); ok {
+			switch op {
+			case MUL:
+				return l.Scale(k), nil
+			case DIV:
+				if k == 0 {
+					return nil, fmt.Errorf("zero division error")
+				}
+				return l.Scale(1 / k), nil
+			case EXP:
+				if k != math.Trunc(k) {
+					return nil, fmt.Errorf("power of %s should be an integer, got %v", l.Unit.Symbol, k)
+				}
+				return l.Pow(int(k))
+			}
+		}
+	case rquantity:
+		if k, ok := scalar(left); ok {
+			switch op {
+			case MUL:
+				return r.Scale(k), nil
+			case DIV:
+				return functions.Quantity{Value: k, Unit: functions.Unit{Factor: 1}}.Div(r)
+			}
+		}
+	}
+	return nil, fmt.Errorf("unsupported operation %s for %s and %s", opName(op), typeName(left), typeName(right))
+}
+
+// compareQuantities compares quantities of the same dimension.
+// Quantity is not equal to other values.
+func compareQuantities(op TokenType, left, right any) (any, error) {
+	l, lquantity := left.(functions.Quantity)
+	r, rquantity := right.(functions.Quantity)
+	if !lquantity || !rquantity {
+		if op == EQ {
+			return false, nil
+		}
+		return nil, fmt.Errorf("can't compare %s and %s", typeName(left), typeName(right))
+	}
+	if op == EQ && !l.Unit.Compatible(r.Unit) {
+		return false, nil
+	}
+	cmp, err := l.Cmp(r)
+	if err != nil {
+		return nil, err
+	}
+	return compare(cmp, 0, op)
+}
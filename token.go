@@ -24,4 +24,8 @@ const (
 	GT  // >
 	LTE // <=
 	GTE // >=
+
+	DOT      // .
+	LBRACKET // [
+	RBRACKET // ]
 )
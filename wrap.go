@@ -0,0 +1,214 @@
+package go_interpreter
+
+import (
+	"fmt"
+	"github.com/kovalenkong/go-interpreter/functions"
+	"math"
+	"reflect"
+	"runtime"
+	"strings"
+	"time"
+)
+
+var (
+	funcType  = reflect.TypeOf(Func(nil))
+	errorType = reflect.TypeOf((*error)(nil)).Elem()
+	anyType   = reflect.TypeOf((*any)(nil)).Elem()
+	timeType  = reflect.TypeOf(time.Time{})
+)
+
+// WrapFunc adapts Go function to Func. Arguments of formulas are
+// converted to parameter types of fn: numbers to numeric parameters
+// (integer parameters require integer numbers), arrays to slices, Blank
+// to zero values; interface parameters receive values as is. Variadic
+// functions are supported. fn should return a value and optionally an
+// error as the second result, a single error result is not a value. The
+// number and types of arguments are checked on every call and reported
+// as #VALUE! errors. Command cmd/wrapgen generates the same adapters
+// without reflection.
+func WrapFunc(fn any) (Func, error) {
+	v := reflect.ValueOf(fn)
+	if v.Kind() != reflect.Func || v.IsNil() {
+		return nil, fmt.Errorf("expected function, got %T", fn)
+	}
+	bind, err := wrapper(funcName(v), v.Type(), 0)
+	if err != nil {
+		return nil, err
+	}
+	return bind(v), nil
+}
+
+// funcName returns name of function without its package path. Closures
+// are named after enclosing functions, like "Outer.func1".
+func funcName(fn reflect.Value) string {
+	name := runtime.FuncForPC(fn.Pointer()).Name()
+	name = name[strings.LastIndex(name, "/")+1:]
+	name = name[strings.Index(name, ".")+1:]
+	return strings.TrimSuffix(name, "-fm")
+}
+
+// wrapper returns constructor of Func calling function values of type t.
+// The first skip parameters are bound already, like receivers of methods.
+func wrapper(name string, t reflect.Type, skip int) (func(fn reflect.Value) Func, error) {
+	if t.NumIn() == skip+1 && t.IsVariadic() && t.In(skip) == reflect.TypeOf([]any(nil)) &&
+		t.NumOut() == 2 && t.Out(0) == anyType && t.Out(1) == errorType {
+		return func(fn reflect.Value) Func {
+			return fn.Convert(funcType).Interface().(Func)
+		}, nil
+	}
+	switch {
+	case t.NumOut() == 0 || t.NumOut() == 1 && t.Out(0) == errorType:
+		return nil, fmt.Errorf("%s: function should return a value", name)
+	case t.NumOut() > 2 || t.NumOut() == 2 && t.Out(1) != errorType:
+		return nil, fmt.Errorf("%s: function should return a value and optionally an error", name)
+	}
+	params := make([]reflect.Type, t.NumIn()-skip)
+	for i := range params {
+		params[i] = t.In(i + skip)
+		param := params[i]
+		if t.IsVariadic() && i == len(params)-1 {
+			param = param.Elem()
+		}
+		if !convertible(param) {
+			return nil, fmt.Errorf("%s: unsupported type %s of parameter %d", name, param, i+1)
+		}
+	}
+	variadic := t.IsVariadic()
+	return func(fn reflect.Value) Func {
+		return func(args ...any) (any, error) {
+			switch {
+			case variadic && len(args) < len(params)-1:
+				return nil, fmt.Errorf("%w %s: expected at least %d arguments, got %d", functions.ErrValue, name, len(params)-1, len(args))
+			case !variadic && len(args) != len(params):
+				return nil, fmt.Errorf("%w %s: expected %d arguments, got %d", functions.ErrValue, name, len(params), len(args))
+			}
+			in := make([]reflect.Value, len(args))
+			for i, arg := range args {
+				var param reflect.Type
+				if variadic && i >= len(params)-1 {
+					param = params[len(params)-1].Elem()
+				} else {
+					param = params[i]
+				}
+				val, err := convert(arg, param)
+				if err != nil {
+					return nil, fmt.Errorf("%w %s: argument %d: %s", functions.ErrValue, name, i+1, err)
+				}
+				in[i] = val
+			}
+			out := fn.Call(in)
+			if len(out) == 2 && !out[1].IsNil() {
+				return nil, out[1].Interface().(error)
+			}
+			return plainValue(out[0]), nil
+		}
+	}, nil
+}
+
+// convertible reports whether values of formulas can be converted to type t.
+func convertible(t reflect.Type) bool {
+	switch t {
+	case reflect.TypeOf(functions.Decimal{}), reflect.TypeOf(functions.Money{}), reflect.TypeOf(functions.Quantity{}), timeType:
+		return true
+	}
+	switch t.Kind() {
+	case reflect.Bool, reflect.String, reflect.Interface, reflect.Complex64, reflect.Complex128:
+		return true
+	case reflect.Slice:
+		return convertible(t.Elem())
+	}
+	return numericKind(t.Kind())
+}
+
+// convert converts value of formula to type t.
+func convert(value any, t reflect.Type) (reflect.Value, error) {
+	if t.Kind() == reflect.Interface && value != nil {
+		if !reflect.TypeOf(value).Implements(t) {
+			return reflect.Value{}, fmt.Errorf("expected %s, got %s", t, typeName(value))
+		}
+		return reflect.ValueOf(value), nil
+	}
+	if value == nil || value == functions.Blank {
+		return reflect.Zero(t), nil
+	}
+	if d, ok := value.(functions.Decimal); ok && t != reflect.TypeOf(d) {
+		value = d.Float64()
+	}
+	v := reflect.ValueOf(value)
+	if v.Type() == t {
+		return v, nil
+	}
+	mismatch := fmt.Errorf("expected %s, got %s", t, typeName(value))
+	switch kind := t.Kind(); {
+	case t == reflect.TypeOf(functions.Decimal{}):
+		x, ok := value.(float64)
+		if !ok {
+			return reflect.Value{}, mismatch
+		}
+		d, err := functions.DecimalFromFloat(x)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		return reflect.ValueOf(d), nil
+	case kind == reflect.Struct:
+		return reflect.Value{}, mismatch
+	case kind == reflect.Slice:
+		elements := reflect.ValueOf(value)
+		if elements.Kind() != reflect.Slice {
+			return reflect.Value{}, mismatch
+		}
+		res := reflect.MakeSlice(t, elements.Len(), elements.Len())
+		for i := 0; i < elements.Len(); i++ {
+			el, err := convert(elements.Index(i).Interface(), t.Elem())
+			if err != nil {
+				return reflect.Value{}, fmt.Errorf("element %d: %s", i+1, err)
+			}
+			res.Index(i).Set(el)
+		}
+		return res, nil
+	case kind == reflect.Complex64 || kind == reflect.Complex128:
+		if x, ok := value.(float64); ok {
+			value = complex(x, 0)
+		}
+	case numericKind(kind):
+		x, ok := value.(float64)
+		if !ok {
+			return reflect.Value{}, mismatch
+		}
+		return number(x, t)
+	}
+	v = reflect.ValueOf(value)
+	if v.Kind() != t.Kind() {
+		return reflect.Value{}, mismatch
+	}
+	return v.Convert(t), nil
+}
+
+// number converts x to numeric type t checking that the type can hold it.
+func number(x float64, t reflect.Type) (reflect.Value, error) {
+	v := reflect.New(t).Elem()
+	switch t.Kind() {
+	case reflect.Float32, reflect.Float64:
+		if v.OverflowFloat(x) {
+			return reflect.Value{}, fmt.Errorf("%v overflows %s", x, t)
+		}
+		v.SetFloat(x)
+		return v, nil
+	}
+	if x != math.Trunc(x) {
+		return reflect.Value{}, fmt.Errorf("expected integer, got %v", x)
+	}
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if x < math.MinInt64 || x >= math.MaxInt64 || v.OverflowInt(int64(x)) {
+			return reflect.Value{}, fmt.Errorf("%v overflows %s", x, t)
+		}
+		v.SetInt(int64(x))
+	default:
+		if x < 0 || x >= math.MaxUint64 || v.OverflowUint(uint64(x)) {
+			return reflect.Value{}, fmt.Errorf("%v overflows %s", x, t)
+		}
+		v.SetUint(uint64(x))
+	}
+	return v, nil
+}
@@ -0,0 +1,91 @@
+// Code generated by wrapgen; DO NOT EDIT.
+
+package go_interpreter
+
+import (
+	"time"
+)
+
+// discountFunc calls discount with arguments of a formula.
+func discountFunc(args ...any) (any, error) {
+	if err := CheckArity("discount", args, 2, false); err != nil {
+		return nil, err
+	}
+	p0, err := Arg[float64]("discount", args, 0)
+	if err != nil {
+		return nil, err
+	}
+	p1, err := Arg[int]("discount", args, 1)
+	if err != nil {
+		return nil, err
+	}
+	res, err := discount(p0, p1)
+	if err != nil {
+		return nil, err
+	}
+	return Result(res), nil
+}
+
+// joinFunc calls join with arguments of a formula.
+func joinFunc(args ...any) (any, error) {
+	if err := CheckArity("join", args, 2, true); err != nil {
+		return nil, err
+	}
+	p0, err := Arg[string]("join", args, 0)
+	if err != nil {
+		return nil, err
+	}
+	p1, err := VariadicArgs[string]("join", args, 1)
+	if err != nil {
+		return nil, err
+	}
+	return Result(join(p0, p1...)), nil
+}
+
+// totalFunc calls total with arguments of a formula.
+func totalFunc(args ...any) (any, error) {
+	if err := CheckArity("total", args, 1, false); err != nil {
+		return nil, err
+	}
+	p0, err := SliceArg[int]("total", args, 0)
+	if err != nil {
+		return nil, err
+	}
+	return Result(total(p0)), nil
+}
+
+// halfFunc calls half with arguments of a formula.
+func halfFunc(args ...any) (any, error) {
+	if err := CheckArity("half", args, 1, false); err != nil {
+		return nil, err
+	}
+	p0, err := Arg[float32]("half", args, 0)
+	if err != nil {
+		return nil, err
+	}
+	return Result(half(p0)), nil
+}
+
+// weekdayFunc calls weekday with arguments of a formula.
+func weekdayFunc(args ...any) (any, error) {
+	if err := CheckArity("weekday", args, 1, false); err != nil {
+		return nil, err
+	}
+	p0, err := Arg[time.Time]("weekday", args, 0)
+	if err != nil {
+		return nil, err
+	}
+	return Result(weekday(p0)), nil
+}
+
+// firstFunc calls first with arguments of a formula.
+func firstFunc(args ...any) (any, error) {
+	if err := CheckArity("first", args, 1, false); err != nil {
+		return nil, err
+	}
+	p0, err := SliceArg[any]("first", args, 0)
+	if err != nil {
+		return nil, err
+	}
+	return Result(first(p0)), nil
+}
@@ -0,0 +1,185 @@
+package go_interpreter
+
+import (
+	"errors"
+	"fmt"
+	"github.com/kovalenkong/go-interpreter/functions"
+	"strings"
+	"testing"
+	"time"
+)
+
+//go:generate go run ./cmd/wrapgen -tests -output wrap_gen_test.go discount join total half weekday first
+
+func discount(price float64, percent int) (float64, error) {
+	if percent > 100 {
+		return 0, fmt.Errorf("%w percent %d is over 100", functions.ErrNum, percent)
+	}
+	return price * float64(100-percent) / 100, nil
+}
+
+func join(sep string, parts ...string) string { return strings.Join(parts, sep) }
+
+func total(xs []int) int {
+	res := 0
+	for _, x := range xs {
+		res += x
+	}
+	return res
+}
+
+func half(x float32) float32 { return x / 2 }
+
+func weekday(d time.Time) string { return d.Weekday().String() }
+
+func first(values []any) any {
+	if len(values) == 0 {
+		return nil
+	}
+	return values[0]
+}
+
+func TestWrapFunc(t *testing.T) {
+	wrap := func(fn any) Func {
+		function, err := WrapFunc(fn)
+		if err != nil {
+			t.Fatalf("%T: expected nil error, got %s", fn, err)
+		}
+		return function
+	}
+	interpreter := NewDefaultInterpreter()
+	interpreter.SetFunction("Discount", wrap(discount))
+	interpreter.SetFunction("Join", wrap(func(sep string, parts ...string) string { return strings.Join(parts, sep) }))
+	interpreter.SetFunction("Total", wrap(func(xs []int) int {
+		total := 0
+		for _, x := range xs {
+			total += x
+		}
+		return total
+	}))
+	interpreter.SetFunction("Weekday", wrap(func(d time.Time) string { return d.Weekday().String() }))
+	interpreter.SetFunction("Kind", wrap(func(v any) string { return fmt.Sprintf("%T", v) }))
+	interpreter.SetFunction("Not", wrap(func(b bool) bool { return !b }))
+	interpreter.SetFunction("Raw", wrap(functions.Sum))
+	interpreter.SetFunction("Half", wrap(func(x float32) float32 { return x / 2 }))
+	interpreter.SetVar("Nums", []any{1., 2., 3.})
+	cases := map[string]any{
+		`Discount(200; 15)`:            170.,
+		`Join("-"; "a"; "b"; "c")`:     "a-b-c",
+		`Join(",")`:                    "",
+		`Total(Nums)`:                  6.,
+		`Weekday(DATE(2024; 3; 15))`:   "Friday",
+		`Kind("a")`:                    "string",
+		`Not(1 > 2)`:                   true,
+		`Raw(1; 2)`:                    3.,
+		`IFERROR(Discount(1; 101); 0)`: 0.,
+	}
+	for formula, expected := range cases {
+		res, err := interpreter.Execute(formula)
+		if err != nil {
+			t.Fatalf("formula '%s': expected nil error, got %s", formula, err)
+		}
+		if res != expected {
+			t.Errorf("formula '%s': expected %v, got %v", formula, expected, res)
+		}
+	}
+	errs := map[string]string{
+		`Discount(1; 101)`:   "#NUM! percent 101 is over 100",
+		`Discount(1)`:        "#VALUE! discount: expected 2 arguments, got 1",
+		`Discount(1; 2,5)`:   "#VALUE! discount: argument 2: expected integer, got 2.5",
+		`Discount("a"; 1)`:   "#VALUE! discount: argument 1: expected float64, got string",
+		`Join()`:             "expected at least 1 arguments, got 0",
+		`Join("-"; "a"; 1)`:  "argument 3: expected string, got float64",
+		`Total(Nums; 1)`:     "expected 1 arguments",
+		`Total(1)`:           "argument 1: expected []int, got float64",
+		`Weekday(1)`:         "expected time.Time",
+		`Discount(1; 10^30)`: "argument 2: 1e+30 overflows int",
+		`Half(10^300)`:       "overflows float32",
+		`Half("a")`:          "#VALUE! TestWrapFunc.func7: argument 1: expected float32, got string",
+	}
+	for formula, expected := range errs {
+		_, err := interpreter.Execute(formula)
+		if err == nil || !strings.Contains(err.Error(), expected) {
+			t.Errorf("formula '%s': expected error %q, got %v", formula, expected, err)
+		}
+	}
+	if _, err := interpreter.Execute(`Discount(1; 2)`); err != nil {
+		t.Errorf("expected nil error, got %s", err)
+	}
+	if _, err := interpreter.Execute(`Total(Nums) + Discount(1; "x")`); !errors.Is(err, functions.ErrValue) {
+		t.Errorf("expected #VALUE!, got %v", err)
+	}
+
+	for _, fn := range []any{
+		nil,
+		1,
+		func() {},
+		func() error { return nil },
+		func() (int, int) { return 0, 0 },
+		func(f func()) int { return 0 },
+		func(m map[string]int) int { return 0 },
+	} {
+		if _, err := WrapFunc(fn); err == nil {
+			t.Errorf("%T: expected error", fn)
+		}
+	}
+}
+
+func TestGeneratedWrappers(t *testing.T) {
+	generated := map[string][2]any{
+		"Discount": {discount, discountFunc},
+		"Join":     {join, joinFunc},
+		"Total":    {total, totalFunc},
+		"Half":     {half, halfFunc},
+		"Weekday":  {weekday, weekdayFunc},
+		"First":    {first, firstFunc},
+	}
+	reflected, direct := NewDefaultInterpreter(), NewDefaultInterpreter()
+	for name, fns := range generated {
+		function, err := WrapFunc(fns[0])
+		if err != nil {
+			t.Fatalf("%s: expected nil error, got %s", name, err)
+		}
+		reflected.SetFunction(name, function)
+		direct.SetFunction(name, fns[1].(func(args ...any) (any, error)))
+	}
+	for _, interpreter := range []*Interpreter{reflected, direct} {
+		interpreter.SetVar("Nums", []any{1., 2., 3.})
+		interpreter.SetVar("Floats", []float64{1, 2.5})
+		interpreter.SetVar("Mixed", []any{"a", 1.})
+		interpreter.SetVar("Empty", []any{})
+	}
+	formulas := []string{
+		`Discount(200; 15)`,
+		`Discount(1; 101)`,
+		`Discount(1)`,
+		`Discount(1; 2,5)`,
+		`Discount("a"; 1)`,
+		`Discount(1; 10^30)`,
+		`Join("-"; "a"; "b")`,
+		`Join(",")`,
+		`Join()`,
+		`Join("-"; "a"; 1)`,
+		`Total(Nums)`,
+		`Total(Floats)`,
+		`Total(Mixed)`,
+		`Total(1)`,
+		`Half(3)`,
+		`Half(10^300)`,
+		`Weekday(DATE(2024; 3; 15))`,
+		`Weekday(1)`,
+		`First(Mixed)`,
+		`First(Floats)`,
+		`First(Empty)`,
+	}
+	for _, formula := range formulas {
+		expected, expectedErr := reflected.Execute(formula)
+		if expectedErr != nil && !errors.Is(expectedErr, functions.ErrValue) && !errors.Is(expectedErr, functions.ErrNum) {
+			t.Fatalf("formula '%s': unexpected error %s", formula, expectedErr)
+		}
+		res, err := direct.Execute(formula)
+		if fmt.Sprint(res) != fmt.Sprint(expected) || fmt.Sprint(err) != fmt.Sprint(expectedErr) {
+			t.Errorf("formula '%s': expected %v (%v), got %v (%v)", formula, expected, expectedErr, res, err)
+		}
+	}
+}
@@ -0,0 +1,241 @@
+package go_interpreter
+
+import (
+	"fmt"
+	"github.com/kovalenkong/go-interpreter/functions"
+	"math"
+	"time"
+)
+
+// Helpers below are called by wrappers generated with cmd/wrapgen. They
+// convert arguments and results the same way WrapFunc does, but with type
+// switches instead of reflection.
+
+// CheckArity checks the number of arguments of function name with params
+// parameters, the last of them may be variadic.
+func CheckArity(name string, args []any, params int, variadic bool) error {
+	switch {
+	case variadic && len(args) < params-1:
+		return fmt.Errorf("%w %s: expected at least %d arguments, got %d", functions.ErrValue, name, params-1, len(args))
+	case !variadic && len(args) != params:
+		return fmt.Errorf("%w %s: expected %d arguments, got %d", functions.ErrValue, name, params, len(args))
+	}
+	return nil
+}
+
+// Arg converts zero-based argument i of function name to type T.
+func Arg[T any](name string, args []any, i int) (T, error) {
+	res, err := argValue[T](args[i])
+	if err != nil {
+		return res, fmt.Errorf("%w %s: argument %d: %s", functions.ErrValue, name, i+1, err)
+	}
+	return res, nil
+}
+
+// SliceArg converts zero-based argument i of function name to a slice of T.
+func SliceArg[T any](name string, args []any, i int) ([]T, error) {
+	res, err := argSlice[T](args[i])
+	if err != nil {
+		return nil, fmt.Errorf("%w %s: argument %d: %s", functions.ErrValue, name, i+1, err)
+	}
+	return res, nil
+}
+
+// VariadicArgs converts arguments of function name starting with
+// zero-based position from to a slice of T.
+func VariadicArgs[T any](name string, args []any, from int) ([]T, error) {
+	res := make([]T, len(args)-from)
+	for i := range res {
+		val, err := Arg[T](name, args, from+i)
+		if err != nil {
+			return nil, err
+		}
+		res[i] = val
+	}
+	return res, nil
+}
+
+// Result converts result of a wrapped function to a value of formulas:
+// numbers become float64, slices of numbers become []float64 and other
+// slices become arrays.
+func Result[T any](value T) any {
+	switch val := any(value).(type) {
+	case nil:
+		return functions.Blank
+	case int:
+		return float64(val)
+	case int8:
+		return float64(val)
+	case int16:
+		return float64(val)
+	case int32:
+		return float64(val)
+	case int64:
+		return float64(val)
+	case uint:
+		return float64(val)
+	case uint8:
+		return float64(val)
+	case uint16:
+		return float64(val)
+	case uint32:
+		return float64(val)
+	case uint64:
+		return float64(val)
+	case float32:
+		return float64(val)
+	case complex64:
+		return complex128(val)
+	case []int:
+		numbers := make([]float64, len(val))
+		for i, x := range val {
+			numbers[i] = float64(x)
+		}
+		return numbers
+	case []string:
+		values := make([]any, len(val))
+		for i, x := range val {
+			values[i] = x
+		}
+		return values
+	case []bool:
+		values := make([]any, len(val))
+		for i, x := range val {
+			values[i] = x
+		}
+		return values
+	}
+	return value
+}
+
+// argSlice converts array of formulas to a slice of T.
+func argSlice[T any](value any) ([]T, error) {
+	if value == nil || value == functions.Blank {
+		return nil, nil
+	}
+	var elements []any
+	switch val := value.(type) {
+	case []any:
+		elements = val
+	case []float64:
+		elements = make([]any, len(val))
+		for i, x := range val {
+			elements[i] = x
+		}
+	default:
+		return nil, fmt.Errorf("expected %T, got %s", []T(nil), typeName(value))
+	}
+	res := make([]T, len(elements))
+	for i, el := range elements {
+		val, err := argValue[T](el)
+		if err != nil {
+			return nil, fmt.Errorf("element %d: %s", i+1, err)
+		}
+		res[i] = val
+	}
+	return res, nil
+}
+
+// argValue converts value of formulas to type T as convert does.
+func argValue[T any](value any) (T, error) {
+	var res T
+	if p, ok := any(&res).(*any); ok {
+		*p = value
+		return res, nil
+	}
+	if value == nil || value == functions.Blank {
+		return res, nil
+	}
+	if d, ok := value.(functions.Decimal); ok {
+		if _, decimal := any(res).(functions.Decimal); !decimal {
+			value = d.Float64()
+		}
+	}
+	if val, ok := value.(T); ok {
+		return val, nil
+	}
+	mismatch := fmt.Errorf("expected %T, got %s", res, typeName(value))
+	var err error
+	switch p := any(&res).(type) {
+	case *functions.Decimal:
+		x, ok := value.(float64)
+		if !ok {
+			return res, mismatch
+		}
+		*p, err = functions.DecimalFromFloat(x)
+	case *complex128:
+		x, ok := value.(float64)
+		if !ok {
+			return res, mismatch
+		}
+		*p = complex(x, 0)
+	case *complex64:
+		switch x := value.(type) {
+		case float64:
+			*p = complex64(complex(x, 0))
+		case complex128:
+			*p = complex64(x)
+		default:
+			return res, mismatch
+		}
+	case *float32, *int, *int8, *int16, *int32, *int64, *uint, *uint8, *uint16, *uint32, *uint64:
+		x, ok := value.(float64)
+		if !ok {
+			return res, mismatch
+		}
+		err = setNumber(p, x)
+	case *bool, *string, *float64, *time.Time, *functions.Money, *functions.Quantity:
+		return res, mismatch
+	default:
+		return res, fmt.Errorf("unsupported type %T", res)
+	}
+	return res, err
+}
+
+// setNumber stores x to pointer p of numeric type checking that the type can hold it.
+func setNumber(p any, x float64) error {
+	if f, ok := p.(*float32); ok {
+		if math.Abs(x) > math.MaxFloat32 && !math.IsInf(x, 0) {
+			return fmt.Errorf("%v overflows float32", x)
+		}
+		*f = float32(x)
+		return nil
+	}
+	if x != math.Trunc(x) {
+		return fmt.Errorf("expected integer, got %v", x)
+	}
+	var err error
+	switch p := p.(type) {
+	case *int:
+		*p, err = integer[int](x, math.MinInt, math.MaxInt, "int")
+	case *int8:
+		*p, err = integer[int8](x, math.MinInt8, math.MaxInt8, "int8")
+	case *int16:
+		*p, err = integer[int16](x, math.MinInt16, math.MaxInt16, "int16")
+	case *int32:
+		*p, err = integer[int32](x, math.MinInt32, math.MaxInt32, "int32")
+	case *int64:
+		*p, err = integer[int64](x, math.MinInt64, math.MaxInt64, "int64")
+	case *uint:
+		*p, err = integer[uint](x, 0, math.MaxUint, "uint")
+	case *uint8:
+		*p, err = integer[uint8](x, 0, math.MaxUint8, "uint8")
+	case *uint16:
+		*p, err = integer[uint16](x, 0, math.MaxUint16, "uint16")
+	case *uint32:
+		*p, err = integer[uint32](x, 0, math.MaxUint32, "uint32")
+	case *uint64:
+		*p, err = integer[uint64](x, 0, math.MaxUint64, "uint64")
+	}
+	return err
+}
+
+// integer converts integer x to type T with bounds [min; max].
+func integer[T int | int8 | int16 | int32 | int64 | uint | uint8 | uint16 | uint32 | uint64](x, min, max float64, name string) (T, error) {
+	// max of 64-bit types rounds up to a power of 2 in float64, so x is
+	// compared with max+1 which is the same power of 2 or exact otherwise
+	if x < min || x >= max+1 {
+		return 0, fmt.Errorf("%v overflows %s", x, name)
+	}
+	return T(x), nil
+}